@@ -0,0 +1,104 @@
+// Scrape audit log plugin status variables.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Subsystem.
+const auditLog = "audit_log"
+
+// auditLogStatusQuery matches both the MySQL Enterprise audit_log
+// (Audit_log_*) and the Percona audit_log_filter (Audit_log_filter_*)
+// status variables.
+const auditLogStatusQuery = `
+	SHOW GLOBAL STATUS LIKE 'Audit_log_%'
+	`
+
+type auditLogMetric struct {
+	desc      *prometheus.Desc
+	valueType prometheus.ValueType
+}
+
+// Metric descriptors, by status variable name without its prefix.
+var auditLogMetrics = map[string]auditLogMetric{
+	"events": {
+		newDesc(auditLog, "events_total", "The number of events handled by the audit log plugin."),
+		prometheus.CounterValue,
+	},
+	"events_written": {
+		newDesc(auditLog, "events_written_total", "The number of events written to the audit log."),
+		prometheus.CounterValue,
+	},
+	"events_lost": {
+		newDesc(auditLog, "events_lost_total", "The number of events lost because they were larger than the audit log buffer."),
+		prometheus.CounterValue,
+	},
+	"events_filtered": {
+		newDesc(auditLog, "events_filtered_total", "The number of events filtered out by the audit log plugin."),
+		prometheus.CounterValue,
+	},
+	"write_waits": {
+		newDesc(auditLog, "write_waits_total", "The number of times an event had to wait for space in the audit log buffer."),
+		prometheus.CounterValue,
+	},
+	"current_size": {
+		newDesc(auditLog, "current_size_bytes", "The size of the current audit log file."),
+		prometheus.GaugeValue,
+	},
+	"total_size": {
+		newDesc(auditLog, "written_bytes_total", "The total size of events written to all audit log files."),
+		prometheus.CounterValue,
+	},
+	"event_max_drop_size": {
+		newDesc(auditLog, "event_max_drop_size_bytes", "The size of the largest dropped event."),
+		prometheus.GaugeValue,
+	},
+}
+
+// ScrapeAuditLog collects from the audit log plugin status variables.
+type ScrapeAuditLog struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeAuditLog) Name() string {
+	return auditLog
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeAuditLog) Help() string {
+	return "Collect audit log plugin status variables from SHOW GLOBAL STATUS"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeAuditLog) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	auditLogRows, err := db.Query(auditLogStatusQuery)
+	if err != nil {
+		return err
+	}
+	defer auditLogRows.Close()
+
+	var (
+		key string
+		val sql.RawBytes
+	)
+	for auditLogRows.Next() {
+		if err := auditLogRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		name := strings.ToLower(key)
+		name = strings.TrimPrefix(name, "audit_log_filter_")
+		name = strings.TrimPrefix(name, "audit_log_")
+		metric, ok := auditLogMetrics[name]
+		if !ok {
+			continue
+		}
+		if floatVal, ok := parseStatus(val); ok {
+			ch <- prometheus.MustNewConstMetric(metric.desc, metric.valueType, floatVal)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+// Scrape the backup history of XtraBackup `PERCONA_SCHEMA.xtrabackup_history`
+// and MySQL Enterprise Backup `mysql.backup_history`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Subsystem.
+const backup = "backup"
+
+const (
+	backupHistoryTablesQuery = `
+	SELECT TABLE_SCHEMA, TABLE_NAME
+	  FROM information_schema.TABLES
+	 WHERE (TABLE_SCHEMA = 'PERCONA_SCHEMA' AND TABLE_NAME = 'xtrabackup_history')
+	    OR (TABLE_SCHEMA = 'mysql' AND TABLE_NAME = 'backup_history')
+	`
+	// XtraBackup only records the backups that completed.
+	xtrabackupHistoryQuery = `
+	SELECT IF(incremental = 'Y', 'incremental', IF(partial = 'Y', 'partial', 'full')),
+	       UNIX_TIMESTAMP(start_time), UNIX_TIMESTAMP(end_time)
+	  FROM PERCONA_SCHEMA.xtrabackup_history
+	 WHERE end_time IS NOT NULL
+	 ORDER BY end_time
+	`
+	mebBackupHistoryQuery = `
+	SELECT LOWER(backup_type), UNIX_TIMESTAMP(start_time), UNIX_TIMESTAMP(end_time)
+	  FROM mysql.backup_history
+	 WHERE exit_state = 'SUCCESS'
+	 ORDER BY end_time
+	`
+)
+
+// Metric descriptors.
+var (
+	backupLastSuccessDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, backup, "last_success_timestamp_seconds"),
+		"The time the last successful backup of the type finished.",
+		[]string{"tool", "type"}, nil,
+	)
+	backupLastDurationDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, backup, "last_success_duration_seconds"),
+		"The duration of the last successful backup of the type.",
+		[]string{"tool", "type"}, nil,
+	)
+)
+
+// ScrapeBackupHistory collects the last successful backups from the backup history tables.
+type ScrapeBackupHistory struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeBackupHistory) Name() string {
+	return "backup_history"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeBackupHistory) Help() string {
+	return "Collect the last successful backup by type from PERCONA_SCHEMA.xtrabackup_history and mysql.backup_history"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeBackupHistory) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	tablesRows, err := db.Query(backupHistoryTablesQuery)
+	if err != nil {
+		return err
+	}
+	var (
+		schema, table string
+		tables        []string
+	)
+	for tablesRows.Next() {
+		if err := tablesRows.Scan(&schema, &table); err != nil {
+			tablesRows.Close()
+			return err
+		}
+		tables = append(tables, schema+"."+table)
+	}
+	tablesRows.Close()
+	if err := tablesRows.Err(); err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		tool, query := "meb", mebBackupHistoryQuery
+		if table == "PERCONA_SCHEMA.xtrabackup_history" {
+			tool, query = "xtrabackup", xtrabackupHistoryQuery
+		}
+		if err := scrapeBackupHistory(db, ch, tool, query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scrapeBackupHistory exports the last of the successful backups of every
+// type, which the query returns from the oldest to the latest.
+func scrapeBackupHistory(db *sql.DB, ch chan<- prometheus.Metric, tool, query string) error {
+	historyRows, err := db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer historyRows.Close()
+
+	type lastBackup struct{ start, end float64 }
+	var (
+		types      []string
+		latest     = map[string]lastBackup{}
+		backupType string
+		start, end float64
+	)
+	for historyRows.Next() {
+		if err := historyRows.Scan(&backupType, &start, &end); err != nil {
+			return err
+		}
+		if _, ok := latest[backupType]; !ok {
+			types = append(types, backupType)
+		}
+		latest[backupType] = lastBackup{start, end}
+	}
+	if err := historyRows.Err(); err != nil {
+		return err
+	}
+
+	for _, backupType := range types {
+		b := latest[backupType]
+		ch <- prometheus.MustNewConstMetric(backupLastSuccessDesc, prometheus.GaugeValue, b.end, tool, backupType)
+		ch <- prometheus.MustNewConstMetric(backupLastDurationDesc, prometheus.GaugeValue, b.end-b.start, tool, backupType)
+	}
+	return nil
+}
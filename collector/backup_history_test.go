@@ -0,0 +1,59 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeBackupHistory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"TABLE_SCHEMA", "TABLE_NAME"}).
+		AddRow("PERCONA_SCHEMA", "xtrabackup_history").
+		AddRow("mysql", "backup_history")
+	mock.ExpectQuery(sanitizeQuery(backupHistoryTablesQuery)).WillReturnRows(rows)
+	columns := []string{"type", "start_time", "end_time"}
+	rows = sqlmock.NewRows(columns).
+		AddRow("full", "1760000000", "1760003600").
+		AddRow("incremental", "1760040000", "1760040600").
+		AddRow("full", "1760086400", "1760088200")
+	mock.ExpectQuery(sanitizeQuery(xtrabackupHistoryQuery)).WillReturnRows(rows)
+	rows = sqlmock.NewRows(columns).AddRow("differential", "1760050000", "1760050900")
+	mock.ExpectQuery(sanitizeQuery(mebBackupHistoryQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeBackupHistory{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"tool": "xtrabackup", "type": "full"}, value: 1760088200, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"tool": "xtrabackup", "type": "full"}, value: 1800, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"tool": "xtrabackup", "type": "incremental"}, value: 1760040600, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"tool": "xtrabackup", "type": "incremental"}, value: 600, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"tool": "meb", "type": "differential"}, value: 1760050900, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"tool": "meb", "type": "differential"}, value: 900, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
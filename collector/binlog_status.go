@@ -0,0 +1,169 @@
+// Scrape `SHOW BINARY LOG STATUS` and the GTID sets.
+
+package collector
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+// SHOW MASTER STATUS was renamed to SHOW BINARY LOG STATUS in MySQL 8.2.
+var binlogStatusQueries = [2]string{"SHOW BINARY LOG STATUS", "SHOW MASTER STATUS"}
+
+const binlogGTIDQuery = `SELECT @@GLOBAL.gtid_executed, @@GLOBAL.gtid_purged`
+
+// Metric descriptors.
+var (
+	binlogStatusFileNumberDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, binlog, "status_file_number"),
+		"The number of the binlog file currently written to.",
+		nil, nil,
+	)
+	binlogStatusPositionDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, binlog, "status_position_bytes"),
+		"The position in the binlog file currently written to. It starts over in every new binlog file.",
+		nil, nil,
+	)
+	binlogGTIDRangesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, binlog, "gtid_ranges"),
+		"The number of GTID intervals in the gtid_executed or gtid_purged set.",
+		[]string{"set"}, nil,
+	)
+	binlogGTIDTransactionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, binlog, "gtid_transactions"),
+		"The number of transactions in the gtid_executed or gtid_purged set.",
+		[]string{"set"}, nil,
+	)
+)
+
+// ScrapeBinlogStatus collects from `SHOW BINARY LOG STATUS` and the GTID sets.
+type ScrapeBinlogStatus struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeBinlogStatus) Name() string {
+	return "binlog_status"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeBinlogStatus) Help() string {
+	return "Collect the current binlog position from SHOW BINARY LOG STATUS and the size of gtid_executed and gtid_purged"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeBinlogStatus) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var (
+		binlogStatusRows *sql.Rows
+		err              error
+	)
+	for _, query := range binlogStatusQueries {
+		binlogStatusRows, err = db.Query(query)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+	defer binlogStatusRows.Close()
+
+	cols, err := binlogStatusRows.Columns()
+	if err != nil {
+		return err
+	}
+	for binlogStatusRows.Next() {
+		scanArgs := make([]interface{}, len(cols))
+		for i := range scanArgs {
+			scanArgs[i] = &sql.RawBytes{}
+		}
+		if err := binlogStatusRows.Scan(scanArgs...); err != nil {
+			return err
+		}
+		file := columnValue(scanArgs, cols, "File")
+		if i := strings.LastIndex(file, "."); i >= 0 {
+			if number, err := strconv.ParseFloat(file[i+1:], 64); err == nil {
+				ch <- prometheus.MustNewConstMetric(binlogStatusFileNumberDesc, prometheus.CounterValue, number)
+			}
+		}
+		if position, err := strconv.ParseFloat(columnValue(scanArgs, cols, "Position"), 64); err == nil {
+			ch <- prometheus.MustNewConstMetric(binlogStatusPositionDesc, prometheus.GaugeValue, position)
+		}
+	}
+
+	var gtidExecuted, gtidPurged string
+	if err := db.QueryRow(binlogGTIDQuery).Scan(&gtidExecuted, &gtidPurged); err != nil {
+		// MySQL before 5.6 and MariaDB have no gtid_executed.
+		log.Debugln("Error querying gtid_executed, skipping GTID sets:", err)
+		return nil
+	}
+	for _, set := range []struct {
+		name string
+		gtid string
+	}{{"executed", gtidExecuted}, {"purged", gtidPurged}} {
+		ranges, transactions := parseGTIDSet(set.gtid).size()
+		ch <- prometheus.MustNewConstMetric(binlogGTIDRangesDesc, prometheus.GaugeValue, float64(ranges), set.name)
+		ch <- prometheus.MustNewConstMetric(binlogGTIDTransactionsDesc, prometheus.GaugeValue, float64(transactions), set.name)
+	}
+	return nil
+}
+
+// gtidInterval is an inclusive range of transaction numbers.
+type gtidInterval struct {
+	start, end uint64
+}
+
+// gtidSet maps a source UUID (and tag, if any) to its intervals.
+type gtidSet map[string][]gtidInterval
+
+// parseGTIDSet parses a GTID set such as `uuid:1-5:7,uuid2:1-100`.
+// Unparsable intervals are skipped.
+func parseGTIDSet(set string) gtidSet {
+	gtids := gtidSet{}
+	for _, member := range strings.Split(set, ",") {
+		parts := strings.Split(strings.TrimSpace(member), ":")
+		if len(parts) < 2 {
+			continue
+		}
+		uuid := strings.ToLower(parts[0])
+		source := uuid
+		for _, part := range parts[1:] {
+			interval, ok := parseGTIDInterval(part)
+			if !ok {
+				// MySQL 8.3 tagged GTIDs, e.g. uuid:tag:1-5.
+				source = uuid + ":" + part
+				continue
+			}
+			gtids[source] = append(gtids[source], interval)
+		}
+	}
+	return gtids
+}
+
+func parseGTIDInterval(part string) (gtidInterval, bool) {
+	bounds := strings.SplitN(part, "-", 2)
+	start, err := strconv.ParseUint(bounds[0], 10, 64)
+	if err != nil {
+		return gtidInterval{}, false
+	}
+	end := start
+	if len(bounds) == 2 {
+		if end, err = strconv.ParseUint(bounds[1], 10, 64); err != nil || end < start {
+			return gtidInterval{}, false
+		}
+	}
+	return gtidInterval{start: start, end: end}, true
+}
+
+// size returns the number of intervals and transactions in the set.
+func (s gtidSet) size() (ranges, transactions uint64) {
+	for _, intervals := range s {
+		for _, interval := range intervals {
+			ranges++
+			transactions += interval.end - interval.start + 1
+		}
+	}
+	return ranges, transactions
+}
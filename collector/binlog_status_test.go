@@ -0,0 +1,68 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeBinlogStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(binlogStatusQueries[0])).WillReturnError(fmt.Errorf("You have an error in your SQL syntax"))
+	columns := []string{"File", "Position", "Binlog_Do_DB", "Binlog_Ignore_DB", "Executed_Gtid_Set"}
+	mock.ExpectQuery(sanitizeQuery(binlogStatusQueries[1])).WillReturnRows(sqlmock.NewRows(columns).
+		AddRow("mysql-bin.000042", "1337", "", "", "3e11fa47-71ca-11e1-9e33-c80aa9429562:1-100"))
+	mock.ExpectQuery(sanitizeQuery(binlogGTIDQuery)).WillReturnRows(sqlmock.NewRows([]string{"gtid_executed", "gtid_purged"}).
+		AddRow("3e11fa47-71ca-11e1-9e33-c80aa9429562:1-100:105-110,\n4e11fa47-71ca-11e1-9e33-c80aa9429562:1", "3e11fa47-71ca-11e1-9e33-c80aa9429562:1-50"))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeBinlogStatus{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{}, value: 42, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 1337, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"set": "executed"}, value: 3, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"set": "executed"}, value: 107, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"set": "purged"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"set": "purged"}, value: 50, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestParseGTIDSet(t *testing.T) {
+	convey.Convey("Parse GTID sets", t, func() {
+		convey.So(parseGTIDSet(""), convey.ShouldResemble, gtidSet{})
+		convey.So(parseGTIDSet("3E11FA47-71CA-11E1-9E33-C80AA9429562:1-5:7"), convey.ShouldResemble, gtidSet{
+			"3e11fa47-71ca-11e1-9e33-c80aa9429562": {{1, 5}, {7, 7}},
+		})
+		convey.So(parseGTIDSet("3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5:blue:1-2:green:3"), convey.ShouldResemble, gtidSet{
+			"3e11fa47-71ca-11e1-9e33-c80aa9429562":       {{1, 5}},
+			"3e11fa47-71ca-11e1-9e33-c80aa9429562:blue":  {{1, 2}},
+			"3e11fa47-71ca-11e1-9e33-c80aa9429562:green": {{3, 3}},
+		})
+	})
+}
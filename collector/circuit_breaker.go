@@ -0,0 +1,94 @@
+package collector
+
+import (
+	"sync"
+	"time"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// Tunable flags.
+var (
+	circuitBreakerFailures = kingpin.Flag(
+		"exporter.circuit-breaker.failures",
+		"Number of consecutive failed connection attempts after which scrapes of a target are skipped for a cooldown. 0 disables the circuit breaker.",
+	).Default("0").Int()
+	circuitBreakerCooldown = kingpin.Flag(
+		"exporter.circuit-breaker.cooldown",
+		"Initial time to skip scrapes of a target once its circuit is open. Doubled every time the circuit reopens.",
+	).Default("30s").Duration()
+	circuitBreakerMaxCooldown = kingpin.Flag(
+		"exporter.circuit-breaker.max-cooldown",
+		"Maximum time to skip scrapes of a target once its circuit is open.",
+	).Default("5m").Duration()
+)
+
+// circuitBreaker tracks consecutive connection failures of a target. Once
+// the threshold is reached the circuit opens and scrapes are skipped until
+// the cooldown has elapsed. The circuit is then half-open: a single attempt
+// is let through, and concurrent scrapes are skipped until it has succeeded,
+// closing the circuit, or failed, reopening it.
+type circuitBreaker struct {
+	mtx       sync.Mutex
+	failures  int
+	cooldown  time.Duration
+	openUntil time.Time
+	// probing is set while the attempt of the half-open circuit is running.
+	probing bool
+}
+
+// allow reports whether a connection attempt should be made. Once it has
+// returned true for a half-open circuit, the caller must report the outcome
+// with success or failure.
+func (b *circuitBreaker) allow(now time.Time) bool {
+	if b == nil || *circuitBreakerFailures <= 0 {
+		return true
+	}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.failures < *circuitBreakerFailures {
+		return true
+	}
+	if now.Before(b.openUntil) || b.probing {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// success closes the circuit.
+func (b *circuitBreaker) success() {
+	if b == nil {
+		return
+	}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.failures = 0
+	b.cooldown = 0
+	b.openUntil = time.Time{}
+	b.probing = false
+}
+
+// failure records a failed connection attempt, opening the circuit with an
+// exponentially growing cooldown once the threshold is reached.
+func (b *circuitBreaker) failure(now time.Time) {
+	if b == nil || *circuitBreakerFailures <= 0 {
+		return
+	}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.probing = false
+	b.failures++
+	if b.failures < *circuitBreakerFailures {
+		return
+	}
+	if b.cooldown == 0 {
+		b.cooldown = *circuitBreakerCooldown
+	} else {
+		b.cooldown *= 2
+	}
+	if b.cooldown > *circuitBreakerMaxCooldown {
+		b.cooldown = *circuitBreakerMaxCooldown
+	}
+	b.openUntil = now.Add(b.cooldown)
+}
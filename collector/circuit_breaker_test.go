@@ -0,0 +1,41 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	*circuitBreakerFailures = 2
+	*circuitBreakerCooldown = 10 * time.Second
+	*circuitBreakerMaxCooldown = 15 * time.Second
+	defer func() { *circuitBreakerFailures = 0 }()
+
+	b := &circuitBreaker{}
+	now := time.Now()
+
+	convey.Convey("Circuit breaker", t, func() {
+		b.failure(now)
+		convey.So(b.allow(now), convey.ShouldBeTrue)
+
+		b.failure(now)
+		convey.So(b.allow(now), convey.ShouldBeFalse)
+		convey.So(b.allow(now.Add(10*time.Second)), convey.ShouldBeTrue)
+		// Only one attempt is let through while the circuit is half-open.
+		convey.So(b.allow(now.Add(10*time.Second)), convey.ShouldBeFalse)
+
+		// A failed trial reopens the circuit with a longer, capped cooldown.
+		now = now.Add(10 * time.Second)
+		b.failure(now)
+		convey.So(b.allow(now.Add(14*time.Second)), convey.ShouldBeFalse)
+		convey.So(b.allow(now.Add(15*time.Second)), convey.ShouldBeTrue)
+		convey.So(b.allow(now.Add(16*time.Second)), convey.ShouldBeFalse)
+
+		b.success()
+		convey.So(b.allow(now), convey.ShouldBeTrue)
+		b.failure(now)
+		convey.So(b.allow(now), convey.ShouldBeTrue)
+	})
+}
@@ -3,10 +3,12 @@ package collector
 import (
 	"bytes"
 	"database/sql"
+	"math"
 	"regexp"
 	"strconv"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
 )
 
 const (
@@ -21,6 +23,23 @@ const (
 
 var logRE = regexp.MustCompile(`.+\.(\d+)$`)
 
+// Tunable flags.
+var (
+	maxRows = kingpin.Flag(
+		"exporter.max-rows",
+		"Maximum number of rows returned by a single query of the information_schema.tables collector. 0 for no limit.",
+	).Default("0").Int()
+)
+
+// maxRowsLimit returns the LIMIT of queries capped by --exporter.max-rows,
+// the largest possible row count when it is not set.
+func maxRowsLimit() uint64 {
+	if *maxRows <= 0 {
+		return math.MaxUint64
+	}
+	return uint64(*maxRows)
+}
+
 func newDesc(subsystem, name, help string) *prometheus.Desc {
 	return prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, subsystem, name),
@@ -53,3 +72,27 @@ func parseStatus(data sql.RawBytes) (float64, bool) {
 	value, err := strconv.ParseFloat(string(data), 64)
 	return value, err == nil
 }
+
+// scrapeNamedValues runs a SHOW STATUS or SHOW VARIABLES style query and
+// stores every numeric value by name.
+func scrapeNamedValues(db *sql.DB, query string, values map[string]float64) error {
+	rows, err := db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var (
+		key string
+		val sql.RawBytes
+	)
+	for rows.Next() {
+		if err := rows.Scan(&key, &val); err != nil {
+			return err
+		}
+		if floatVal, ok := parseStatus(val); ok {
+			values[key] = floatVal
+		}
+	}
+	return rows.Err()
+}
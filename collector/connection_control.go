@@ -0,0 +1,122 @@
+// Scrape `information_schema.connection_control_failed_login_attempts`.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Subsystem.
+const connectionControl = "connection_control"
+
+const (
+	connectionControlStatusQuery = `
+	SHOW GLOBAL STATUS LIKE 'Connection_control_%'
+	`
+	connectionControlVariablesQuery = `
+	SHOW GLOBAL VARIABLES LIKE 'connection_control_%'
+	`
+	connectionControlFailedLoginAttemptsQuery = `
+	SELECT USERHOST, FAILED_ATTEMPTS
+	  FROM information_schema.connection_control_failed_login_attempts
+	`
+)
+
+// Metric descriptors.
+var (
+	connectionControlDelayGeneratedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, connectionControl, "delay_generated_total"),
+		"The number of times the server added a delay to a connection attempt.",
+		nil, nil,
+	)
+	connectionControlThresholdDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, connectionControl, "failed_connections_threshold"),
+		"The number of consecutive failed connection attempts permitted before the server adds a delay.",
+		nil, nil,
+	)
+	connectionControlMinDelayDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, connectionControl, "min_connection_delay_seconds"),
+		"The minimum delay added to connection attempts beyond the threshold.",
+		nil, nil,
+	)
+	connectionControlMaxDelayDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, connectionControl, "max_connection_delay_seconds"),
+		"The maximum delay added to connection attempts beyond the threshold.",
+		nil, nil,
+	)
+	connectionControlFailedLoginAttemptsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, connectionControl, "failed_login_attempts"),
+		"The number of consecutive failed login attempts by account.",
+		[]string{"user", "host"}, nil,
+	)
+)
+
+// ScrapeConnectionControl collects from the connection_control plugin.
+type ScrapeConnectionControl struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeConnectionControl) Name() string {
+	return connectionControl
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeConnectionControl) Help() string {
+	return "Collect failed login attempts and delays from the connection_control plugin"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeConnectionControl) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	values := map[string]float64{}
+	for _, query := range []string{connectionControlStatusQuery, connectionControlVariablesQuery} {
+		if err := scrapeNamedValues(db, query, values); err != nil {
+			return err
+		}
+	}
+	if value, ok := values["Connection_control_delay_generated"]; ok {
+		ch <- prometheus.MustNewConstMetric(connectionControlDelayGeneratedDesc, prometheus.CounterValue, value)
+	}
+	if value, ok := values["connection_control_failed_connections_threshold"]; ok {
+		ch <- prometheus.MustNewConstMetric(connectionControlThresholdDesc, prometheus.GaugeValue, value)
+	}
+	// Delays are configured in milliseconds.
+	if value, ok := values["connection_control_min_connection_delay"]; ok {
+		ch <- prometheus.MustNewConstMetric(connectionControlMinDelayDesc, prometheus.GaugeValue, value/1000)
+	}
+	if value, ok := values["connection_control_max_connection_delay"]; ok {
+		ch <- prometheus.MustNewConstMetric(connectionControlMaxDelayDesc, prometheus.GaugeValue, value/1000)
+	}
+
+	failedLoginAttemptsRows, err := db.Query(connectionControlFailedLoginAttemptsQuery)
+	if err != nil {
+		return err
+	}
+	defer failedLoginAttemptsRows.Close()
+
+	var (
+		userHost string
+		attempts uint64
+	)
+	for failedLoginAttemptsRows.Next() {
+		if err := failedLoginAttemptsRows.Scan(&userHost, &attempts); err != nil {
+			return err
+		}
+		user, host := splitUserHost(userHost)
+		ch <- prometheus.MustNewConstMetric(
+			connectionControlFailedLoginAttemptsDesc, prometheus.GaugeValue, float64(attempts),
+			user, host,
+		)
+	}
+	return nil
+}
+
+// splitUserHost splits a `'user'@'host'` account name.
+func splitUserHost(userHost string) (string, string) {
+	i := strings.LastIndex(userHost, "@")
+	if i < 0 {
+		return strings.Trim(userHost, "'"), ""
+	}
+	return strings.Trim(userHost[:i], "'"), strings.Trim(userHost[i+1:], "'")
+}
@@ -0,0 +1,59 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeConnectionControl(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"Variable_name", "Value"}
+	mock.ExpectQuery(sanitizeQuery(connectionControlStatusQuery)).WillReturnRows(
+		sqlmock.NewRows(columns).AddRow("Connection_control_delay_generated", "12"))
+	mock.ExpectQuery(sanitizeQuery(connectionControlVariablesQuery)).WillReturnRows(
+		sqlmock.NewRows(columns).
+			AddRow("connection_control_failed_connections_threshold", "3").
+			AddRow("connection_control_max_connection_delay", "2147483647").
+			AddRow("connection_control_min_connection_delay", "1000"))
+	mock.ExpectQuery(sanitizeQuery(connectionControlFailedLoginAttemptsQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"USERHOST", "FAILED_ATTEMPTS"}).
+			AddRow("'root'@'10.0.0.1'", "5").
+			AddRow("'app'@'%'", "1"))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeConnectionControl{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{}, value: 12, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 3, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 2147483.647, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "root", "host": "10.0.0.1"}, value: 5, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "app", "host": "%"}, value: 1, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -7,7 +7,7 @@ import (
 	"sync"
 	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/log"
 	"gopkg.in/alecthomas/kingpin.v2"
@@ -37,17 +37,71 @@ var (
 		"exporter.log_slow_filter",
 		"Add a log_slow_filter to avoid slow query logging of scrapes. NOTE: Not supported by Oracle MySQL.",
 	).Default("false").Bool()
+	serializeScrapes = kingpin.Flag(
+		"exporter.serialize-scrapes",
+		"Serialize concurrent scrapes of the same DSN so stacked scrapes of a slow server do not run in parallel.",
+	).Default("false").Bool()
+	coalesceWindow = kingpin.Flag(
+		"exporter.coalesce-window",
+		"Serve concurrent scrapes, and scrapes arriving within this window after a finished one, from a single collection run. 0 disables coalescing.",
+	).Default("0s").Duration()
+	slowCollectorThreshold = kingpin.Flag(
+		"exporter.slow-collector-threshold",
+		"Log a warning with the slowest query of any collector taking longer than this, and export mysql_exporter_slow_collector. 0 disables the check.",
+	).Default("0s").Duration()
+	scrapeDurationBuckets = kingpin.Flag(
+		"exporter.collector-duration-buckets",
+		"Bucket boundary, in seconds, of the collector duration histogram. Can be repeated.",
+	).Default("0.005", "0.01", "0.025", "0.05", "0.1", "0.25", "0.5", "1", "2.5", "5", "10").Float64List()
 )
 
-// Metric descriptors.
+// coalescedScrape is a collection run shared by identical scrapes.
+type coalescedScrape struct {
+	done     chan struct{}
+	metrics  []prometheus.Metric
+	finished time.Time
+}
+
 var (
-	scrapeDurationDesc = prometheus.NewDesc(
-		prometheus.BuildFQName(namespace, exporter, "collector_duration_seconds"),
-		"Collector time duration.",
-		[]string{"collector"}, nil,
-	)
+	coalescedScrapesMtx sync.Mutex
+	coalescedScrapes    = map[string]*coalescedScrape{}
+)
+
+// scrapeLocks holds one mutex per DSN to serialize concurrent scrapes. A
+// mutex is dropped once no scrape holds or waits for it.
+var (
+	scrapeLocksMtx sync.Mutex
+	scrapeLocks    = map[string]*scrapeLockEntry{}
 )
 
+type scrapeLockEntry struct {
+	sync.Mutex
+	refs int
+}
+
+// lockScrape blocks until no other scrape of dsn runs, and returns the
+// function releasing the lock.
+func lockScrape(dsn string) (unlock func()) {
+	scrapeLocksMtx.Lock()
+	entry, ok := scrapeLocks[dsn]
+	if !ok {
+		entry = &scrapeLockEntry{}
+		scrapeLocks[dsn] = entry
+	}
+	entry.refs++
+	scrapeLocksMtx.Unlock()
+
+	entry.Lock()
+	return func() {
+		entry.Unlock()
+		scrapeLocksMtx.Lock()
+		defer scrapeLocksMtx.Unlock()
+		if entry.refs--; entry.refs == 0 {
+			delete(scrapeLocks, dsn)
+		}
+	}
+}
+
 // Exporter collects MySQL metrics. It implements prometheus.Collector.
 type Exporter struct {
 	dsn      string
@@ -64,12 +118,15 @@ func New(dsn string, metrics Metrics, scrapers []Scraper) *Exporter {
 		dsnParams = append(dsnParams, sessionSettingsParam)
 	}
 
-	if strings.Contains(dsn, "?") {
-		dsn = dsn + "&"
-	} else {
-		dsn = dsn + "?"
+	// The ProxySQL admin interface has no session variables to set.
+	if !*proxysqlMode {
+		if strings.Contains(dsn, "?") {
+			dsn = dsn + "&"
+		} else {
+			dsn = dsn + "?"
+		}
+		dsn += strings.Join(dsnParams, "&")
 	}
-	dsn += strings.Join(dsnParams, "&")
 
 	return &Exporter{
 		dsn:      dsn,
@@ -84,100 +141,293 @@ func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
 	ch <- e.metrics.Error.Desc()
 	e.metrics.ScrapeErrors.Describe(ch)
 	ch <- e.metrics.MySQLUp.Desc()
+	e.metrics.Queries.Describe(ch)
+	e.metrics.QueryErrors.Describe(ch)
+	e.metrics.RowsScanned.Describe(ch)
+	e.metrics.BytesScanned.Describe(ch)
+	e.metrics.ScrapeDurations.Describe(ch)
 }
 
 // Collect implements prometheus.Collector.
 func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	if *coalesceWindow > 0 {
+		for _, m := range e.coalescedCollect() {
+			ch <- m
+		}
+		return
+	}
+	e.collect(ch)
+}
+
+// coalescedCollect returns the metrics of an in-flight or recently finished
+// identical scrape, starting a new collection run if there is none.
+func (e *Exporter) coalescedCollect() []prometheus.Metric {
+	names := make([]string, 0, len(e.scrapers))
+	for _, scraper := range e.scrapers {
+		names = append(names, scraper.Name())
+	}
+	key := fmt.Sprintf("%s %p %s", e.dsn, e.metrics.TotalScrapes, strings.Join(names, ","))
+
+	coalescedScrapesMtx.Lock()
+	run, ok := coalescedScrapes[key]
+	if ok {
+		select {
+		case <-run.done:
+			if time.Since(run.finished) > *coalesceWindow {
+				ok = false
+			}
+		default:
+		}
+	}
+	if ok {
+		coalescedScrapesMtx.Unlock()
+		<-run.done
+		return run.metrics
+	}
+	// Drop the runs too old to be shared, e.g. of targets no longer scraped.
+	for other, old := range coalescedScrapes {
+		select {
+		case <-old.done:
+			if time.Since(old.finished) > *coalesceWindow {
+				delete(coalescedScrapes, other)
+			}
+		default:
+		}
+	}
+	run = &coalescedScrape{done: make(chan struct{})}
+	coalescedScrapes[key] = run
+	coalescedScrapesMtx.Unlock()
+
+	buf := make(chan prometheus.Metric)
+	go func() {
+		e.collect(buf)
+		close(buf)
+	}()
+	for m := range buf {
+		run.metrics = append(run.metrics, m)
+	}
+	run.finished = time.Now()
+	close(run.done)
+	return run.metrics
+}
+
+func (e *Exporter) collect(ch chan<- prometheus.Metric) {
+	if *serializeScrapes {
+		defer lockScrape(e.dsn)()
+	}
 	e.scrape(ch)
 
 	ch <- e.metrics.TotalScrapes
 	ch <- e.metrics.Error
 	e.metrics.ScrapeErrors.Collect(ch)
 	ch <- e.metrics.MySQLUp
+	e.metrics.Queries.Collect(ch)
+	e.metrics.QueryErrors.Collect(ch)
+	e.metrics.RowsScanned.Collect(ch)
+	e.metrics.BytesScanned.Collect(ch)
+	e.metrics.ScrapeDurations.Collect(ch)
 }
 
 func (e *Exporter) scrape(ch chan<- prometheus.Metric) {
 	e.metrics.TotalScrapes.Inc()
-	var err error
 
 	scrapeTime := time.Now()
-	db, err := sql.Open("mysql", e.dsn)
-	if err != nil {
-		log.Errorln("Error opening connection to database:", err)
+	if !e.metrics.breaker.allow(scrapeTime) {
+		log.Debugln("Circuit open, skipping scrape")
+		e.metrics.MySQLUp.Set(0)
 		e.metrics.Error.Set(1)
 		return
 	}
-	defer db.Close()
 
-	// By design exporter should use maximum one connection per request.
-	db.SetMaxOpenConns(1)
-	db.SetMaxIdleConns(1)
-	// Set max lifetime for a connection.
-	db.SetConnMaxLifetime(1 * time.Minute)
+	shared := &sharedConn{driver: mysql.MySQLDriver{}, dsn: e.dsn}
+	if cfg, err := mysql.ParseDSN(e.dsn); err == nil {
+		shared.target = cfg.Addr
+	}
+	defer shared.Close()
+
+	db, _ := e.openDB(shared, "connection")
+	defer db.Close()
 
 	if err := db.Ping(); err != nil {
 		log.Errorln("Error pinging mysqld:", err)
+		e.metrics.breaker.failure(time.Now())
 		e.metrics.MySQLUp.Set(0)
 		e.metrics.Error.Set(1)
 		return
 	}
 
+	e.metrics.breaker.success()
 	e.metrics.MySQLUp.Set(1)
 
+	scrapeDurationDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, exporter, "collector_duration_seconds"),
+		"Collector time duration.",
+		[]string{"collector"}, e.metrics.ConstLabels,
+	)
 	ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), "connection")
+	e.metrics.ScrapeDurations.WithLabelValues("connection").Observe(time.Since(scrapeTime).Seconds())
+
+	collectorSuccessDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, exporter, "collector_success"),
+		"Whether the collector succeeded.",
+		[]string{"collector"}, e.metrics.ConstLabels,
+	)
+	slowCollectorDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, exporter, "slow_collector"),
+		"Whether the collector took longer than the slow collector threshold.",
+		[]string{"collector"}, e.metrics.ConstLabels,
+	)
+
+	// Collectors reading tables Vitess lacks would only log errors.
+	vitess := !*proxysqlMode && isVitess(e.dsn, db)
 
 	wg := &sync.WaitGroup{}
 	defer wg.Wait()
 	for _, scraper := range e.scrapers {
+		if vitess && !vitessCompatibleScrapers[scraper.Name()] {
+			log.Debugf("Skipping collect.%s on Vitess", scraper.Name())
+			continue
+		}
 		wg.Add(1)
 		go func(scraper Scraper) {
 			defer wg.Done()
 			label := "collect." + scraper.Name()
 			scrapeTime := time.Now()
-			if err := scraper.Scrape(db, ch); err != nil {
+			success := 1.0
+			db, conn := e.openDB(shared, label)
+			defer db.Close()
+			if err := scrapeWithRetry(db, scraper, ch); err != nil {
 				log.Errorln("Error scraping for "+label+":", err)
 				e.metrics.ScrapeErrors.WithLabelValues(label).Inc()
 				e.metrics.Error.Set(1)
+				success = 0
 			}
 			ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, time.Since(scrapeTime).Seconds(), label)
+			e.metrics.ScrapeDurations.WithLabelValues(label).Observe(time.Since(scrapeTime).Seconds())
+			ch <- prometheus.MustNewConstMetric(collectorSuccessDesc, prometheus.GaugeValue, success, label)
+			if *slowCollectorThreshold > 0 {
+				slow := 0.0
+				if elapsed := time.Since(scrapeTime); elapsed > *slowCollectorThreshold {
+					query, queryTime := conn.slowestQuery()
+					log.With("collector", label).
+						With("duration_seconds", elapsed.Seconds()).
+						With("query", query).
+						With("query_duration_seconds", queryTime.Seconds()).
+						Warnln("Collector exceeded the slow collector threshold")
+					slow = 1
+				}
+				ch <- prometheus.MustNewConstMetric(slowCollectorDesc, prometheus.GaugeValue, slow, label)
+			}
 		}(scraper)
 	}
 }
 
+// openDB returns a sql.DB running the queries of the named collector on the
+// shared connection of the scrape, along with its connector.
+func (e *Exporter) openDB(shared *sharedConn, collector string) (*sql.DB, *collectorConnector) {
+	conn := &collectorConnector{shared: shared, collector: collector, metrics: e.metrics}
+	db := sql.OpenDB(conn)
+	// By design exporter should use maximum one connection per request.
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	return db, conn
+}
+
 // Metrics represents exporter metrics which values can be carried between http requests.
 type Metrics struct {
 	TotalScrapes prometheus.Counter
 	ScrapeErrors *prometheus.CounterVec
 	Error        prometheus.Gauge
 	MySQLUp      prometheus.Gauge
+	Queries      *prometheus.CounterVec
+	QueryErrors  *prometheus.CounterVec
+	RowsScanned  *prometheus.CounterVec
+	BytesScanned *prometheus.CounterVec
+	// ScrapeDurations supplements the collector duration gauge to expose percentiles.
+	ScrapeDurations *prometheus.HistogramVec
+	// ConstLabels are added to the exporter metrics, e.g. the probed target.
+	ConstLabels prometheus.Labels
+
+	breaker *circuitBreaker
 }
 
 // NewMetrics creates new Metrics instance.
 func NewMetrics() Metrics {
+	return newMetrics(nil)
+}
+
+// NewTargetMetrics creates new Metrics instance labeled with the probed target.
+func NewTargetMetrics(target string) Metrics {
+	return newMetrics(prometheus.Labels{"target": target})
+}
+
+func newMetrics(constLabels prometheus.Labels) Metrics {
 	subsystem := exporter
 	return Metrics{
 		TotalScrapes: prometheus.NewCounter(prometheus.CounterOpts{
-			Namespace: namespace,
-			Subsystem: subsystem,
-			Name:      "scrapes_total",
-			Help:      "Total number of times MySQL was scraped for metrics.",
+			Namespace:   namespace,
+			Subsystem:   subsystem,
+			Name:        "scrapes_total",
+			Help:        "Total number of times MySQL was scraped for metrics.",
+			ConstLabels: constLabels,
 		}),
 		ScrapeErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
-			Namespace: namespace,
-			Subsystem: subsystem,
-			Name:      "scrape_errors_total",
-			Help:      "Total number of times an error occurred scraping a MySQL.",
+			Namespace:   namespace,
+			Subsystem:   subsystem,
+			Name:        "scrape_errors_total",
+			Help:        "Total number of times an error occurred scraping a MySQL.",
+			ConstLabels: constLabels,
 		}, []string{"collector"}),
 		Error: prometheus.NewGauge(prometheus.GaugeOpts{
-			Namespace: namespace,
-			Subsystem: subsystem,
-			Name:      "last_scrape_error",
-			Help:      "Whether the last scrape of metrics from MySQL resulted in an error (1 for error, 0 for success).",
+			Namespace:   namespace,
+			Subsystem:   subsystem,
+			Name:        "last_scrape_error",
+			Help:        "Whether the last scrape of metrics from MySQL resulted in an error (1 for error, 0 for success).",
+			ConstLabels: constLabels,
 		}),
 		MySQLUp: prometheus.NewGauge(prometheus.GaugeOpts{
-			Namespace: namespace,
-			Name:      "up",
-			Help:      "Whether the MySQL server is up.",
+			Namespace:   namespace,
+			Name:        "up",
+			Help:        "Whether the MySQL server is up.",
+			ConstLabels: constLabels,
 		}),
+		Queries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   subsystem,
+			Name:        "queries_total",
+			Help:        "Total number of queries the exporter ran against MySQL.",
+			ConstLabels: constLabels,
+		}, []string{"collector"}),
+		QueryErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   subsystem,
+			Name:        "query_errors_total",
+			Help:        "Total number of queries against MySQL that failed.",
+			ConstLabels: constLabels,
+		}, []string{"collector"}),
+		RowsScanned: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   subsystem,
+			Name:        "rows_scanned_total",
+			Help:        "Total number of rows the exporter read from MySQL.",
+			ConstLabels: constLabels,
+		}, []string{"collector"}),
+		BytesScanned: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   subsystem,
+			Name:        "bytes_scanned_total",
+			Help:        "Total number of bytes of string and binary values the exporter read from MySQL.",
+			ConstLabels: constLabels,
+		}, []string{"collector"}),
+		ScrapeDurations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   namespace,
+			Subsystem:   subsystem,
+			Name:        "collector_scrape_duration_seconds",
+			Help:        "Histogram of collector time durations.",
+			Buckets:     *scrapeDurationBuckets,
+			ConstLabels: constLabels,
+		}, []string{"collector"}),
+		ConstLabels: constLabels,
+		breaker:     &circuitBreaker{},
 	}
 }
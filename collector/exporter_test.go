@@ -2,8 +2,10 @@ package collector
 
 import (
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/model"
 	"github.com/smartystreets/goconvey/convey"
 )
@@ -48,3 +50,69 @@ func TestExporter(t *testing.T) {
 		}
 	})
 }
+
+func TestScrapeLock(t *testing.T) {
+	convey.Convey("One lock per DSN", t, func() {
+		unlockA := lockScrape("a@/mysql")
+		unlockB := lockScrape("b@/mysql")
+		convey.So(scrapeLocks, convey.ShouldHaveLength, 2)
+
+		locked := make(chan struct{})
+		go func() {
+			lockScrape("a@/mysql")()
+			close(locked)
+		}()
+		select {
+		case <-locked:
+			t.Error("second scrape of the same DSN did not wait")
+		case <-time.After(10 * time.Millisecond):
+		}
+		unlockA()
+		<-locked
+
+		unlockB()
+		convey.So(scrapeLocks, convey.ShouldBeEmpty)
+	})
+}
+
+func TestCoalescedCollect(t *testing.T) {
+	*coalesceWindow = time.Minute
+	defer func() { *coalesceWindow = 0 }()
+
+	metrics := NewMetrics()
+	exporter := New("root@tcp(127.0.0.1:1)/", metrics, []Scraper{ScrapeGlobalStatus{}})
+
+	convey.Convey("Scrapes within the window share one run", t, func() {
+		for i := 0; i < 2; i++ {
+			ch := make(chan prometheus.Metric)
+			go func() {
+				exporter.Collect(ch)
+				close(ch)
+			}()
+			for range ch {
+			}
+		}
+		pb := &dto.Metric{}
+		metrics.TotalScrapes.Write(pb)
+		convey.So(pb.GetCounter().GetValue(), convey.ShouldEqual, 1)
+	})
+}
+
+func TestScrapeDurationBuckets(t *testing.T) {
+	defaultBuckets := *scrapeDurationBuckets
+	*scrapeDurationBuckets = []float64{1, 2}
+	defer func() { *scrapeDurationBuckets = defaultBuckets }()
+
+	metrics := NewMetrics()
+	metrics.ScrapeDurations.WithLabelValues("collect.global_status").Observe(1.5)
+
+	convey.Convey("Configured buckets", t, func() {
+		pb := &dto.Metric{}
+		metrics.ScrapeDurations.WithLabelValues("collect.global_status").(prometheus.Histogram).Write(pb)
+		buckets := map[float64]uint64{}
+		for _, b := range pb.GetHistogram().GetBucket() {
+			buckets[b.GetUpperBound()] = b.GetCumulativeCount()
+		}
+		convey.So(buckets, convey.ShouldResemble, map[float64]uint64{1: 0, 2: 1})
+	})
+}
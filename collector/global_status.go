@@ -96,6 +96,14 @@ func (ScrapeGlobalStatus) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error
 			key = strings.ToLower(key)
 			match := globalStatusRE.FindStringSubmatch(key)
 			if match == nil {
+				if variable, ok := globalStatusMetadata[key]; ok {
+					ch <- prometheus.MustNewConstMetric(
+						newDesc(globalStatus, key, variable.help),
+						variable.valueType,
+						floatVal,
+					)
+					continue
+				}
 				ch <- prometheus.MustNewConstMetric(
 					newDesc(globalStatus, key, "Generic metric from SHOW GLOBAL STATUS."),
 					prometheus.UntypedValue,
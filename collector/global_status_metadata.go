@@ -0,0 +1,127 @@
+// Types and help strings of well known `SHOW GLOBAL STATUS` variables.
+
+package collector
+
+import "github.com/prometheus/client_golang/prometheus"
+
+type globalStatusVariable struct {
+	valueType prometheus.ValueType
+	help      string
+}
+
+// globalStatusMetadata classifies the status variables that are not already
+// grouped by globalStatusRE. Variables missing here are exported as untyped.
+var globalStatusMetadata = map[string]globalStatusVariable{
+	"aborted_clients":                       {prometheus.CounterValue, "Total number of connections aborted because the client died without closing the connection properly."},
+	"aborted_connects":                      {prometheus.CounterValue, "Total number of failed attempts to connect to the MySQL server."},
+	"binlog_cache_disk_use":                 {prometheus.CounterValue, "Total number of transactions that used a temporary binary log cache file."},
+	"binlog_cache_use":                      {prometheus.CounterValue, "Total number of transactions that used the binary log cache."},
+	"binlog_stmt_cache_disk_use":            {prometheus.CounterValue, "Total number of nontransactional statements that used a temporary binary log statement cache file."},
+	"binlog_stmt_cache_use":                 {prometheus.CounterValue, "Total number of nontransactional statements that used the binary log statement cache."},
+	"bytes_received":                        {prometheus.CounterValue, "Total number of bytes received from all clients."},
+	"bytes_sent":                            {prometheus.CounterValue, "Total number of bytes sent to all clients."},
+	"connections":                           {prometheus.CounterValue, "Total number of connection attempts, successful or not."},
+	"created_tmp_disk_tables":               {prometheus.CounterValue, "Total number of internal on-disk temporary tables created while executing statements."},
+	"created_tmp_files":                     {prometheus.CounterValue, "Total number of temporary files created."},
+	"created_tmp_tables":                    {prometheus.CounterValue, "Total number of internal temporary tables created while executing statements."},
+	"innodb_buffer_pool_bytes_data":         {prometheus.GaugeValue, "Number of bytes in the InnoDB buffer pool containing data."},
+	"innodb_buffer_pool_bytes_dirty":        {prometheus.GaugeValue, "Number of bytes held in dirty pages in the InnoDB buffer pool."},
+	"innodb_buffer_pool_read_ahead":         {prometheus.CounterValue, "Total number of pages read into the InnoDB buffer pool by the read-ahead background thread."},
+	"innodb_buffer_pool_read_ahead_evicted": {prometheus.CounterValue, "Total number of pages read by the read-ahead thread evicted without having been accessed."},
+	"innodb_buffer_pool_read_requests":      {prometheus.CounterValue, "Total number of logical read requests."},
+	"innodb_buffer_pool_reads":              {prometheus.CounterValue, "Total number of logical reads that InnoDB could not satisfy from the buffer pool."},
+	"innodb_buffer_pool_wait_free":          {prometheus.CounterValue, "Total number of waits for pages to be flushed before a free page was available."},
+	"innodb_buffer_pool_write_requests":     {prometheus.CounterValue, "Total number of writes done to the InnoDB buffer pool."},
+	"innodb_data_fsyncs":                    {prometheus.CounterValue, "Total number of fsync() operations."},
+	"innodb_data_pending_fsyncs":            {prometheus.GaugeValue, "Current number of pending fsync() operations."},
+	"innodb_data_pending_reads":             {prometheus.GaugeValue, "Current number of pending reads."},
+	"innodb_data_pending_writes":            {prometheus.GaugeValue, "Current number of pending writes."},
+	"innodb_data_read":                      {prometheus.CounterValue, "Total number of bytes read."},
+	"innodb_data_reads":                     {prometheus.CounterValue, "Total number of data reads."},
+	"innodb_data_writes":                    {prometheus.CounterValue, "Total number of data writes."},
+	"innodb_data_written":                   {prometheus.CounterValue, "Total number of bytes written."},
+	"innodb_dblwr_pages_written":            {prometheus.CounterValue, "Total number of pages written to the doublewrite buffer."},
+	"innodb_dblwr_writes":                   {prometheus.CounterValue, "Total number of doublewrite operations performed."},
+	"innodb_log_waits":                      {prometheus.CounterValue, "Total number of times the log buffer was too small and a wait was required."},
+	"innodb_log_write_requests":             {prometheus.CounterValue, "Total number of write requests for the InnoDB redo log."},
+	"innodb_log_writes":                     {prometheus.CounterValue, "Total number of physical writes to the InnoDB redo log file."},
+	"innodb_num_open_files":                 {prometheus.GaugeValue, "Number of files InnoDB currently holds open."},
+	"innodb_os_log_fsyncs":                  {prometheus.CounterValue, "Total number of fsync() writes done to the InnoDB redo log files."},
+	"innodb_os_log_pending_fsyncs":          {prometheus.GaugeValue, "Current number of pending fsync() operations for the InnoDB redo log files."},
+	"innodb_os_log_pending_writes":          {prometheus.GaugeValue, "Current number of pending writes to the InnoDB redo log files."},
+	"innodb_os_log_written":                 {prometheus.CounterValue, "Total number of bytes written to the InnoDB redo log files."},
+	"innodb_page_size":                      {prometheus.GaugeValue, "InnoDB page size."},
+	"innodb_pages_created":                  {prometheus.CounterValue, "Total number of pages created by operations on InnoDB tables."},
+	"innodb_pages_read":                     {prometheus.CounterValue, "Total number of pages read from the InnoDB buffer pool by operations on InnoDB tables."},
+	"innodb_pages_written":                  {prometheus.CounterValue, "Total number of pages written by operations on InnoDB tables."},
+	"innodb_row_lock_current_waits":         {prometheus.GaugeValue, "Current number of row locks being waited for by operations on InnoDB tables."},
+	"innodb_row_lock_time":                  {prometheus.CounterValue, "Total time spent in acquiring row locks for InnoDB tables, in milliseconds."},
+	"innodb_row_lock_time_avg":              {prometheus.GaugeValue, "Average time to acquire a row lock for InnoDB tables, in milliseconds."},
+	"innodb_row_lock_time_max":              {prometheus.GaugeValue, "Maximum time to acquire a row lock for InnoDB tables, in milliseconds."},
+	"innodb_row_lock_waits":                 {prometheus.CounterValue, "Total number of times operations on InnoDB tables had to wait for a row lock."},
+	"key_blocks_not_flushed":                {prometheus.GaugeValue, "Number of key blocks in the MyISAM key cache that have changed but have not yet been flushed to disk."},
+	"key_blocks_unused":                     {prometheus.GaugeValue, "Number of unused blocks in the MyISAM key cache."},
+	"key_blocks_used":                       {prometheus.GaugeValue, "Number of used blocks in the MyISAM key cache."},
+	"key_read_requests":                     {prometheus.CounterValue, "Total number of requests to read a key block from the MyISAM key cache."},
+	"key_reads":                             {prometheus.CounterValue, "Total number of physical reads of a key block from disk into the MyISAM key cache."},
+	"key_write_requests":                    {prometheus.CounterValue, "Total number of requests to write a key block to the MyISAM key cache."},
+	"key_writes":                            {prometheus.CounterValue, "Total number of physical writes of a key block from the MyISAM key cache to disk."},
+	"max_used_connections":                  {prometheus.GaugeValue, "Maximum number of connections that have been in use simultaneously since the server started."},
+	"open_files":                            {prometheus.GaugeValue, "Number of files that are open."},
+	"open_table_definitions":                {prometheus.GaugeValue, "Number of cached table definitions."},
+	"open_tables":                           {prometheus.GaugeValue, "Number of tables that are open."},
+	"opened_files":                          {prometheus.CounterValue, "Total number of files that have been opened with my_open()."},
+	"opened_table_definitions":              {prometheus.CounterValue, "Total number of table definitions that have been cached."},
+	"opened_tables":                         {prometheus.CounterValue, "Total number of tables that have been opened."},
+	"prepared_stmt_count":                   {prometheus.GaugeValue, "Current number of prepared statements."},
+	"qcache_free_blocks":                    {prometheus.GaugeValue, "Number of free memory blocks in the query cache."},
+	"qcache_free_memory":                    {prometheus.GaugeValue, "Amount of free memory for the query cache."},
+	"qcache_hits":                           {prometheus.CounterValue, "Total number of query cache hits."},
+	"qcache_inserts":                        {prometheus.CounterValue, "Total number of queries added to the query cache."},
+	"qcache_lowmem_prunes":                  {prometheus.CounterValue, "Total number of queries deleted from the query cache because of low memory."},
+	"qcache_not_cached":                     {prometheus.CounterValue, "Total number of noncached queries."},
+	"qcache_queries_in_cache":               {prometheus.GaugeValue, "Number of queries registered in the query cache."},
+	"qcache_total_blocks":                   {prometheus.GaugeValue, "Total number of blocks in the query cache."},
+	"queries":                               {prometheus.CounterValue, "Total number of statements executed by the server, including statements executed within stored programs."},
+	"questions":                             {prometheus.CounterValue, "Total number of statements executed by the server, sent by clients."},
+	"select_full_join":                      {prometheus.CounterValue, "Total number of joins that perform table scans because they do not use indexes."},
+	"select_full_range_join":                {prometheus.CounterValue, "Total number of joins that used a range search on a reference table."},
+	"select_range":                          {prometheus.CounterValue, "Total number of joins that used ranges on the first table."},
+	"select_range_check":                    {prometheus.CounterValue, "Total number of joins without keys that check for key usage after each row."},
+	"select_scan":                           {prometheus.CounterValue, "Total number of joins that did a full scan of the first table."},
+	"slave_open_temp_tables":                {prometheus.GaugeValue, "Number of temporary tables that the replication SQL thread currently has open."},
+	"slave_running":                         {prometheus.GaugeValue, "Whether the replica is fully connected and both replication threads are running."},
+	"slow_launch_threads":                   {prometheus.CounterValue, "Total number of threads that have taken more than slow_launch_time seconds to create."},
+	"slow_queries":                          {prometheus.CounterValue, "Total number of queries that have taken more than long_query_time seconds."},
+	"sort_merge_passes":                     {prometheus.CounterValue, "Total number of merge passes that the sort algorithm has had to do."},
+	"sort_range":                            {prometheus.CounterValue, "Total number of sorts that were done using ranges."},
+	"sort_rows":                             {prometheus.CounterValue, "Total number of sorted rows."},
+	"sort_scan":                             {prometheus.CounterValue, "Total number of sorts that were done by scanning the table."},
+	"table_locks_immediate":                 {prometheus.CounterValue, "Total number of times that a request for a table lock could be granted immediately."},
+	"table_locks_waited":                    {prometheus.CounterValue, "Total number of times that a request for a table lock could not be granted immediately and a wait was needed."},
+	"table_open_cache_hits":                 {prometheus.CounterValue, "Total number of hits for open tables cache lookups."},
+	"table_open_cache_misses":               {prometheus.CounterValue, "Total number of misses for open tables cache lookups."},
+	"table_open_cache_overflows":            {prometheus.CounterValue, "Total number of overflows for the open tables cache."},
+	"threads_cached":                        {prometheus.GaugeValue, "Number of threads in the thread cache."},
+	"threads_connected":                     {prometheus.GaugeValue, "Number of currently open connections."},
+	"threads_created":                       {prometheus.CounterValue, "Total number of threads created to handle connections."},
+	"threads_running":                       {prometheus.GaugeValue, "Number of threads that are not sleeping."},
+	"uptime":                                {prometheus.CounterValue, "Number of seconds that the server has been up."},
+	"uptime_since_flush_status":             {prometheus.CounterValue, "Number of seconds since the most recent FLUSH STATUS statement."},
+	"wsrep_cluster_size":                    {prometheus.GaugeValue, "Current number of members in the Galera cluster."},
+	"wsrep_cluster_status":                  {prometheus.GaugeValue, "Whether the node is part of the primary component of the Galera cluster."},
+	"wsrep_connected":                       {prometheus.GaugeValue, "Whether the node is connected to the Galera cluster."},
+	"wsrep_flow_control_paused":             {prometheus.GaugeValue, "Fraction of time since the last FLUSH STATUS that replication was paused due to flow control."},
+	"wsrep_flow_control_recv":               {prometheus.CounterValue, "Total number of flow control pause events received."},
+	"wsrep_flow_control_sent":               {prometheus.CounterValue, "Total number of flow control pause events sent."},
+	"wsrep_local_bf_aborts":                 {prometheus.CounterValue, "Total number of local transactions aborted by replicated transactions."},
+	"wsrep_local_cert_failures":             {prometheus.CounterValue, "Total number of local transactions that failed the certification test."},
+	"wsrep_local_recv_queue":                {prometheus.GaugeValue, "Current length of the receive queue."},
+	"wsrep_local_send_queue":                {prometheus.GaugeValue, "Current length of the send queue."},
+	"wsrep_local_state":                     {prometheus.GaugeValue, "Internal Galera node state number."},
+	"wsrep_ready":                           {prometheus.GaugeValue, "Whether the node can accept queries."},
+	"wsrep_received":                        {prometheus.CounterValue, "Total number of write-sets received from other nodes."},
+	"wsrep_received_bytes":                  {prometheus.CounterValue, "Total size of write-sets received from other nodes."},
+	"wsrep_replicated":                      {prometheus.CounterValue, "Total number of write-sets replicated to other nodes."},
+	"wsrep_replicated_bytes":                {prometheus.CounterValue, "Total size of write-sets replicated to other nodes."},
+}
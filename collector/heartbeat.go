@@ -6,8 +6,10 @@ import (
 	"database/sql"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
 	"gopkg.in/alecthomas/kingpin.v2"
 )
 
@@ -19,6 +21,12 @@ const (
 	// The second column allows gets the server timestamp at the exact same
 	// time the query is run.
 	heartbeatQuery = "SELECT UNIX_TIMESTAMP(ts), UNIX_TIMESTAMP(NOW(6)), server_id from `%s`.`%s`"
+	// heartbeatWriteQuery updates the row of the current server like
+	// pt-heartbeat --update does, leaving any other columns untouched.
+	// The server_id is bound as a literal: with binlog_format STATEMENT or
+	// MIXED, @@server_id would be evaluated again by every replica.
+	heartbeatWriteQuery    = "INSERT INTO `%s`.`%s` (ts, server_id) VALUES (NOW(6), ?) ON DUPLICATE KEY UPDATE ts = VALUES(ts)"
+	heartbeatReadOnlyQuery = "SELECT @@read_only, @@server_id"
 )
 
 var (
@@ -30,6 +38,30 @@ var (
 		"collect.heartbeat.table",
 		"Table from where to collect heartbeat data",
 	).Default("heartbeat").String()
+	collectHeartbeatWrite = kingpin.Flag(
+		"collect.heartbeat.write",
+		"Update the heartbeat table from the exporter, instead of relying on a separate pt-heartbeat daemon",
+	).Default("false").Bool()
+	collectHeartbeatWriteInterval = kingpin.Flag(
+		"collect.heartbeat.write_interval",
+		"Interval between heartbeat table updates",
+	).Default("1s").Duration()
+)
+
+// Heartbeat writer metrics.
+var (
+	heartbeatWritesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: heartbeat,
+		Name:      "writes_total",
+		Help:      "Total number of heartbeat table updates attempted by the exporter.",
+	})
+	heartbeatWriteErrorsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: heartbeat,
+		Name:      "write_errors_total",
+		Help:      "Total number of failed heartbeat table updates.",
+	})
 )
 
 // Metric descriptors.
@@ -112,3 +144,52 @@ func (ScrapeHeartbeat) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
 
 	return nil
 }
+
+// StartHeartbeatWriter updates the heartbeat table every
+// collect.heartbeat.write_interval, if collect.heartbeat.write is set.
+// Only the server of dsn is written to: targets from /probe, service
+// discovery or other my.cnf sections are not.
+func StartHeartbeatWriter(dsn string) error {
+	if !*collectHeartbeatWrite {
+		return nil
+	}
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return err
+	}
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+
+	prometheus.MustRegister(heartbeatWritesTotal, heartbeatWriteErrorsTotal)
+	go func() {
+		ticker := time.NewTicker(*collectHeartbeatWriteInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			heartbeatWritesTotal.Inc()
+			if err := writeHeartbeat(db); err != nil {
+				heartbeatWriteErrorsTotal.Inc()
+				log.Errorln("Error writing heartbeat:", err)
+			}
+		}
+	}()
+	return nil
+}
+
+// writeHeartbeat stores the current timestamp for this server in the heartbeat table.
+// Read-only servers are skipped, so the writer can be enabled on every member
+// of a replication topology and only the source writes.
+func writeHeartbeat(db *sql.DB) error {
+	var (
+		readOnly bool
+		serverID uint32
+	)
+	if err := db.QueryRow(heartbeatReadOnlyQuery).Scan(&readOnly, &serverID); err != nil {
+		return err
+	}
+	if readOnly {
+		return nil
+	}
+	query := fmt.Sprintf(heartbeatWriteQuery, *collectHeartbeatDatabase, *collectHeartbeatTable)
+	_, err := db.Exec(query, serverID)
+	return err
+}
@@ -54,3 +54,33 @@ func TestScrapeHeartbeat(t *testing.T) {
 		t.Errorf("there were unfulfilled exceptions: %s", err)
 	}
 }
+
+func TestWriteHeartbeat(t *testing.T) {
+	_, err := kingpin.CommandLine.Parse([]string{
+		"--collect.heartbeat.database", "heartbeat-test",
+		"--collect.heartbeat.table", "heartbeat-test",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(heartbeatReadOnlyQuery)).WillReturnRows(sqlmock.NewRows([]string{"@@read_only", "@@server_id"}).AddRow(0, 42))
+	mock.ExpectExec(sanitizeQuery("INSERT INTO `heartbeat-test`.`heartbeat-test` (ts, server_id) VALUES (NOW(6), ?) ON DUPLICATE KEY UPDATE ts = VALUES(ts)")).
+		WithArgs(42).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := writeHeartbeat(db); err != nil {
+		t.Errorf("error calling function on test: %s", err)
+	}
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
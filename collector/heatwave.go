@@ -0,0 +1,143 @@
+// Scrape the HeatWave secondary engine tables `performance_schema.rpd_tables`
+// and `performance_schema.rpd_table_id`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Subsystem.
+const heatwave = "heatwave"
+
+const (
+	heatwaveEnabledQuery = `
+	SELECT COUNT(*)
+	  FROM information_schema.TABLES
+	 WHERE TABLE_SCHEMA = 'performance_schema' AND TABLE_NAME = 'rpd_tables'
+	`
+	heatwaveTablesQuery = `
+	SELECT i.SCHEMA_NAME, i.TABLE_NAME, t.LOAD_STATUS, ifnull(t.LOAD_PROGRESS, 0),
+	       ifnull(t.NROWS, 0), ifnull(t.SIZE_BYTES, 0), ifnull(t.QUERY_COUNT, 0)
+	  FROM performance_schema.rpd_tables t
+	  JOIN performance_schema.rpd_table_id i ON i.ID = t.ID
+	`
+	heatwaveSecondaryEngineTablesQuery = `
+	SELECT TABLE_SCHEMA, COUNT(*)
+	  FROM information_schema.TABLES
+	 WHERE UPPER(CREATE_OPTIONS) LIKE '%SECONDARY_ENGINE%RAPID%'
+	 GROUP BY TABLE_SCHEMA
+	`
+)
+
+// heatwaveLoadedStatus is the load status of a table available to HeatWave queries.
+const heatwaveLoadedStatus = "AVAIL_RPDGSTABSTATE"
+
+// Metric descriptors.
+var (
+	heatwaveTableLabels = []string{"schema", "table"}
+
+	heatwaveTableLoadedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, heatwave, "table_loaded"),
+		"Whether the table is loaded into HeatWave and available to offloaded queries.",
+		heatwaveTableLabels, nil,
+	)
+	heatwaveTableLoadStatusDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, heatwave, "table_load_status"),
+		"The load status of the table in HeatWave, always 1.",
+		[]string{"schema", "table", "status"}, nil,
+	)
+	heatwaveTableLoadProgressDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, heatwave, "table_load_progress_ratio"),
+		"The progress of loading the table into HeatWave, from 0 to 1.",
+		heatwaveTableLabels, nil,
+	)
+	heatwaveTableRowsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, heatwave, "table_rows"),
+		"The number of rows of the table loaded into HeatWave.",
+		heatwaveTableLabels, nil,
+	)
+	heatwaveTableSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, heatwave, "table_size_bytes"),
+		"The memory used by the table in HeatWave.",
+		heatwaveTableLabels, nil,
+	)
+	heatwaveTableQueriesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, heatwave, "table_queries_total"),
+		"The number of queries referencing the table that were offloaded to HeatWave.",
+		heatwaveTableLabels, nil,
+	)
+	heatwaveSecondaryEngineTablesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, heatwave, "secondary_engine_tables"),
+		"The number of tables defined with SECONDARY_ENGINE=RAPID, loaded or not.",
+		[]string{"schema"}, nil,
+	)
+)
+
+// ScrapeHeatWave collects the load status of the HeatWave secondary engine tables.
+type ScrapeHeatWave struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeHeatWave) Name() string {
+	return heatwave
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeHeatWave) Help() string {
+	return "Collect the HeatWave load status of secondary engine tables from performance_schema.rpd_tables"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeHeatWave) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var enabled int
+	if err := db.QueryRow(heatwaveEnabledQuery).Scan(&enabled); err != nil || enabled == 0 {
+		return err
+	}
+
+	tablesRows, err := db.Query(heatwaveTablesQuery)
+	if err != nil {
+		return err
+	}
+	defer tablesRows.Close()
+
+	var (
+		schema, table, status string
+		progress              float64
+		rows, size, queries   uint64
+	)
+	for tablesRows.Next() {
+		if err := tablesRows.Scan(&schema, &table, &status, &progress, &rows, &size, &queries); err != nil {
+			return err
+		}
+		loaded := 0.0
+		if status == heatwaveLoadedStatus {
+			loaded = 1
+		}
+		ch <- prometheus.MustNewConstMetric(heatwaveTableLoadedDesc, prometheus.GaugeValue, loaded, schema, table)
+		ch <- prometheus.MustNewConstMetric(heatwaveTableLoadStatusDesc, prometheus.GaugeValue, 1, schema, table, status)
+		ch <- prometheus.MustNewConstMetric(heatwaveTableLoadProgressDesc, prometheus.GaugeValue, progress/100, schema, table)
+		ch <- prometheus.MustNewConstMetric(heatwaveTableRowsDesc, prometheus.GaugeValue, float64(rows), schema, table)
+		ch <- prometheus.MustNewConstMetric(heatwaveTableSizeDesc, prometheus.GaugeValue, float64(size), schema, table)
+		ch <- prometheus.MustNewConstMetric(heatwaveTableQueriesDesc, prometheus.CounterValue, float64(queries), schema, table)
+	}
+	if err := tablesRows.Err(); err != nil {
+		return err
+	}
+
+	secondaryRows, err := db.Query(heatwaveSecondaryEngineTablesQuery)
+	if err != nil {
+		return err
+	}
+	defer secondaryRows.Close()
+
+	var count uint64
+	for secondaryRows.Next() {
+		if err := secondaryRows.Scan(&schema, &count); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(heatwaveSecondaryEngineTablesDesc, prometheus.GaugeValue, float64(count), schema)
+	}
+	return secondaryRows.Err()
+}
@@ -0,0 +1,64 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeHeatWave(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(heatwaveEnabledQuery)).WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+	columns := []string{"SCHEMA_NAME", "TABLE_NAME", "LOAD_STATUS", "LOAD_PROGRESS", "NROWS", "SIZE_BYTES", "QUERY_COUNT"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("tpch", "orders", "AVAIL_RPDGSTABSTATE", "100", "1500000", "268435456", "42").
+		AddRow("tpch", "lineitem", "LOADING_RPDGSTABSTATE", "37.5", "0", "0", "0")
+	mock.ExpectQuery(sanitizeQuery(heatwaveTablesQuery)).WillReturnRows(rows)
+	rows = sqlmock.NewRows([]string{"TABLE_SCHEMA", "COUNT(*)"}).AddRow("tpch", "3")
+	mock.ExpectQuery(sanitizeQuery(heatwaveSecondaryEngineTablesQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeHeatWave{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	orders := labelMap{"schema": "tpch", "table": "orders"}
+	lineitem := labelMap{"schema": "tpch", "table": "lineitem"}
+	metricExpected := []MetricResult{
+		{labels: orders, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "tpch", "table": "orders", "status": "AVAIL_RPDGSTABSTATE"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: orders, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: orders, value: 1500000, metricType: dto.MetricType_GAUGE},
+		{labels: orders, value: 268435456, metricType: dto.MetricType_GAUGE},
+		{labels: orders, value: 42, metricType: dto.MetricType_COUNTER},
+		{labels: lineitem, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "tpch", "table": "lineitem", "status": "LOADING_RPDGSTABSTATE"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: lineitem, value: 0.375, metricType: dto.MetricType_GAUGE},
+		{labels: lineitem, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: lineitem, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: lineitem, value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"schema": "tpch"}, value: 3, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
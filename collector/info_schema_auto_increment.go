@@ -34,6 +34,11 @@ var (
 		"The max value of an auto_increment column from information_schema.",
 		[]string{"schema", "table", "column"}, nil,
 	)
+	autoIncrementUtilizationDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "auto_increment", "utilization_ratio"),
+		"The ratio of the values of an auto_increment column used, given its data type and signedness.",
+		[]string{"schema", "table", "column"}, nil,
+	)
 )
 
 // ScrapeAutoIncrementColumns collects auto_increment column information.
@@ -76,6 +81,12 @@ func (ScrapeAutoIncrementColumns) Scrape(db *sql.DB, ch chan<- prometheus.Metric
 			globalInfoSchemaAutoIncrementMaxDesc, prometheus.GaugeValue, max,
 			schema, table, column,
 		)
+		// auto_increment is the next value, the column is exhausted once
+		// the previous one is the max.
+		ch <- prometheus.MustNewConstMetric(
+			autoIncrementUtilizationDesc, prometheus.GaugeValue, (value-1)/max,
+			schema, table, column,
+		)
 	}
-	return nil
+	return autoIncrementRows.Err()
 }
@@ -0,0 +1,54 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeAutoIncrementColumns(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"table_schema", "table_name", "column_name", "auto_increment", "max_int"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("shop", "orders", "id", "1610612737", "2147483647").
+		AddRow("shop", "tags", "id", "256", "255")
+	mock.ExpectQuery(sanitizeQuery(infoSchemaAutoIncrementQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeAutoIncrementColumns{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	orders := labelMap{"schema": "shop", "table": "orders", "column": "id"}
+	tags := labelMap{"schema": "shop", "table": "tags", "column": "id"}
+	metricExpected := []MetricResult{
+		{labels: orders, value: 1610612737, metricType: dto.MetricType_GAUGE},
+		{labels: orders, value: 2147483647, metricType: dto.MetricType_GAUGE},
+		{labels: orders, value: 1610612736.0 / 2147483647, metricType: dto.MetricType_GAUGE},
+		{labels: tags, value: 256, metricType: dto.MetricType_GAUGE},
+		{labels: tags, value: 255, metricType: dto.MetricType_GAUGE},
+		{labels: tags, value: 1, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,90 @@
+// Scrape `information_schema.column_statistics`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// columnStatisticsQuery reads the histograms created by ANALYZE TABLE ...
+// UPDATE HISTOGRAM. Their last-updated time is in UTC, so it is converted
+// to a Unix timestamp without the time zone of the session.
+const columnStatisticsQuery = `
+	SELECT SCHEMA_NAME, TABLE_NAME, COLUMN_NAME,
+	    JSON_UNQUOTE(JSON_EXTRACT(HISTOGRAM, '$."histogram-type"')),
+	    JSON_LENGTH(HISTOGRAM, '$.buckets'),
+	    TIMESTAMPDIFF(SECOND, '1970-01-01 00:00:00',
+	      CAST(JSON_UNQUOTE(JSON_EXTRACT(HISTOGRAM, '$."last-updated"')) AS DATETIME))
+	  FROM information_schema.COLUMN_STATISTICS
+	`
+
+// Metric descriptors.
+var (
+	infoSchemaColumnHistogramBucketsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "column_histogram_buckets"),
+		"The number of buckets of the optimizer histogram of the column.",
+		[]string{"schema", "table", "column", "histogram_type"}, nil,
+	)
+	infoSchemaColumnHistogramLastUpdatedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "column_histogram_last_updated_timestamp_seconds"),
+		"The time the optimizer histogram of the column was last updated.",
+		[]string{"schema", "table", "column"}, nil,
+	)
+	infoSchemaColumnHistogramsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "column_histograms"),
+		"The number of columns with an optimizer histogram by schema.",
+		[]string{"schema"}, nil,
+	)
+)
+
+// ScrapeColumnStatistics collects from `information_schema.column_statistics`.
+type ScrapeColumnStatistics struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeColumnStatistics) Name() string {
+	return informationSchema + ".column_statistics"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeColumnStatistics) Help() string {
+	return "Collect the optimizer histograms and their last update time from information_schema.column_statistics"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeColumnStatistics) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	columnStatisticsRows, err := db.Query(columnStatisticsQuery)
+	if err != nil {
+		return err
+	}
+	defer columnStatisticsRows.Close()
+
+	var (
+		schema, table, column, histogramType string
+		buckets, lastUpdated                 float64
+	)
+	histograms := map[string]float64{}
+
+	for columnStatisticsRows.Next() {
+		if err := columnStatisticsRows.Scan(&schema, &table, &column, &histogramType, &buckets, &lastUpdated); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaColumnHistogramBucketsDesc, prometheus.GaugeValue, buckets,
+			schema, table, column, histogramType,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaColumnHistogramLastUpdatedDesc, prometheus.GaugeValue, lastUpdated,
+			schema, table, column,
+		)
+		histograms[schema]++
+	}
+	if err := columnStatisticsRows.Err(); err != nil {
+		return err
+	}
+	for schema, count := range histograms {
+		ch <- prometheus.MustNewConstMetric(infoSchemaColumnHistogramsDesc, prometheus.GaugeValue, count, schema)
+	}
+	return nil
+}
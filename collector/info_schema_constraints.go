@@ -0,0 +1,90 @@
+// Scrape `information_schema.referential_constraints` and `information_schema.table_constraints`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	foreignKeysQuery = `
+	SELECT CONSTRAINT_SCHEMA, COUNT(*)
+	  FROM information_schema.referential_constraints
+	  WHERE CONSTRAINT_SCHEMA NOT IN ('mysql', 'performance_schema', 'information_schema', 'sys')
+	  GROUP BY CONSTRAINT_SCHEMA
+	`
+	tablesWithoutPrimaryKeyQuery = `
+	SELECT t.TABLE_SCHEMA, t.TABLE_NAME, ifnull(t.ENGINE, 'NONE') as ENGINE
+	  FROM information_schema.tables t
+	  LEFT JOIN information_schema.table_constraints c
+	    ON c.TABLE_SCHEMA = t.TABLE_SCHEMA
+	    AND c.TABLE_NAME = t.TABLE_NAME
+	    AND c.CONSTRAINT_TYPE = 'PRIMARY KEY'
+	  WHERE t.TABLE_TYPE = 'BASE TABLE'
+	    AND c.CONSTRAINT_NAME IS NULL
+	    AND t.TABLE_SCHEMA NOT IN ('mysql', 'performance_schema', 'information_schema', 'sys')
+	`
+)
+
+// Metric descriptors.
+var (
+	infoSchemaForeignKeysDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "foreign_keys"),
+		"The number of foreign key constraints by schema.",
+		[]string{"schema"}, nil,
+	)
+	infoSchemaTableWithoutPrimaryKeyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "table_without_primary_key_info"),
+		"A table without a primary key, which makes row based replication scan the table for every row event.",
+		[]string{"schema", "table", "engine"}, nil,
+	)
+)
+
+// ScrapeConstraints collects from `information_schema.referential_constraints` and `information_schema.table_constraints`.
+type ScrapeConstraints struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeConstraints) Name() string {
+	return informationSchema + ".constraints"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeConstraints) Help() string {
+	return "Collect foreign key counts and tables without primary keys from information_schema"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeConstraints) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	foreignKeysRows, err := db.Query(foreignKeysQuery)
+	if err != nil {
+		return err
+	}
+	defer foreignKeysRows.Close()
+
+	var (
+		schema, table, engine string
+		count                 uint64
+	)
+	for foreignKeysRows.Next() {
+		if err := foreignKeysRows.Scan(&schema, &count); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(infoSchemaForeignKeysDesc, prometheus.GaugeValue, float64(count), schema)
+	}
+
+	tablesWithoutPrimaryKeyRows, err := db.Query(tablesWithoutPrimaryKeyQuery)
+	if err != nil {
+		return err
+	}
+	defer tablesWithoutPrimaryKeyRows.Close()
+
+	for tablesWithoutPrimaryKeyRows.Next() {
+		if err := tablesWithoutPrimaryKeyRows.Scan(&schema, &table, &engine); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(infoSchemaTableWithoutPrimaryKeyDesc, prometheus.GaugeValue, 1, schema, table, engine)
+	}
+	return nil
+}
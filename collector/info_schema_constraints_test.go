@@ -0,0 +1,47 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeConstraints(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(foreignKeysQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"CONSTRAINT_SCHEMA", "COUNT(*)"}).AddRow("shop", "7"))
+	mock.ExpectQuery(sanitizeQuery(tablesWithoutPrimaryKeyQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"TABLE_SCHEMA", "TABLE_NAME", "ENGINE"}).AddRow("shop", "audit_log", "InnoDB"))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeConstraints{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"schema": "shop"}, value: 7, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop", "table": "audit_log", "engine": "InnoDB"}, value: 1, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,97 @@
+// Scrape `information_schema.events`.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	eventSchedulerQuery = `SELECT @@event_scheduler`
+	eventsQuery         = `
+	SELECT
+	    EVENT_SCHEMA, EVENT_NAME, STATUS,
+	    TIMESTAMPDIFF(SECOND, LAST_EXECUTED, NOW()) as LAST_EXECUTED_AGE
+	  FROM information_schema.events
+	`
+)
+
+// Metric descriptors.
+var (
+	infoSchemaEventSchedulerDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "event_scheduler_enabled"),
+		"Whether the event scheduler is running.",
+		nil, nil,
+	)
+	infoSchemaEventsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "events"),
+		"The number of scheduled events by status.",
+		[]string{"status"}, nil,
+	)
+	infoSchemaEventLastExecutedAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "event_last_executed_age_seconds"),
+		"The number of seconds since the event was last executed.",
+		[]string{"schema", "name"}, nil,
+	)
+)
+
+// ScrapeEvents collects from `information_schema.events`.
+type ScrapeEvents struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeEvents) Name() string {
+	return informationSchema + ".events"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeEvents) Help() string {
+	return "Collect event scheduler state and metrics from information_schema.events"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeEvents) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var scheduler string
+	if err := db.QueryRow(eventSchedulerQuery).Scan(&scheduler); err != nil {
+		return err
+	}
+	enabled := 0.0
+	if strings.ToUpper(scheduler) == "ON" {
+		enabled = 1
+	}
+	ch <- prometheus.MustNewConstMetric(infoSchemaEventSchedulerDesc, prometheus.GaugeValue, enabled)
+
+	eventsRows, err := db.Query(eventsQuery)
+	if err != nil {
+		return err
+	}
+	defer eventsRows.Close()
+
+	var (
+		eventSchema, eventName, status string
+		lastExecutedAge                sql.NullInt64
+	)
+	statuses := map[string]uint64{
+		"enabled":            0,
+		"disabled":           0,
+		"slaveside_disabled": 0,
+	}
+	for eventsRows.Next() {
+		if err := eventsRows.Scan(&eventSchema, &eventName, &status, &lastExecutedAge); err != nil {
+			return err
+		}
+		statuses[strings.ToLower(status)]++
+		if lastExecutedAge.Valid {
+			ch <- prometheus.MustNewConstMetric(
+				infoSchemaEventLastExecutedAgeDesc, prometheus.GaugeValue, float64(lastExecutedAge.Int64),
+				eventSchema, eventName,
+			)
+		}
+	}
+	for status, count := range statuses {
+		ch <- prometheus.MustNewConstMetric(infoSchemaEventsDesc, prometheus.GaugeValue, float64(count), status)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeEvents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(eventSchedulerQuery)).WillReturnRows(sqlmock.NewRows([]string{"@@event_scheduler"}).AddRow("ON"))
+
+	columns := []string{"EVENT_SCHEMA", "EVENT_NAME", "STATUS", "LAST_EXECUTED_AGE"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("shop", "purge_sessions", "ENABLED", "55").
+		AddRow("shop", "rollup", "ENABLED", nil).
+		AddRow("shop", "old_job", "DISABLED", "864000")
+	mock.ExpectQuery(sanitizeQuery(eventsQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeEvents{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("Metrics comparison", t, func() {
+		convey.So(readMetric(<-ch), convey.ShouldResemble, MetricResult{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE})
+		convey.So(readMetric(<-ch), convey.ShouldResemble, MetricResult{labels: labelMap{"schema": "shop", "name": "purge_sessions"}, value: 55, metricType: dto.MetricType_GAUGE})
+		convey.So(readMetric(<-ch), convey.ShouldResemble, MetricResult{labels: labelMap{"schema": "shop", "name": "old_job"}, value: 864000, metricType: dto.MetricType_GAUGE})
+
+		// Status counts are emitted in map order.
+		got := map[string]float64{}
+		for m := range ch {
+			r := readMetric(m)
+			got[r.labels["status"]] = r.value
+		}
+		convey.So(got, convey.ShouldResemble, map[string]float64{"enabled": 2, "disabled": 1, "slaveside_disabled": 0})
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
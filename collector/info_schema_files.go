@@ -0,0 +1,99 @@
+// Scrape `information_schema.files`.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// innodbUndoTempFilesQuery joins the tablespace state, which is available as
+// of MySQL 8.0.14. MAXIMUM_SIZE is NULL for files which may grow unbounded.
+const innodbUndoTempFilesQuery = `
+	SELECT
+	    f.TABLESPACE_NAME,
+	    f.FILE_TYPE,
+	    ifnull(f.TOTAL_EXTENTS * f.EXTENT_SIZE, 0) as SIZE,
+	    ifnull(f.FREE_EXTENTS * f.EXTENT_SIZE, 0) as FREE,
+	    ifnull(f.AUTOEXTEND_SIZE, 0) as AUTOEXTEND_SIZE,
+	    ifnull(f.MAXIMUM_SIZE, 0) as MAXIMUM_SIZE,
+	    ifnull(t.STATE, '') as STATE
+	  FROM information_schema.files f
+	  LEFT JOIN information_schema.innodb_tablespaces t
+	    ON t.NAME = f.TABLESPACE_NAME
+	  WHERE f.FILE_TYPE IN ('UNDO LOG', 'TEMPORARY')
+	`
+
+// Metric descriptors.
+var (
+	infoSchemaInnodbFileSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_file_size_bytes"),
+		"The size of the undo or temporary tablespace file.",
+		[]string{"tablespace_name", "file_type"}, nil,
+	)
+	infoSchemaInnodbFileFreeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_file_free_bytes"),
+		"The size of the free extents in the undo or temporary tablespace file.",
+		[]string{"tablespace_name", "file_type"}, nil,
+	)
+	infoSchemaInnodbFileAutoextendDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_file_autoextend_size_bytes"),
+		"The size by which the undo or temporary tablespace file is extended when full, 0 if it does not autoextend.",
+		[]string{"tablespace_name", "file_type"}, nil,
+	)
+	infoSchemaInnodbFileMaximumSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_file_maximum_size_bytes"),
+		"The maximum size of the undo or temporary tablespace file, 0 if unlimited.",
+		[]string{"tablespace_name", "file_type"}, nil,
+	)
+	infoSchemaInnodbFileStateDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_file_state_info"),
+		"The state of the undo tablespace, e.g. active, inactive or empty.",
+		[]string{"tablespace_name", "file_type", "state"}, nil,
+	)
+)
+
+// ScrapeInnodbUndoTempFiles collects from `information_schema.files`.
+type ScrapeInnodbUndoTempFiles struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeInnodbUndoTempFiles) Name() string {
+	return informationSchema + ".innodb_undo_temp_files"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeInnodbUndoTempFiles) Help() string {
+	return "Collect undo and temporary tablespace file sizes from information_schema.files"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeInnodbUndoTempFiles) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	innodbFilesRows, err := db.Query(innodbUndoTempFilesQuery)
+	if err != nil {
+		return err
+	}
+	defer innodbFilesRows.Close()
+
+	var (
+		tablespaceName, fileType, state string
+		size, free, autoextend, maximum uint64
+	)
+	for innodbFilesRows.Next() {
+		if err := innodbFilesRows.Scan(
+			&tablespaceName, &fileType, &size, &free, &autoextend, &maximum, &state,
+		); err != nil {
+			return err
+		}
+		fileType = strings.Replace(strings.ToLower(fileType), " ", "_", -1)
+		ch <- prometheus.MustNewConstMetric(infoSchemaInnodbFileSizeDesc, prometheus.GaugeValue, float64(size), tablespaceName, fileType)
+		ch <- prometheus.MustNewConstMetric(infoSchemaInnodbFileFreeDesc, prometheus.GaugeValue, float64(free), tablespaceName, fileType)
+		ch <- prometheus.MustNewConstMetric(infoSchemaInnodbFileAutoextendDesc, prometheus.GaugeValue, float64(autoextend), tablespaceName, fileType)
+		ch <- prometheus.MustNewConstMetric(infoSchemaInnodbFileMaximumSizeDesc, prometheus.GaugeValue, float64(maximum), tablespaceName, fileType)
+		if state != "" {
+			ch <- prometheus.MustNewConstMetric(infoSchemaInnodbFileStateDesc, prometheus.GaugeValue, 1, tablespaceName, fileType, strings.ToLower(state))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeInnodbUndoTempFiles(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"TABLESPACE_NAME", "FILE_TYPE", "SIZE", "FREE", "AUTOEXTEND_SIZE", "MAXIMUM_SIZE", "STATE"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("innodb_undo_001", "UNDO LOG", "16777216", "2097152", "0", "0", "active").
+		AddRow("innodb_temporary", "TEMPORARY", "12582912", "0", "67108864", "0", "")
+	mock.ExpectQuery(sanitizeQuery(innodbUndoTempFilesQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeInnodbUndoTempFiles{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"tablespace_name": "innodb_undo_001", "file_type": "undo_log"}, value: 16777216, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"tablespace_name": "innodb_undo_001", "file_type": "undo_log"}, value: 2097152, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"tablespace_name": "innodb_undo_001", "file_type": "undo_log"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"tablespace_name": "innodb_undo_001", "file_type": "undo_log"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"tablespace_name": "innodb_undo_001", "file_type": "undo_log", "state": "active"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"tablespace_name": "innodb_temporary", "file_type": "temporary"}, value: 12582912, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"tablespace_name": "innodb_temporary", "file_type": "temporary"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"tablespace_name": "innodb_temporary", "file_type": "temporary"}, value: 67108864, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"tablespace_name": "innodb_temporary", "file_type": "temporary"}, value: 0, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,51 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeInnodbBufferPage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"TABLE_NAME", "COUNT(*)", "SUM(DATA_SIZE)"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("`shop`.`orders`", "5000", "70000000").
+		AddRow("SYS_TABLES", "2", "1000")
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(innodbBufferPageQuery, 20))).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeInnodbBufferPage{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"schema": "shop", "table": "orders"}, value: 5000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop", "table": "orders"}, value: 70000000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "", "table": "SYS_TABLES"}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "", "table": "SYS_TABLES"}, value: 1000, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,113 @@
+// Scrape `information_schema.INNODB_BUFFER_POOL_STATS`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const innodbBufferPoolStatsQuery = `
+	SELECT
+	    POOL_ID, POOL_SIZE,
+	    FREE_BUFFERS, DATABASE_PAGES, OLD_DATABASE_PAGES, MODIFIED_DATABASE_PAGES,
+	    PAGES_MADE_YOUNG, PAGES_NOT_MADE_YOUNG,
+	    NUMBER_PAGES_READ, NUMBER_PAGES_CREATED, NUMBER_PAGES_WRITTEN,
+	    NUMBER_PAGES_READ_AHEAD, NUMBER_READ_AHEAD_EVICTED
+	  FROM information_schema.innodb_buffer_pool_stats
+	`
+
+// Metric descriptors.
+var (
+	infoSchemaInnodbBufferPoolSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_buffer_pool_size_pages"),
+		"The size of the buffer pool instance in pages.",
+		[]string{"pool_id"}, nil,
+	)
+	infoSchemaInnodbBufferPoolPagesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_buffer_pool_pages"),
+		"The number of pages in the buffer pool instance by state.",
+		[]string{"pool_id", "state"}, nil,
+	)
+	infoSchemaInnodbBufferPoolYoungDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_buffer_pool_pages_made_young_total"),
+		"The number of pages made young in the buffer pool instance.",
+		[]string{"pool_id"}, nil,
+	)
+	infoSchemaInnodbBufferPoolNotYoungDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_buffer_pool_pages_not_made_young_total"),
+		"The number of pages not made young in the buffer pool instance.",
+		[]string{"pool_id"}, nil,
+	)
+	infoSchemaInnodbBufferPoolPageOpsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_buffer_pool_page_ops_total"),
+		"The number of pages read, created or written in the buffer pool instance.",
+		[]string{"pool_id", "operation"}, nil,
+	)
+	infoSchemaInnodbBufferPoolReadAheadDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_buffer_pool_read_ahead_total"),
+		"The number of pages read ahead into the buffer pool instance.",
+		[]string{"pool_id"}, nil,
+	)
+	infoSchemaInnodbBufferPoolReadAheadEvictedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_buffer_pool_read_ahead_evicted_total"),
+		"The number of pages read ahead into the buffer pool instance which were evicted without being accessed.",
+		[]string{"pool_id"}, nil,
+	)
+)
+
+// ScrapeInnodbBufferPoolStats collects from `information_schema.innodb_buffer_pool_stats`.
+type ScrapeInnodbBufferPoolStats struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeInnodbBufferPoolStats) Name() string {
+	return informationSchema + ".innodb_buffer_pool_stats"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeInnodbBufferPoolStats) Help() string {
+	return "Collect metrics from information_schema.innodb_buffer_pool_stats"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeInnodbBufferPoolStats) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	innodbBufferPoolStatsRows, err := db.Query(innodbBufferPoolStatsQuery)
+	if err != nil {
+		return err
+	}
+	defer innodbBufferPoolStatsRows.Close()
+
+	var (
+		poolID                           string
+		poolSize, free, data, old, dirty uint64
+		young, notYoung                  uint64
+		read, created, written           uint64
+		readAhead, readAheadEvicted      uint64
+	)
+
+	for innodbBufferPoolStatsRows.Next() {
+		if err := innodbBufferPoolStatsRows.Scan(
+			&poolID, &poolSize,
+			&free, &data, &old, &dirty,
+			&young, &notYoung,
+			&read, &created, &written,
+			&readAhead, &readAheadEvicted,
+		); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(infoSchemaInnodbBufferPoolSizeDesc, prometheus.GaugeValue, float64(poolSize), poolID)
+		ch <- prometheus.MustNewConstMetric(infoSchemaInnodbBufferPoolPagesDesc, prometheus.GaugeValue, float64(free), poolID, "free")
+		ch <- prometheus.MustNewConstMetric(infoSchemaInnodbBufferPoolPagesDesc, prometheus.GaugeValue, float64(data), poolID, "data")
+		ch <- prometheus.MustNewConstMetric(infoSchemaInnodbBufferPoolPagesDesc, prometheus.GaugeValue, float64(old), poolID, "old")
+		ch <- prometheus.MustNewConstMetric(infoSchemaInnodbBufferPoolPagesDesc, prometheus.GaugeValue, float64(dirty), poolID, "dirty")
+		ch <- prometheus.MustNewConstMetric(infoSchemaInnodbBufferPoolYoungDesc, prometheus.CounterValue, float64(young), poolID)
+		ch <- prometheus.MustNewConstMetric(infoSchemaInnodbBufferPoolNotYoungDesc, prometheus.CounterValue, float64(notYoung), poolID)
+		ch <- prometheus.MustNewConstMetric(infoSchemaInnodbBufferPoolPageOpsDesc, prometheus.CounterValue, float64(read), poolID, "read")
+		ch <- prometheus.MustNewConstMetric(infoSchemaInnodbBufferPoolPageOpsDesc, prometheus.CounterValue, float64(created), poolID, "created")
+		ch <- prometheus.MustNewConstMetric(infoSchemaInnodbBufferPoolPageOpsDesc, prometheus.CounterValue, float64(written), poolID, "written")
+		ch <- prometheus.MustNewConstMetric(infoSchemaInnodbBufferPoolReadAheadDesc, prometheus.CounterValue, float64(readAhead), poolID)
+		ch <- prometheus.MustNewConstMetric(infoSchemaInnodbBufferPoolReadAheadEvictedDesc, prometheus.CounterValue, float64(readAheadEvicted), poolID)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeInnodbBufferPoolStats(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{
+		"POOL_ID", "POOL_SIZE",
+		"FREE_BUFFERS", "DATABASE_PAGES", "OLD_DATABASE_PAGES", "MODIFIED_DATABASE_PAGES",
+		"PAGES_MADE_YOUNG", "PAGES_NOT_MADE_YOUNG",
+		"NUMBER_PAGES_READ", "NUMBER_PAGES_CREATED", "NUMBER_PAGES_WRITTEN",
+		"NUMBER_PAGES_READ_AHEAD", "NUMBER_READ_AHEAD_EVICTED",
+	}
+	rows := sqlmock.NewRows(columns).
+		AddRow("0", "8192", "1024", "7000", "2580", "120", "300", "4000", "9000", "500", "7000", "64", "2")
+	mock.ExpectQuery(sanitizeQuery(innodbBufferPoolStatsQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeInnodbBufferPoolStats{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"pool_id": "0"}, value: 8192, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"pool_id": "0", "state": "free"}, value: 1024, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"pool_id": "0", "state": "data"}, value: 7000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"pool_id": "0", "state": "old"}, value: 2580, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"pool_id": "0", "state": "dirty"}, value: 120, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"pool_id": "0"}, value: 300, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"pool_id": "0"}, value: 4000, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"pool_id": "0", "operation": "read"}, value: 9000, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"pool_id": "0", "operation": "created"}, value: 500, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"pool_id": "0", "operation": "written"}, value: 7000, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"pool_id": "0"}, value: 64, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"pool_id": "0"}, value: 2, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,200 @@
+// Scrape InnoDB full-text search auxiliary tables and cache configuration.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const (
+	innodbFulltextVariablesQuery = `
+	SHOW GLOBAL VARIABLES
+	  WHERE Variable_name IN ('innodb_ft_aux_table', 'innodb_ft_cache_size', 'innodb_ft_total_cache_size')
+	`
+	// Auxiliary tables are named fts_<table_id in hex>_..., so they can
+	// be attributed to their parent table by id.
+	innodbFulltextAuxTablesQuery = `
+	SELECT
+	    t.NAME,
+	    CASE
+	      WHEN ts.NAME LIKE '%_index_%' THEN 'index'
+	      WHEN ts.NAME LIKE '%being_deleted%' THEN 'being_deleted'
+	      WHEN ts.NAME LIKE '%deleted%' THEN 'deleted'
+	      ELSE 'config'
+	    END AS AUX_TYPE,
+	    SUM(ts.FILE_SIZE) AS FILE_SIZE,
+	    SUM(ts.ALLOCATED_SIZE) AS ALLOCATED_SIZE
+	  FROM information_schema.innodb_tablespaces ts
+	  JOIN information_schema.innodb_tables t
+	    ON t.TABLE_ID = CONV(SUBSTRING(ts.NAME, LOCATE('/fts_', ts.NAME) + 5, 16), 16, 10)
+	  WHERE ts.NAME LIKE '%/fts_%'
+	    AND REPLACE(t.NAME, '/', '.') REGEXP ?
+	  GROUP BY t.NAME, AUX_TYPE
+	`
+	innodbFulltextDeletedQuery = `
+	SELECT COUNT(*) FROM information_schema.innodb_ft_deleted
+	`
+	innodbFulltextConfigQuery = `
+	SELECT * FROM information_schema.innodb_ft_config
+	`
+)
+
+// Tunable flags.
+var (
+	innodbFulltextFilter = kingpin.Flag(
+		"collect.info_schema.innodb_fulltext.filter",
+		"RegEx schema.table filter for full-text search auxiliary tables",
+	).Default(".*").String()
+)
+
+// Metric descriptors.
+var (
+	infoSchemaInnodbFtCacheSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_ft_cache_size_bytes"),
+		"The memory allocated for the full-text search index cache of each table.",
+		nil, nil,
+	)
+	infoSchemaInnodbFtTotalCacheSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_ft_total_cache_size_bytes"),
+		"The total memory allocated for full-text search index caches of all tables.",
+		nil, nil,
+	)
+	infoSchemaInnodbFtAuxTableSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_ft_aux_table_size_bytes"),
+		"The apparent size of the full-text search auxiliary tables by parent table and auxiliary table type.",
+		[]string{"schema", "table", "type"}, nil,
+	)
+	infoSchemaInnodbFtAuxTableAllocatedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_ft_aux_table_allocated_bytes"),
+		"The allocated size of the full-text search auxiliary tables by parent table and auxiliary table type.",
+		[]string{"schema", "table", "type"}, nil,
+	)
+	infoSchemaInnodbFtDeletedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_ft_deleted_rows"),
+		"The number of rows deleted from the table but not yet removed from its full-text index, for the table set in innodb_ft_aux_table.",
+		[]string{"schema", "table"}, nil,
+	)
+	infoSchemaInnodbFtConfigDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_ft_config"),
+		"The numeric full-text index configuration values for the table set in innodb_ft_aux_table.",
+		[]string{"schema", "table", "key"}, nil,
+	)
+)
+
+// ScrapeInnodbFulltext collects full-text search auxiliary table and cache metrics.
+type ScrapeInnodbFulltext struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeInnodbFulltext) Name() string {
+	return informationSchema + ".innodb_fulltext"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeInnodbFulltext) Help() string {
+	return "Collect InnoDB full-text search auxiliary table sizes and cache configuration"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeInnodbFulltext) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	variablesRows, err := db.Query(innodbFulltextVariablesQuery)
+	if err != nil {
+		return err
+	}
+	defer variablesRows.Close()
+
+	var (
+		key, val string
+		auxTable string
+	)
+	for variablesRows.Next() {
+		if err := variablesRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		switch key {
+		case "innodb_ft_aux_table":
+			auxTable = val
+		case "innodb_ft_cache_size":
+			if size, ok := parseStatus(sql.RawBytes(val)); ok {
+				ch <- prometheus.MustNewConstMetric(infoSchemaInnodbFtCacheSizeDesc, prometheus.GaugeValue, size)
+			}
+		case "innodb_ft_total_cache_size":
+			if size, ok := parseStatus(sql.RawBytes(val)); ok {
+				ch <- prometheus.MustNewConstMetric(infoSchemaInnodbFtTotalCacheSizeDesc, prometheus.GaugeValue, size)
+			}
+		}
+	}
+
+	auxTablesRows, err := db.Query(innodbFulltextAuxTablesQuery, *innodbFulltextFilter)
+	if err != nil {
+		return err
+	}
+	defer auxTablesRows.Close()
+
+	var (
+		tableName, auxType      string
+		fileSize, allocatedSize float64
+	)
+	for auxTablesRows.Next() {
+		if err := auxTablesRows.Scan(&tableName, &auxType, &fileSize, &allocatedSize); err != nil {
+			return err
+		}
+		schema, table := splitInnodbTableName(tableName)
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaInnodbFtAuxTableSizeDesc, prometheus.GaugeValue, fileSize,
+			schema, table, auxType,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaInnodbFtAuxTableAllocatedDesc, prometheus.GaugeValue, allocatedSize,
+			schema, table, auxType,
+		)
+	}
+
+	// innodb_ft_deleted and innodb_ft_config only report on the table
+	// named in innodb_ft_aux_table, which is left to the operator to set.
+	if auxTable == "" {
+		return nil
+	}
+	schema, table := splitInnodbTableName(auxTable)
+
+	var deleted uint64
+	if err := db.QueryRow(innodbFulltextDeletedQuery).Scan(&deleted); err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(
+		infoSchemaInnodbFtDeletedDesc, prometheus.GaugeValue, float64(deleted),
+		schema, table,
+	)
+
+	configRows, err := db.Query(innodbFulltextConfigQuery)
+	if err != nil {
+		return err
+	}
+	defer configRows.Close()
+
+	var value sql.RawBytes
+	for configRows.Next() {
+		if err := configRows.Scan(&key, &value); err != nil {
+			return err
+		}
+		if floatVal, ok := parseStatus(value); ok {
+			ch <- prometheus.MustNewConstMetric(
+				infoSchemaInnodbFtConfigDesc, prometheus.GaugeValue, floatVal,
+				schema, table, key,
+			)
+		}
+	}
+	return nil
+}
+
+// splitInnodbTableName splits an InnoDB internal `db/table` name.
+func splitInnodbTableName(name string) (string, string) {
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) != 2 {
+		return "", name
+	}
+	return parts[0], parts[1]
+}
@@ -0,0 +1,71 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeInnodbFulltext(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(innodbFulltextVariablesQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"Variable_name", "Value"}).
+			AddRow("innodb_ft_aux_table", "db1/articles").
+			AddRow("innodb_ft_cache_size", "8000000").
+			AddRow("innodb_ft_total_cache_size", "640000000"))
+
+	columns := []string{"NAME", "AUX_TYPE", "FILE_SIZE", "ALLOCATED_SIZE"}
+	mock.ExpectQuery(sanitizeQuery(innodbFulltextAuxTablesQuery)).WithArgs(".*").WillReturnRows(
+		sqlmock.NewRows(columns).
+			AddRow("db1/articles", "deleted", "98304", "65536").
+			AddRow("db1/articles", "index", "589824", "524288"))
+
+	mock.ExpectQuery(sanitizeQuery(innodbFulltextDeletedQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(42))
+	mock.ExpectQuery(sanitizeQuery(innodbFulltextConfigQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"KEY", "VALUE"}).
+			AddRow("optimize_checkpoint_limit", "180").
+			AddRow("synced_doc_id", "1001").
+			AddRow("stopword_table_name", "").
+			AddRow("use_stopword", "1"))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeInnodbFulltext{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{}, value: 8000000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 640000000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "db1", "table": "articles", "type": "deleted"}, value: 98304, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "db1", "table": "articles", "type": "deleted"}, value: 65536, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "db1", "table": "articles", "type": "index"}, value: 589824, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "db1", "table": "articles", "type": "index"}, value: 524288, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "db1", "table": "articles"}, value: 42, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "db1", "table": "articles", "key": "optimize_checkpoint_limit"}, value: 180, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "db1", "table": "articles", "key": "synced_doc_id"}, value: 1001, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "db1", "table": "articles", "key": "use_stopword"}, value: 1, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
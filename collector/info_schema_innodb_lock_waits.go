@@ -0,0 +1,111 @@
+// Scrape `information_schema.INNODB_LOCK_WAITS`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The INNODB_LOCKS and INNODB_LOCK_WAITS tables were removed in MySQL 8.0,
+// see perf_schema.data_locks for newer servers.
+const (
+	innodbLockWaitsQuery = `
+	SELECT
+	    COUNT(*),
+	    COUNT(DISTINCT w.requesting_trx_id),
+	    COUNT(DISTINCT w.blocking_trx_id),
+	    ifnull(MAX(TIMESTAMPDIFF(SECOND, r.trx_wait_started, NOW())), 0)
+	  FROM information_schema.innodb_lock_waits w
+	  JOIN information_schema.innodb_trx r
+	    ON r.trx_id = w.requesting_trx_id
+	`
+	innodbLockWaitsByTableQuery = `
+	SELECT l.lock_table, COUNT(*)
+	  FROM information_schema.innodb_lock_waits w
+	  JOIN information_schema.innodb_locks l
+	    ON l.lock_id = w.requested_lock_id
+	  GROUP BY l.lock_table
+	`
+)
+
+// Metric descriptors.
+var (
+	infoSchemaInnodbLockWaitsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_lock_waits"),
+		"The number of InnoDB lock waits.",
+		nil, nil,
+	)
+	infoSchemaInnodbLockWaitsBlockedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_lock_waits_blocked_transactions"),
+		"The number of InnoDB transactions waiting for a lock.",
+		nil, nil,
+	)
+	infoSchemaInnodbLockWaitsBlockingDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_lock_waits_blocking_transactions"),
+		"The number of InnoDB transactions holding a lock another transaction waits for.",
+		nil, nil,
+	)
+	infoSchemaInnodbLockWaitsOldestAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_lock_waits_oldest_age_seconds"),
+		"The age of the oldest InnoDB lock wait.",
+		nil, nil,
+	)
+	infoSchemaInnodbLockWaitsByTableDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_lock_waits_by_table"),
+		"The number of InnoDB lock waits by table.",
+		[]string{"schema", "table"}, nil,
+	)
+)
+
+// ScrapeInnodbLockWaits collects from `information_schema.innodb_lock_waits`.
+type ScrapeInnodbLockWaits struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeInnodbLockWaits) Name() string {
+	return informationSchema + ".innodb_lock_waits"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeInnodbLockWaits) Help() string {
+	return "Collect blocked and blocking transactions from information_schema.innodb_lock_waits (MySQL 5.6 and 5.7)"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeInnodbLockWaits) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var waits, blocked, blocking, oldestAge uint64
+	if err := db.QueryRow(innodbLockWaitsQuery).Scan(&waits, &blocked, &blocking, &oldestAge); err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(infoSchemaInnodbLockWaitsDesc, prometheus.GaugeValue, float64(waits))
+	ch <- prometheus.MustNewConstMetric(infoSchemaInnodbLockWaitsBlockedDesc, prometheus.GaugeValue, float64(blocked))
+	ch <- prometheus.MustNewConstMetric(infoSchemaInnodbLockWaitsBlockingDesc, prometheus.GaugeValue, float64(blocking))
+	ch <- prometheus.MustNewConstMetric(infoSchemaInnodbLockWaitsOldestAgeDesc, prometheus.GaugeValue, float64(oldestAge))
+	if waits == 0 {
+		return nil
+	}
+
+	innodbLockWaitsByTableRows, err := db.Query(innodbLockWaitsByTableQuery)
+	if err != nil {
+		return err
+	}
+	defer innodbLockWaitsByTableRows.Close()
+
+	var (
+		lockTable string
+		count     uint64
+	)
+
+	for innodbLockWaitsByTableRows.Next() {
+		if err := innodbLockWaitsByTableRows.Scan(&lockTable, &count); err != nil {
+			return err
+		}
+		schema, table := splitQuotedTableName(lockTable)
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaInnodbLockWaitsByTableDesc, prometheus.GaugeValue, float64(count),
+			schema, table,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+// Scrape `information_schema.innodb_session_temp_tablespaces`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const (
+	// innodbSessionTempTablespacesQuery is available as of MySQL 8.0.13.
+	innodbSessionTempTablespacesQuery = `
+	SELECT STATE, PURPOSE, COUNT(*), ifnull(SUM(SIZE), 0)
+	  FROM information_schema.innodb_session_temp_tablespaces
+	  GROUP BY STATE, PURPOSE
+	`
+	innodbSessionTempTablespacesBySessionQuery = `
+	SELECT ID, PURPOSE, SIZE
+	  FROM information_schema.innodb_session_temp_tablespaces
+	  WHERE STATE = 'ACTIVE'
+	    AND SIZE > 0
+	  ORDER BY SIZE DESC
+	  LIMIT %d
+	`
+	// innodbTempTableInfoQuery is the fallback for MySQL 5.7, which only
+	// reports the temporary tables, not their size.
+	innodbTempTableInfoQuery = `
+	SELECT COUNT(*)
+	  FROM information_schema.innodb_temp_table_info
+	`
+)
+
+// Tunable flags.
+var (
+	innodbSessionTempTablespacesLimit = kingpin.Flag(
+		"collect.info_schema.innodb_session_temp_tablespaces.limit",
+		"Limit the number of sessions by temporary tablespace size",
+	).Default("20").Int()
+)
+
+// Metric descriptors.
+var (
+	infoSchemaInnodbSessionTempTablespacesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_session_temp_tablespaces"),
+		"The number of session temporary tablespaces by state and purpose.",
+		[]string{"state", "purpose"}, nil,
+	)
+	infoSchemaInnodbSessionTempTablespacesSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_session_temp_tablespaces_size_bytes"),
+		"The size of session temporary tablespaces by state and purpose.",
+		[]string{"state", "purpose"}, nil,
+	)
+	infoSchemaInnodbSessionTempTablespaceSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_session_temp_tablespace_size_bytes"),
+		"The size of the active temporary tablespaces of the session by purpose.",
+		[]string{"session_id", "purpose"}, nil,
+	)
+	infoSchemaInnodbTempTablesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_temp_tables"),
+		"The number of active user created InnoDB temporary tables.",
+		nil, nil,
+	)
+)
+
+// ScrapeInnodbSessionTempTablespaces collects from `information_schema.innodb_session_temp_tablespaces`.
+type ScrapeInnodbSessionTempTablespaces struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeInnodbSessionTempTablespaces) Name() string {
+	return informationSchema + ".innodb_session_temp_tablespaces"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeInnodbSessionTempTablespaces) Help() string {
+	return "Collect session temporary tablespace usage from information_schema.innodb_session_temp_tablespaces"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeInnodbSessionTempTablespaces) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	tempTablespacesRows, err := db.Query(innodbSessionTempTablespacesQuery)
+	if err != nil {
+		log.Debugln("Error querying innodb_session_temp_tablespaces, falling back to innodb_temp_table_info:", err)
+		var tables uint64
+		if err := db.QueryRow(innodbTempTableInfoQuery).Scan(&tables); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(infoSchemaInnodbTempTablesDesc, prometheus.GaugeValue, float64(tables))
+		return nil
+	}
+	defer tempTablespacesRows.Close()
+
+	var (
+		state, purpose string
+		count, size    uint64
+	)
+	for tempTablespacesRows.Next() {
+		if err := tempTablespacesRows.Scan(&state, &purpose, &count, &size); err != nil {
+			return err
+		}
+		state, purpose = strings.ToLower(state), strings.ToLower(purpose)
+		ch <- prometheus.MustNewConstMetric(infoSchemaInnodbSessionTempTablespacesDesc, prometheus.GaugeValue, float64(count), state, purpose)
+		ch <- prometheus.MustNewConstMetric(infoSchemaInnodbSessionTempTablespacesSizeDesc, prometheus.GaugeValue, float64(size), state, purpose)
+	}
+
+	bySessionRows, err := db.Query(fmt.Sprintf(innodbSessionTempTablespacesBySessionQuery, *innodbSessionTempTablespacesLimit))
+	if err != nil {
+		return err
+	}
+	defer bySessionRows.Close()
+
+	var sessionID uint64
+	for bySessionRows.Next() {
+		if err := bySessionRows.Scan(&sessionID, &purpose, &size); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaInnodbSessionTempTablespaceSizeDesc, prometheus.GaugeValue, float64(size),
+			strconv.FormatUint(sessionID, 10), strings.ToLower(purpose),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeInnodbSessionTempTablespaces(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(innodbSessionTempTablespacesQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"STATE", "PURPOSE", "COUNT(*)", "SUM(SIZE)"}).
+			AddRow("ACTIVE", "INTRINSIC", "2", "2147483648").
+			AddRow("INACTIVE", "NONE", "10", "819200"))
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(innodbSessionTempTablespacesBySessionQuery, 20))).WillReturnRows(
+		sqlmock.NewRows([]string{"ID", "PURPOSE", "SIZE"}).
+			AddRow("42", "INTRINSIC", "2147401728"))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeInnodbSessionTempTablespaces{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"state": "active", "purpose": "intrinsic"}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "active", "purpose": "intrinsic"}, value: 2147483648, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "inactive", "purpose": "none"}, value: 10, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "inactive", "purpose": "none"}, value: 819200, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"session_id": "42", "purpose": "intrinsic"}, value: 2147401728, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestScrapeInnodbSessionTempTablespacesFallback(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(innodbSessionTempTablespacesQuery)).WillReturnError(fmt.Errorf("Unknown table 'INNODB_SESSION_TEMP_TABLESPACES'"))
+	mock.ExpectQuery(sanitizeQuery(innodbTempTableInfoQuery)).WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(3))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeInnodbSessionTempTablespaces{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("Metrics comparison", t, func() {
+		got := readMetric(<-ch)
+		convey.So(got, convey.ShouldResemble, MetricResult{labels: labelMap{}, value: 3, metricType: dto.MetricType_GAUGE})
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
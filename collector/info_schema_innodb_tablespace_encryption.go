@@ -0,0 +1,72 @@
+// Scrape `information_schema.innodb_tablespaces` encryption status.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// innodbTablespaceEncryptionQuery requires the ENCRYPTION column added in MySQL 8.0.13.
+const innodbTablespaceEncryptionQuery = `
+	SELECT NAME, ENCRYPTION
+	  FROM information_schema.innodb_tablespaces
+	`
+
+// Metric descriptors.
+var (
+	infoSchemaInnodbTablespaceEncryptedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_tablespace_encrypted"),
+		"Whether the tablespace is encrypted.",
+		[]string{"tablespace_name"}, nil,
+	)
+	infoSchemaInnodbTablespacesByEncryptionDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "innodb_tablespaces_by_encryption"),
+		"The number of encrypted and unencrypted tablespaces.",
+		[]string{"encrypted"}, nil,
+	)
+)
+
+// ScrapeInnodbTablespaceEncryption collects from `information_schema.innodb_tablespaces`.
+type ScrapeInnodbTablespaceEncryption struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeInnodbTablespaceEncryption) Name() string {
+	return informationSchema + ".innodb_tablespace_encryption"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeInnodbTablespaceEncryption) Help() string {
+	return "Collect tablespace encryption status from information_schema.innodb_tablespaces"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeInnodbTablespaceEncryption) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	tablespacesRows, err := db.Query(innodbTablespaceEncryptionQuery)
+	if err != nil {
+		return err
+	}
+	defer tablespacesRows.Close()
+
+	var (
+		name, encryption     string
+		encrypted, plaintext uint64
+	)
+	for tablespacesRows.Next() {
+		if err := tablespacesRows.Scan(&name, &encryption); err != nil {
+			return err
+		}
+		value := 0.0
+		if encryption == "Y" {
+			value = 1
+			encrypted++
+		} else {
+			plaintext++
+		}
+		ch <- prometheus.MustNewConstMetric(infoSchemaInnodbTablespaceEncryptedDesc, prometheus.GaugeValue, value, name)
+	}
+	ch <- prometheus.MustNewConstMetric(infoSchemaInnodbTablespacesByEncryptionDesc, prometheus.GaugeValue, float64(encrypted), "yes")
+	ch <- prometheus.MustNewConstMetric(infoSchemaInnodbTablespacesByEncryptionDesc, prometheus.GaugeValue, float64(plaintext), "no")
+	return nil
+}
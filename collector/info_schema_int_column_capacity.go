@@ -0,0 +1,187 @@
+// Scrape the capacity used by integer primary key columns.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const (
+	intColumnServerQuery = `SELECT @@hostname, @@port`
+	// intColumnsQuery lists the integer columns leading a primary key, whose
+	// max value is read from the index, leaving out the auto_increment
+	// columns covered by auto_increment.columns.
+	intColumnsQuery = `
+	SELECT c.TABLE_SCHEMA, c.TABLE_NAME, c.COLUMN_NAME,
+	  pow(2, case c.DATA_TYPE
+	    when 'tinyint'   then 7
+	    when 'smallint'  then 15
+	    when 'mediumint' then 23
+	    when 'int'       then 31
+	    when 'bigint'    then 63
+	    end+(c.COLUMN_TYPE like '%% unsigned'))-1 as max_int
+	  FROM information_schema.COLUMNS c
+	  JOIN information_schema.STATISTICS s
+	    ON s.TABLE_SCHEMA = c.TABLE_SCHEMA AND s.TABLE_NAME = c.TABLE_NAME AND s.COLUMN_NAME = c.COLUMN_NAME
+	  JOIN information_schema.TABLES t
+	    ON t.TABLE_SCHEMA = c.TABLE_SCHEMA AND t.TABLE_NAME = c.TABLE_NAME
+	  WHERE s.INDEX_NAME = 'PRIMARY' AND s.SEQ_IN_INDEX = 1
+	    AND c.DATA_TYPE IN ('tinyint', 'smallint', 'mediumint', 'int', 'bigint')
+	    AND c.EXTRA NOT LIKE '%%auto_increment%%'
+	    AND c.TABLE_SCHEMA NOT IN ('mysql', 'performance_schema', 'information_schema', 'sys')
+	    AND t.TABLE_TYPE = 'BASE TABLE'
+	  ORDER BY t.TABLE_ROWS DESC
+	  LIMIT %d
+	`
+	intColumnMaxQuery = "SELECT MAX(%s) FROM %s.%s"
+)
+
+// Tunable flags.
+var (
+	intColumnCapacityLimit = kingpin.Flag(
+		"collect.info_schema.int_column_capacity.limit",
+		"Limit the number of integer primary key columns, by table rows, to sample the max value of",
+	).Default("20").Int()
+	intColumnCapacityInterval = kingpin.Flag(
+		"collect.info_schema.int_column_capacity.interval",
+		"Minimum interval between two samplings of the max values, scrapes in between export the previous sample",
+	).Default("1h").Duration()
+)
+
+// Metric descriptors.
+var (
+	infoSchemaIntColumnDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "int_column"),
+		"The max value of an integer primary key column at the last sampling.",
+		[]string{"schema", "table", "column"}, nil,
+	)
+	infoSchemaIntColumnMaxDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "int_column_max"),
+		"The max value allowed by the data type of an integer primary key column.",
+		[]string{"schema", "table", "column"}, nil,
+	)
+	infoSchemaIntColumnUtilizationDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "int_column_utilization_ratio"),
+		"The ratio of the values of an integer primary key column used at the last sampling.",
+		[]string{"schema", "table", "column"}, nil,
+	)
+)
+
+// intColumnSample is the sampled max value of an integer column.
+type intColumnSample struct {
+	schema, table, column string
+	value, max            float64
+}
+
+// intColumnSamples keeps the last sampling of every server, so the MAX()
+// queries run at most once per interval.
+var intColumnSamples = struct {
+	sync.Mutex
+	byServer map[string]intColumnSampling
+}{byServer: map[string]intColumnSampling{}}
+
+type intColumnSampling struct {
+	sampled time.Time
+	samples []intColumnSample
+}
+
+// ScrapeIntColumnCapacity collects the max values of integer primary key columns.
+type ScrapeIntColumnCapacity struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeIntColumnCapacity) Name() string {
+	return informationSchema + ".int_column_capacity"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeIntColumnCapacity) Help() string {
+	return "Collect the max values of integer primary key columns without auto_increment, sampled at most once per interval"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeIntColumnCapacity) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var host, port string
+	if err := db.QueryRow(intColumnServerQuery).Scan(&host, &port); err != nil {
+		return err
+	}
+	server := host + ":" + port
+
+	intColumnSamples.Lock()
+	sampling, ok := intColumnSamples.byServer[server]
+	intColumnSamples.Unlock()
+	if !ok || time.Since(sampling.sampled) >= *intColumnCapacityInterval {
+		samples, err := sampleIntColumns(db)
+		if err != nil {
+			return err
+		}
+		sampling = intColumnSampling{sampled: time.Now(), samples: samples}
+		intColumnSamples.Lock()
+		intColumnSamples.byServer[server] = sampling
+		intColumnSamples.Unlock()
+	}
+
+	for _, s := range sampling.samples {
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaIntColumnDesc, prometheus.GaugeValue, s.value,
+			s.schema, s.table, s.column,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaIntColumnMaxDesc, prometheus.GaugeValue, s.max,
+			s.schema, s.table, s.column,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaIntColumnUtilizationDesc, prometheus.GaugeValue, s.value/s.max,
+			s.schema, s.table, s.column,
+		)
+	}
+	return nil
+}
+
+// sampleIntColumns reads the max value of the integer primary key columns of the largest tables.
+func sampleIntColumns(db *sql.DB) ([]intColumnSample, error) {
+	intColumnsRows, err := db.Query(fmt.Sprintf(intColumnsQuery, *intColumnCapacityLimit))
+	if err != nil {
+		return nil, err
+	}
+	var samples []intColumnSample
+	for intColumnsRows.Next() {
+		var s intColumnSample
+		if err := intColumnsRows.Scan(&s.schema, &s.table, &s.column, &s.max); err != nil {
+			intColumnsRows.Close()
+			return nil, err
+		}
+		samples = append(samples, s)
+	}
+	intColumnsRows.Close()
+	if err := intColumnsRows.Err(); err != nil {
+		return nil, err
+	}
+
+	sampled := samples[:0]
+	for _, s := range samples {
+		var value sql.NullFloat64
+		query := fmt.Sprintf(intColumnMaxQuery, quoteIdentifier(s.column), quoteIdentifier(s.schema), quoteIdentifier(s.table))
+		if err := db.QueryRow(query).Scan(&value); err != nil {
+			return nil, err
+		}
+		// Empty tables have no max value.
+		if !value.Valid {
+			continue
+		}
+		s.value = value.Float64
+		sampled = append(sampled, s)
+	}
+	return sampled, nil
+}
+
+// quoteIdentifier quotes a schema, table or column name for use in a query.
+func quoteIdentifier(name string) string {
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
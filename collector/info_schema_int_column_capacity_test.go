@@ -0,0 +1,64 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeIntColumnCapacity(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	server := sqlmock.NewRows([]string{"@@hostname", "@@port"}).AddRow("db1", "3306")
+	mock.ExpectQuery(sanitizeQuery(intColumnServerQuery)).WillReturnRows(server)
+	columns := []string{"TABLE_SCHEMA", "TABLE_NAME", "COLUMN_NAME", "max_int"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("shop", "orders", "order_id", "2147483647").
+		AddRow("shop", "empty", "id", "127")
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(intColumnsQuery, 20))).WillReturnRows(rows)
+	mock.ExpectQuery(sanitizeQuery("SELECT MAX(`order_id`) FROM `shop`.`orders`")).
+		WillReturnRows(sqlmock.NewRows([]string{"MAX(`order_id`)"}).AddRow("1073741823"))
+	mock.ExpectQuery(sanitizeQuery("SELECT MAX(`id`) FROM `shop`.`empty`")).
+		WillReturnRows(sqlmock.NewRows([]string{"MAX(`id`)"}).AddRow(nil))
+	// The second scrape exports the previous sample.
+	server = sqlmock.NewRows([]string{"@@hostname", "@@port"}).AddRow("db1", "3306")
+	mock.ExpectQuery(sanitizeQuery(intColumnServerQuery)).WillReturnRows(server)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		for i := 0; i < 2; i++ {
+			if err = (ScrapeIntColumnCapacity{}).Scrape(db, ch); err != nil {
+				t.Errorf("error calling function on test: %s", err)
+			}
+		}
+		close(ch)
+	}()
+
+	orders := labelMap{"schema": "shop", "table": "orders", "column": "order_id"}
+	metricExpected := []MetricResult{
+		{labels: orders, value: 1073741823, metricType: dto.MetricType_GAUGE},
+		{labels: orders, value: 2147483647, metricType: dto.MetricType_GAUGE},
+		{labels: orders, value: 1073741823.0 / 2147483647, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for i := 0; i < 2; i++ {
+			for _, expect := range metricExpected {
+				got := readMetric(<-ch)
+				convey.So(got, convey.ShouldResemble, expect)
+			}
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,133 @@
+// Scrape `information_schema.partitions`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const (
+	partitionsCountQuery = `
+	SELECT TABLE_SCHEMA, TABLE_NAME, COUNT(*)
+	  FROM information_schema.partitions
+	  WHERE PARTITION_NAME IS NOT NULL
+	    AND CONCAT(TABLE_SCHEMA, '.', TABLE_NAME) REGEXP ?
+	  GROUP BY TABLE_SCHEMA, TABLE_NAME
+	`
+	partitionsQuery = `
+	SELECT
+	    TABLE_SCHEMA, TABLE_NAME, PARTITION_NAME,
+	    ifnull(SUBPARTITION_NAME, '') as SUBPARTITION_NAME,
+	    ifnull(TABLE_ROWS, 0) as TABLE_ROWS,
+	    ifnull(DATA_LENGTH, 0) as DATA_LENGTH,
+	    ifnull(INDEX_LENGTH, 0) as INDEX_LENGTH
+	  FROM information_schema.partitions
+	  WHERE PARTITION_NAME IS NOT NULL
+	    AND CONCAT(TABLE_SCHEMA, '.', TABLE_NAME) REGEXP ?
+	  ORDER BY DATA_LENGTH + INDEX_LENGTH DESC
+	  LIMIT %d
+	`
+)
+
+// Tunable flags.
+var (
+	partitionsFilter = kingpin.Flag(
+		"collect.info_schema.partitions.filter",
+		"RegEx schema.table filter for information_schema.partitions",
+	).Default(".*").String()
+	partitionsLimit = kingpin.Flag(
+		"collect.info_schema.partitions.limit",
+		"Limit the number of partitions by data and index size",
+	).Default("100").Int()
+)
+
+// Metric descriptors.
+var (
+	infoSchemaPartitionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "table_partitions"),
+		"The number of partitions of the table.",
+		[]string{"schema", "table"}, nil,
+	)
+	infoSchemaPartitionRowsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "partition_rows"),
+		"The estimated number of rows in the partition from information_schema.partitions.",
+		[]string{"schema", "table", "partition", "subpartition"}, nil,
+	)
+	infoSchemaPartitionSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "partition_size"),
+		"The size of the partition components from information_schema.partitions.",
+		[]string{"schema", "table", "partition", "subpartition", "component"}, nil,
+	)
+)
+
+// ScrapePartitions collects from `information_schema.partitions`.
+type ScrapePartitions struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapePartitions) Name() string {
+	return informationSchema + ".partitions"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapePartitions) Help() string {
+	return "Collect metrics from information_schema.partitions"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapePartitions) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	partitionsCountRows, err := db.Query(partitionsCountQuery, *partitionsFilter)
+	if err != nil {
+		return err
+	}
+	defer partitionsCountRows.Close()
+
+	var (
+		tableSchema, tableName string
+		count                  uint64
+	)
+	for partitionsCountRows.Next() {
+		if err := partitionsCountRows.Scan(&tableSchema, &tableName, &count); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaPartitionsDesc, prometheus.GaugeValue, float64(count),
+			tableSchema, tableName,
+		)
+	}
+
+	partitionsRows, err := db.Query(fmt.Sprintf(partitionsQuery, *partitionsLimit), *partitionsFilter)
+	if err != nil {
+		return err
+	}
+	defer partitionsRows.Close()
+
+	var (
+		partitionName, subpartitionName    string
+		tableRows, dataLength, indexLength uint64
+	)
+	for partitionsRows.Next() {
+		if err := partitionsRows.Scan(
+			&tableSchema, &tableName, &partitionName, &subpartitionName,
+			&tableRows, &dataLength, &indexLength,
+		); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaPartitionRowsDesc, prometheus.GaugeValue, float64(tableRows),
+			tableSchema, tableName, partitionName, subpartitionName,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaPartitionSizeDesc, prometheus.GaugeValue, float64(dataLength),
+			tableSchema, tableName, partitionName, subpartitionName, "data_length",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			infoSchemaPartitionSizeDesc, prometheus.GaugeValue, float64(indexLength),
+			tableSchema, tableName, partitionName, subpartitionName, "index_length",
+		)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapePartitions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	countRows := sqlmock.NewRows([]string{"TABLE_SCHEMA", "TABLE_NAME", "COUNT(*)"}).
+		AddRow("logs", "events", "2")
+	mock.ExpectQuery(sanitizeQuery(partitionsCountQuery)).WithArgs(".*").WillReturnRows(countRows)
+
+	columns := []string{"TABLE_SCHEMA", "TABLE_NAME", "PARTITION_NAME", "SUBPARTITION_NAME", "TABLE_ROWS", "DATA_LENGTH", "INDEX_LENGTH"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("logs", "events", "p202410", "", "1000000", "104857600", "20971520").
+		AddRow("logs", "events", "p202411", "", "2000", "1048576", "16384")
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(partitionsQuery, 100))).WithArgs(".*").WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapePartitions{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"schema": "logs", "table": "events"}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "logs", "table": "events", "partition": "p202410", "subpartition": ""}, value: 1000000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "logs", "table": "events", "partition": "p202410", "subpartition": "", "component": "data_length"}, value: 104857600, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "logs", "table": "events", "partition": "p202410", "subpartition": "", "component": "index_length"}, value: 20971520, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "logs", "table": "events", "partition": "p202411", "subpartition": ""}, value: 2000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "logs", "table": "events", "partition": "p202411", "subpartition": "", "component": "data_length"}, value: 1048576, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "logs", "table": "events", "partition": "p202411", "subpartition": "", "component": "index_length"}, value: 16384, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
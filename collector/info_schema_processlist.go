@@ -20,6 +20,18 @@ const infoSchemaProcesslistQuery = `
 		  ORDER BY null
 		`
 
+const infoSchemaProcesslistByUserQuery = `
+		SELECT COALESCE(user,''),COALESCE(db,''),COALESCE(command,''),count(*),max(time)
+		  FROM information_schema.processlist
+		  WHERE ID != connection_id()
+		    AND TIME >= %d
+		  GROUP BY user,db,command
+		  ORDER BY null
+		`
+
+// Subsystem.
+const processlist = "processlist"
+
 // Tunable flags.
 var (
 	processlistMinTime = kingpin.Flag(
@@ -38,6 +50,18 @@ var (
 		prometheus.BuildFQName(namespace, informationSchema, "threads_seconds"),
 		"The number of seconds threads (connections) have used split by current state.",
 		[]string{"state"}, nil)
+	processlistByDatabaseDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "threads_by_database"),
+		"The number of threads (connections) split by default database.",
+		[]string{"db"}, nil)
+	processlistByCommandDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "threads_by_command"),
+		"The number of threads (connections) split by command.",
+		[]string{"command"}, nil)
+	processlistOldestQueryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, processlist, "oldest_query_seconds"),
+		"The number of seconds the oldest running query has been executing, by user and default database.",
+		[]string{"user", "db"}, nil)
 )
 
 // whitelist for connection/process states in SHOW PROCESSLIST
@@ -176,6 +200,45 @@ func (ScrapeProcesslist) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
 		ch <- prometheus.MustNewConstMetric(processlistTimeDesc, prometheus.GaugeValue, float64(time), state)
 	}
 
+	return scrapeProcesslistByUser(db, ch)
+}
+
+// scrapeProcesslistByUser collects thread counts by database and command,
+// and the age of the oldest query by user and database.
+func scrapeProcesslistByUser(db *sql.DB, ch chan<- prometheus.Metric) error {
+	processlistRows, err := db.Query(fmt.Sprintf(infoSchemaProcesslistByUserQuery, *processlistMinTime))
+	if err != nil {
+		return err
+	}
+	defer processlistRows.Close()
+
+	var (
+		user, database, command string
+		count, maxTime          uint32
+	)
+	databaseCounts := map[string]uint32{}
+	commandCounts := map[string]uint32{}
+	// Rows are grouped by command, so there is at most one Query row per user and database.
+	oldestQuery := map[[2]string]uint32{}
+	for processlistRows.Next() {
+		if err := processlistRows.Scan(&user, &database, &command, &count, &maxTime); err != nil {
+			return err
+		}
+		databaseCounts[database] += count
+		commandCounts[strings.ToLower(command)] += count
+		if command == "Query" {
+			oldestQuery[[2]string{user, database}] = maxTime
+		}
+	}
+	for database, count := range databaseCounts {
+		ch <- prometheus.MustNewConstMetric(processlistByDatabaseDesc, prometheus.GaugeValue, float64(count), database)
+	}
+	for command, count := range commandCounts {
+		ch <- prometheus.MustNewConstMetric(processlistByCommandDesc, prometheus.GaugeValue, float64(count), command)
+	}
+	for key, time := range oldestQuery {
+		ch <- prometheus.MustNewConstMetric(processlistOldestQueryDesc, prometheus.GaugeValue, float64(time), key[0], key[1])
+	}
 	return nil
 }
 
@@ -0,0 +1,55 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeProcesslistByUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"user", "db", "command", "count(*)", "max(time)"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("app", "db1", "Query", "3", "42").
+		AddRow("app", "db1", "Sleep", "10", "600")
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(infoSchemaProcesslistByUserQuery, 0))).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = scrapeProcesslistByUser(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"db": "db1"}, value: 13, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"command": "query"}, value: 3, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"command": "sleep"}, value: 10, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "app", "db": "db1"}, value: 42, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		got := []MetricResult{}
+		for metric := range ch {
+			got = append(got, readMetric(metric))
+		}
+		convey.So(got, convey.ShouldHaveLength, len(expected))
+		for _, expect := range expected {
+			convey.So(got, convey.ShouldContain, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,111 @@
+// Scrape `information_schema.routines` and `information_schema.triggers`.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	routinesQuery = `
+	SELECT
+	    ROUTINE_SCHEMA, ROUTINE_TYPE, COUNT(*),
+	    ifnull(UNIX_TIMESTAMP(MAX(CREATED)), 0),
+	    ifnull(UNIX_TIMESTAMP(MAX(LAST_ALTERED)), 0)
+	  FROM information_schema.routines
+	  WHERE ROUTINE_SCHEMA NOT IN ('mysql', 'performance_schema', 'information_schema', 'sys')
+	  GROUP BY ROUTINE_SCHEMA, ROUTINE_TYPE
+	`
+	triggersQuery = `
+	SELECT
+	    TRIGGER_SCHEMA, COUNT(*),
+	    ifnull(UNIX_TIMESTAMP(MAX(CREATED)), 0)
+	  FROM information_schema.triggers
+	  WHERE TRIGGER_SCHEMA NOT IN ('mysql', 'performance_schema', 'information_schema', 'sys')
+	  GROUP BY TRIGGER_SCHEMA
+	`
+)
+
+// Metric descriptors.
+var (
+	infoSchemaRoutinesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "routines"),
+		"The number of stored routines by schema and type.",
+		[]string{"schema", "type"}, nil,
+	)
+	infoSchemaRoutinesCreatedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "routines_last_created_timestamp_seconds"),
+		"The unix timestamp of the most recently created stored routine by schema and type.",
+		[]string{"schema", "type"}, nil,
+	)
+	infoSchemaRoutinesAlteredDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "routines_last_altered_timestamp_seconds"),
+		"The unix timestamp of the most recently altered stored routine by schema and type.",
+		[]string{"schema", "type"}, nil,
+	)
+	infoSchemaTriggersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "triggers"),
+		"The number of triggers by schema.",
+		[]string{"schema"}, nil,
+	)
+	infoSchemaTriggersCreatedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "triggers_last_created_timestamp_seconds"),
+		"The unix timestamp of the most recently created trigger by schema.",
+		[]string{"schema"}, nil,
+	)
+)
+
+// ScrapeRoutines collects from `information_schema.routines` and `information_schema.triggers`.
+type ScrapeRoutines struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeRoutines) Name() string {
+	return informationSchema + ".routines"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeRoutines) Help() string {
+	return "Collect stored routine and trigger counts from information_schema.routines and information_schema.triggers"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeRoutines) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	routinesRows, err := db.Query(routinesQuery)
+	if err != nil {
+		return err
+	}
+	defer routinesRows.Close()
+
+	var (
+		schema, routineType string
+		count               uint64
+		created, altered    float64
+	)
+	for routinesRows.Next() {
+		if err := routinesRows.Scan(&schema, &routineType, &count, &created, &altered); err != nil {
+			return err
+		}
+		routineType = strings.ToLower(routineType)
+		ch <- prometheus.MustNewConstMetric(infoSchemaRoutinesDesc, prometheus.GaugeValue, float64(count), schema, routineType)
+		ch <- prometheus.MustNewConstMetric(infoSchemaRoutinesCreatedDesc, prometheus.GaugeValue, created, schema, routineType)
+		ch <- prometheus.MustNewConstMetric(infoSchemaRoutinesAlteredDesc, prometheus.GaugeValue, altered, schema, routineType)
+	}
+
+	triggersRows, err := db.Query(triggersQuery)
+	if err != nil {
+		return err
+	}
+	defer triggersRows.Close()
+
+	for triggersRows.Next() {
+		if err := triggersRows.Scan(&schema, &count, &created); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(infoSchemaTriggersDesc, prometheus.GaugeValue, float64(count), schema)
+		ch <- prometheus.MustNewConstMetric(infoSchemaTriggersCreatedDesc, prometheus.GaugeValue, created, schema)
+	}
+	return nil
+}
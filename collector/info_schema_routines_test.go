@@ -0,0 +1,57 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeRoutines(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	routineRows := sqlmock.NewRows([]string{"ROUTINE_SCHEMA", "ROUTINE_TYPE", "COUNT(*)", "CREATED", "LAST_ALTERED"}).
+		AddRow("shop", "PROCEDURE", "4", "1700000000", "1710000000").
+		AddRow("shop", "FUNCTION", "1", "1600000000", "1600000000")
+	mock.ExpectQuery(sanitizeQuery(routinesQuery)).WillReturnRows(routineRows)
+
+	triggerRows := sqlmock.NewRows([]string{"TRIGGER_SCHEMA", "COUNT(*)", "CREATED"}).
+		AddRow("shop", "2", "1720000000")
+	mock.ExpectQuery(sanitizeQuery(triggersQuery)).WillReturnRows(triggerRows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeRoutines{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"schema": "shop", "type": "procedure"}, value: 4, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop", "type": "procedure"}, value: 1700000000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop", "type": "procedure"}, value: 1710000000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop", "type": "function"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop", "type": "function"}, value: 1600000000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop", "type": "function"}, value: 1600000000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop"}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop"}, value: 1720000000, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
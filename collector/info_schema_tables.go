@@ -27,12 +27,14 @@ const (
 		    ifnull(CREATE_OPTIONS, 'NONE') as CREATE_OPTIONS
 		  FROM information_schema.tables
 		  WHERE TABLE_SCHEMA = '%s'
+		  LIMIT %d
 		`
 	dbListQuery = `
 		SELECT
 		    SCHEMA_NAME
 		  FROM information_schema.schemata
 		  WHERE SCHEMA_NAME NOT IN ('mysql', 'performance_schema', 'information_schema')
+		  LIMIT %d
 		`
 )
 
@@ -42,6 +44,10 @@ var (
 		"collect.info_schema.tables.databases",
 		"The list of databases to collect table stats for, or '*' for all",
 	).Default("*").String()
+	tableFragmentationThreshold = kingpin.Flag(
+		"collect.info_schema.tables.fragmentation_threshold",
+		"Minimum fragmentation ratio, data_free / (data_length + index_length), of the tables to export the ratio for",
+	).Default("0").Float64()
 )
 
 // Metric descriptors.
@@ -61,6 +67,11 @@ var (
 		"The size of the table components from information_schema.tables",
 		[]string{"schema", "table", "component"}, nil,
 	)
+	infoSchemaTablesFragmentationDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, informationSchema, "table_fragmentation_ratio"),
+		"The estimated fragmentation of the table, data_free / (data_length + index_length), from information_schema.tables",
+		[]string{"schema", "table"}, nil,
+	)
 )
 
 // ScrapeTableSchema collects from `information_schema.tables`.
@@ -80,7 +91,7 @@ func (ScrapeTableSchema) Help() string {
 func (ScrapeTableSchema) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
 	var dbList []string
 	if *tableSchemaDatabases == "*" {
-		dbListRows, err := db.Query(dbListQuery)
+		dbListRows, err := db.Query(fmt.Sprintf(dbListQuery, maxRowsLimit()))
 		if err != nil {
 			return err
 		}
@@ -101,7 +112,7 @@ func (ScrapeTableSchema) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
 	}
 
 	for _, database := range dbList {
-		tableSchemaRows, err := db.Query(fmt.Sprintf(tableSchemaQuery, database))
+		tableSchemaRows, err := db.Query(fmt.Sprintf(tableSchemaQuery, database, maxRowsLimit()))
 		if err != nil {
 			return err
 		}
@@ -158,6 +169,15 @@ func (ScrapeTableSchema) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
 				infoSchemaTablesSizeDesc, prometheus.GaugeValue, float64(dataFree),
 				tableSchema, tableName, "data_free",
 			)
+			if size := dataLength + indexLength; size > 0 {
+				fragmentation := float64(dataFree) / float64(size)
+				if fragmentation >= *tableFragmentationThreshold {
+					ch <- prometheus.MustNewConstMetric(
+						infoSchemaTablesFragmentationDesc, prometheus.GaugeValue, fragmentation,
+						tableSchema, tableName,
+					)
+				}
+			}
 		}
 	}
 
@@ -0,0 +1,74 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+func TestScrapeTableSchemaFragmentation(t *testing.T) {
+	_, err := kingpin.CommandLine.Parse([]string{
+		"--collect.info_schema.tables.databases", "shop",
+		"--collect.info_schema.tables.fragmentation_threshold", "0.2",
+		"--exporter.max-rows", "1000",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer kingpin.CommandLine.Parse([]string{})
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"TABLE_SCHEMA", "TABLE_NAME", "TABLE_TYPE", "ENGINE", "VERSION", "ROW_FORMAT", "TABLE_ROWS", "DATA_LENGTH", "INDEX_LENGTH", "DATA_FREE", "CREATE_OPTIONS"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("shop", "orders", "BASE TABLE", "InnoDB", "10", "Dynamic", "1000", "600", "200", "400", "").
+		AddRow("shop", "tags", "BASE TABLE", "InnoDB", "10", "Dynamic", "10", "100", "0", "10", "")
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(tableSchemaQuery, "shop", 1000))).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeTableSchema{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	orders := labelMap{"schema": "shop", "table": "orders"}
+	tags := labelMap{"schema": "shop", "table": "tags"}
+	metricExpected := []MetricResult{
+		{labels: labelMap{"schema": "shop", "table": "orders", "type": "BASE TABLE", "engine": "InnoDB", "row_format": "Dynamic", "create_options": ""}, value: 10, metricType: dto.MetricType_GAUGE},
+		{labels: orders, value: 1000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop", "table": "orders", "component": "data_length"}, value: 600, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop", "table": "orders", "component": "index_length"}, value: 200, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop", "table": "orders", "component": "data_free"}, value: 400, metricType: dto.MetricType_GAUGE},
+		{labels: orders, value: 0.5, metricType: dto.MetricType_GAUGE},
+		// Below the threshold, the ratio of tags is not exported.
+		{labels: labelMap{"schema": "shop", "table": "tags", "type": "BASE TABLE", "engine": "InnoDB", "row_format": "Dynamic", "create_options": ""}, value: 10, metricType: dto.MetricType_GAUGE},
+		{labels: tags, value: 10, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop", "table": "tags", "component": "data_length"}, value: 100, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop", "table": "tags", "component": "index_length"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "shop", "table": "tags", "component": "data_free"}, value: 10, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,113 @@
+// Scrape InnoDB adaptive hash index statistics.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	innodbAdaptiveHashIndexVariablesQuery = `
+	SHOW GLOBAL VARIABLES
+	  WHERE Variable_name IN ('innodb_adaptive_hash_index', 'innodb_adaptive_hash_index_parts')
+	`
+	// Only the searches counters are enabled by default, the others
+	// require innodb_monitor_enable.
+	innodbAdaptiveHashIndexMetricsQuery = `
+	SELECT NAME, COUNT
+	  FROM information_schema.innodb_metrics
+	  WHERE NAME IN ('adaptive_hash_searches', 'adaptive_hash_searches_btree',
+	                 'adaptive_hash_pages_added', 'adaptive_hash_pages_removed',
+	                 'adaptive_hash_rows_added', 'adaptive_hash_rows_removed')
+	    AND STATUS = 'enabled'
+	`
+)
+
+// Metric descriptors.
+var (
+	innodbAdaptiveHashIndexEnabledDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "adaptive_hash_index_enabled"),
+		"Whether the adaptive hash index is enabled.",
+		nil, nil,
+	)
+	innodbAdaptiveHashIndexPartitionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "adaptive_hash_index_partitions"),
+		"The number of partitions of the adaptive hash index.",
+		nil, nil,
+	)
+	innodbAdaptiveHashIndexSearchesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "adaptive_hash_index_searches_total"),
+		"The number of index lookups resolved by the adaptive hash index or by a B-tree search.",
+		[]string{"method"}, nil,
+	)
+	innodbAdaptiveHashIndexPagesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "adaptive_hash_index_pages_total"),
+		"The number of index pages added to or removed from the adaptive hash index.",
+		[]string{"operation"}, nil,
+	)
+	innodbAdaptiveHashIndexRowsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "adaptive_hash_index_rows_total"),
+		"The number of index rows added to or removed from the adaptive hash index.",
+		[]string{"operation"}, nil,
+	)
+)
+
+// ScrapeInnodbAdaptiveHashIndex collects adaptive hash index statistics.
+type ScrapeInnodbAdaptiveHashIndex struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeInnodbAdaptiveHashIndex) Name() string {
+	return innodbSubsystem + ".adaptive_hash_index"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeInnodbAdaptiveHashIndex) Help() string {
+	return "Collect adaptive hash index statistics from global variables and information_schema.innodb_metrics"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeInnodbAdaptiveHashIndex) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	variables := map[string]float64{}
+	if err := scrapeNamedValues(db, innodbAdaptiveHashIndexVariablesQuery, variables); err != nil {
+		return err
+	}
+	if enabled, ok := variables["innodb_adaptive_hash_index"]; ok {
+		ch <- prometheus.MustNewConstMetric(innodbAdaptiveHashIndexEnabledDesc, prometheus.GaugeValue, enabled)
+	}
+	if parts, ok := variables["innodb_adaptive_hash_index_parts"]; ok {
+		ch <- prometheus.MustNewConstMetric(innodbAdaptiveHashIndexPartitionsDesc, prometheus.GaugeValue, parts)
+	}
+
+	metricsRows, err := db.Query(innodbAdaptiveHashIndexMetricsQuery)
+	if err != nil {
+		return err
+	}
+	defer metricsRows.Close()
+
+	var (
+		name  string
+		value float64
+	)
+	for metricsRows.Next() {
+		if err := metricsRows.Scan(&name, &value); err != nil {
+			return err
+		}
+		switch name {
+		case "adaptive_hash_searches":
+			ch <- prometheus.MustNewConstMetric(innodbAdaptiveHashIndexSearchesDesc, prometheus.CounterValue, value, "hash")
+		case "adaptive_hash_searches_btree":
+			ch <- prometheus.MustNewConstMetric(innodbAdaptiveHashIndexSearchesDesc, prometheus.CounterValue, value, "btree")
+		case "adaptive_hash_pages_added":
+			ch <- prometheus.MustNewConstMetric(innodbAdaptiveHashIndexPagesDesc, prometheus.CounterValue, value, "added")
+		case "adaptive_hash_pages_removed":
+			ch <- prometheus.MustNewConstMetric(innodbAdaptiveHashIndexPagesDesc, prometheus.CounterValue, value, "removed")
+		case "adaptive_hash_rows_added":
+			ch <- prometheus.MustNewConstMetric(innodbAdaptiveHashIndexRowsDesc, prometheus.CounterValue, value, "added")
+		case "adaptive_hash_rows_removed":
+			ch <- prometheus.MustNewConstMetric(innodbAdaptiveHashIndexRowsDesc, prometheus.CounterValue, value, "removed")
+		}
+	}
+	return nil
+}
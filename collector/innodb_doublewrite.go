@@ -0,0 +1,127 @@
+// Scrape InnoDB doublewrite buffer statistics.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	innodbDoublewriteStatusQuery = `
+	SHOW GLOBAL STATUS LIKE 'Innodb_dblwr_%'
+	`
+	innodbDoublewriteVariablesQuery = `
+	SHOW GLOBAL VARIABLES LIKE 'innodb_doublewrite%'
+	`
+)
+
+// Metric descriptors.
+var (
+	innodbDoublewritePagesWrittenDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "doublewrite_pages_written_total"),
+		"The number of pages written to the doublewrite buffer.",
+		nil, nil,
+	)
+	innodbDoublewriteWritesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "doublewrite_writes_total"),
+		"The number of doublewrite flush batches performed.",
+		nil, nil,
+	)
+	innodbDoublewritePagesPerWriteDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "doublewrite_pages_per_write"),
+		"The average number of pages written per doublewrite flush batch since startup.",
+		nil, nil,
+	)
+	innodbDoublewriteEnabledDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "doublewrite_enabled"),
+		"Whether the doublewrite buffer is enabled.",
+		nil, nil,
+	)
+	innodbDoublewriteFilesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "doublewrite_files"),
+		"The number of doublewrite files.",
+		nil, nil,
+	)
+	innodbDoublewritePagesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "doublewrite_pages"),
+		"The maximum number of doublewrite pages per thread for a batch write.",
+		nil, nil,
+	)
+	innodbDoublewriteBatchSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "doublewrite_batch_size"),
+		"The number of doublewrite pages to write in a batch.",
+		nil, nil,
+	)
+)
+
+// ScrapeInnodbDoublewrite collects doublewrite buffer statistics.
+type ScrapeInnodbDoublewrite struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeInnodbDoublewrite) Name() string {
+	return innodbSubsystem + ".doublewrite"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeInnodbDoublewrite) Help() string {
+	return "Collect doublewrite buffer statistics from global status and variables"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeInnodbDoublewrite) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	status := map[string]float64{}
+	if err := scrapeNamedValues(db, innodbDoublewriteStatusQuery, status); err != nil {
+		return err
+	}
+
+	pagesWritten, okPages := status["Innodb_dblwr_pages_written"]
+	writes, okWrites := status["Innodb_dblwr_writes"]
+	if okPages || okWrites {
+		ch <- prometheus.MustNewConstMetric(innodbDoublewritePagesWrittenDesc, prometheus.CounterValue, pagesWritten)
+		ch <- prometheus.MustNewConstMetric(innodbDoublewriteWritesDesc, prometheus.CounterValue, writes)
+		if writes > 0 {
+			ch <- prometheus.MustNewConstMetric(innodbDoublewritePagesPerWriteDesc, prometheus.GaugeValue, pagesWritten/writes)
+		}
+	}
+
+	variablesRows, err := db.Query(innodbDoublewriteVariablesQuery)
+	if err != nil {
+		return err
+	}
+	defer variablesRows.Close()
+
+	var (
+		key string
+		val sql.RawBytes
+	)
+	for variablesRows.Next() {
+		if err := variablesRows.Scan(&key, &val); err != nil {
+			return err
+		}
+		switch strings.ToLower(key) {
+		case "innodb_doublewrite":
+			// 8.0.30 also accepts DETECT_AND_RECOVER and DETECT_ONLY.
+			enabled := 1.0
+			if strings.ToUpper(string(val)) == "OFF" {
+				enabled = 0
+			}
+			ch <- prometheus.MustNewConstMetric(innodbDoublewriteEnabledDesc, prometheus.GaugeValue, enabled)
+		case "innodb_doublewrite_files":
+			if floatVal, ok := parseStatus(val); ok {
+				ch <- prometheus.MustNewConstMetric(innodbDoublewriteFilesDesc, prometheus.GaugeValue, floatVal)
+			}
+		case "innodb_doublewrite_pages":
+			if floatVal, ok := parseStatus(val); ok {
+				ch <- prometheus.MustNewConstMetric(innodbDoublewritePagesDesc, prometheus.GaugeValue, floatVal)
+			}
+		case "innodb_doublewrite_batch_size":
+			if floatVal, ok := parseStatus(val); ok {
+				ch <- prometheus.MustNewConstMetric(innodbDoublewriteBatchSizeDesc, prometheus.GaugeValue, floatVal)
+			}
+		}
+	}
+	return nil
+}
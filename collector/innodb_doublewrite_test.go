@@ -0,0 +1,60 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeInnodbDoublewrite(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"Variable_name", "Value"}
+	mock.ExpectQuery(sanitizeQuery(innodbDoublewriteStatusQuery)).WillReturnRows(
+		sqlmock.NewRows(columns).
+			AddRow("Innodb_dblwr_pages_written", "6400").
+			AddRow("Innodb_dblwr_writes", "100"))
+	mock.ExpectQuery(sanitizeQuery(innodbDoublewriteVariablesQuery)).WillReturnRows(
+		sqlmock.NewRows(columns).
+			AddRow("innodb_doublewrite", "ON").
+			AddRow("innodb_doublewrite_batch_size", "0").
+			AddRow("innodb_doublewrite_dir", "").
+			AddRow("innodb_doublewrite_files", "2").
+			AddRow("innodb_doublewrite_pages", "4"))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeInnodbDoublewrite{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{}, value: 6400, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 100, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 64, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 4, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
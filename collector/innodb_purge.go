@@ -0,0 +1,87 @@
+// Scrape the InnoDB history list length and purge progress.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// innodbPurgeQuery reads trx_rseg_history_len, which is enabled by default,
+// independently of the information_schema.innodb_metrics collector.
+const innodbPurgeQuery = `
+	SELECT NAME, COUNT
+	  FROM information_schema.innodb_metrics
+	  WHERE NAME IN ('trx_rseg_history_len', 'purge_invoked', 'purge_undo_log_pages', 'purge_del_mark_records', 'purge_upd_exist_or_extern_records')
+	    AND STATUS = 'enabled'
+	`
+
+// Metric descriptors.
+var (
+	innodbHistoryListLengthDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "history_list_length"),
+		"The number of undo log units not yet purged, i.e. the length of the history list.",
+		nil, nil,
+	)
+	innodbPurgeInvokedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "purge_invoked_total"),
+		"The number of times the purge was invoked.",
+		nil, nil,
+	)
+	innodbPurgeUndoLogPagesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "purge_undo_log_pages_total"),
+		"The number of undo log pages handled by the purge.",
+		nil, nil,
+	)
+	innodbPurgeRecordsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "purge_records_total"),
+		"The number of records purged by type.",
+		[]string{"type"}, nil,
+	)
+)
+
+// ScrapeInnodbPurge collects the history list length and purge progress.
+type ScrapeInnodbPurge struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeInnodbPurge) Name() string {
+	return innodbSubsystem + ".purge"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeInnodbPurge) Help() string {
+	return "Collect the history list length and purge progress from information_schema.innodb_metrics"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeInnodbPurge) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	innodbPurgeRows, err := db.Query(innodbPurgeQuery)
+	if err != nil {
+		return err
+	}
+	defer innodbPurgeRows.Close()
+
+	var (
+		name  string
+		value float64
+	)
+	for innodbPurgeRows.Next() {
+		if err := innodbPurgeRows.Scan(&name, &value); err != nil {
+			return err
+		}
+		switch name {
+		case "trx_rseg_history_len":
+			ch <- prometheus.MustNewConstMetric(innodbHistoryListLengthDesc, prometheus.GaugeValue, value)
+		case "purge_invoked":
+			ch <- prometheus.MustNewConstMetric(innodbPurgeInvokedDesc, prometheus.CounterValue, value)
+		case "purge_undo_log_pages":
+			ch <- prometheus.MustNewConstMetric(innodbPurgeUndoLogPagesDesc, prometheus.CounterValue, value)
+		case "purge_del_mark_records":
+			ch <- prometheus.MustNewConstMetric(innodbPurgeRecordsDesc, prometheus.CounterValue, value, "del_mark")
+		case "purge_upd_exist_or_extern_records":
+			ch <- prometheus.MustNewConstMetric(innodbPurgeRecordsDesc, prometheus.CounterValue, value, "upd_exist_or_extern")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+// Scrape InnoDB redo log capacity and checkpoint age.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	// innodbSubsystem is the Metric subsystem we use.
+	innodbSubsystem = "innodb"
+	// innodbRedoLogVariablesQuery covers both innodb_redo_log_capacity, as of
+	// MySQL 8.0.30, and the older fixed size redo log files.
+	innodbRedoLogVariablesQuery = `
+	SHOW GLOBAL VARIABLES
+	  WHERE Variable_name IN ('innodb_redo_log_capacity', 'innodb_log_file_size', 'innodb_log_files_in_group')
+	`
+	// innodbRedoLogStatusQuery reads the Innodb_redo_log_* status variables
+	// of MySQL 8.0.30 and later.
+	innodbRedoLogStatusQuery = `SHOW GLOBAL STATUS LIKE 'Innodb_redo_log_%'`
+	// innodbRedoLogMetricsQuery reads counters of the log module, which
+	// must be enabled with innodb_monitor_enable on MySQL 5.7.
+	innodbRedoLogMetricsQuery = `
+	SELECT NAME, COUNT
+	  FROM information_schema.innodb_metrics
+	  WHERE NAME IN ('log_lsn_current', 'log_lsn_last_checkpoint', 'log_max_modified_age_async', 'log_max_modified_age_sync')
+	    AND STATUS = 'enabled'
+	`
+	// innodbRedoLogLogStatusQuery reads the LSNs from performance_schema.log_status,
+	// available as of MySQL 8.0.17 to users with the BACKUP_ADMIN privilege.
+	innodbRedoLogLogStatusQuery = `
+	SELECT
+	    STORAGE_ENGINES->>'$.InnoDB.LSN',
+	    STORAGE_ENGINES->>'$.InnoDB.LSN_checkpoint'
+	  FROM performance_schema.log_status
+	`
+)
+
+// Metric descriptors.
+var (
+	innodbRedoLogCapacityDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "redo_log_capacity_bytes"),
+		"The total capacity of the InnoDB redo log.",
+		nil, nil,
+	)
+	innodbCheckpointAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "checkpoint_age_bytes"),
+		"The amount of redo log written since the last checkpoint.",
+		nil, nil,
+	)
+	innodbCheckpointAsyncFlushPointDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "checkpoint_async_flush_point_bytes"),
+		"The checkpoint age at which InnoDB starts asynchronously flushing dirty pages.",
+		nil, nil,
+	)
+	innodbCheckpointSyncFlushPointDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbSubsystem, "checkpoint_sync_flush_point_bytes"),
+		"The checkpoint age at which InnoDB blocks writes to synchronously flush dirty pages.",
+		nil, nil,
+	)
+)
+
+// ScrapeInnodbRedoLog collects the redo log capacity and checkpoint age.
+type ScrapeInnodbRedoLog struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeInnodbRedoLog) Name() string {
+	return innodbSubsystem + ".redo_log"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeInnodbRedoLog) Help() string {
+	return "Collect redo log capacity, checkpoint age and flush points from global variables, Innodb_redo_log_* status variables, information_schema.innodb_metrics and performance_schema.log_status"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeInnodbRedoLog) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	variables := map[string]float64{}
+	if err := scrapeNamedValues(db, innodbRedoLogVariablesQuery, variables); err != nil {
+		return err
+	}
+	status := map[string]float64{}
+	if err := scrapeNamedValues(db, innodbRedoLogStatusQuery, status); err != nil {
+		return err
+	}
+
+	// The capacity can be resized online as of MySQL 8.0.30, the status
+	// variable has the capacity in effect. MariaDB 10.5 and later have a
+	// single redo log file and none of these.
+	if capacity, ok := status["Innodb_redo_log_capacity_resized"]; ok {
+		ch <- prometheus.MustNewConstMetric(innodbRedoLogCapacityDesc, prometheus.GaugeValue, capacity)
+	} else if capacity, ok := variables["innodb_redo_log_capacity"]; ok {
+		ch <- prometheus.MustNewConstMetric(innodbRedoLogCapacityDesc, prometheus.GaugeValue, capacity)
+	} else if files, ok := variables["innodb_log_files_in_group"]; ok {
+		ch <- prometheus.MustNewConstMetric(innodbRedoLogCapacityDesc, prometheus.GaugeValue, variables["innodb_log_file_size"]*files)
+	}
+
+	metricsRows, err := db.Query(innodbRedoLogMetricsQuery)
+	if err != nil {
+		return err
+	}
+	defer metricsRows.Close()
+
+	var (
+		key   string
+		value float64
+	)
+	metrics := map[string]float64{}
+	for metricsRows.Next() {
+		if err := metricsRows.Scan(&key, &value); err != nil {
+			return err
+		}
+		metrics[key] = value
+	}
+	if err := metricsRows.Err(); err != nil {
+		return err
+	}
+
+	current, okCurrent := status["Innodb_redo_log_current_lsn"]
+	checkpoint, okCheckpoint := status["Innodb_redo_log_checkpoint_lsn"]
+	if !okCurrent || !okCheckpoint {
+		current, okCurrent = metrics["log_lsn_current"]
+		checkpoint, okCheckpoint = metrics["log_lsn_last_checkpoint"]
+	}
+	if !okCurrent || !okCheckpoint {
+		if err := db.QueryRow(innodbRedoLogLogStatusQuery).Scan(&current, &checkpoint); err != nil {
+			log.Debugln("Error querying log_status, skipping checkpoint age:", err)
+		} else {
+			okCurrent, okCheckpoint = true, true
+		}
+	}
+	if okCurrent && okCheckpoint && current > 0 {
+		ch <- prometheus.MustNewConstMetric(innodbCheckpointAgeDesc, prometheus.GaugeValue, current-checkpoint)
+	}
+	if async, ok := metrics["log_max_modified_age_async"]; ok {
+		ch <- prometheus.MustNewConstMetric(innodbCheckpointAsyncFlushPointDesc, prometheus.GaugeValue, async)
+	}
+	if sync, ok := metrics["log_max_modified_age_sync"]; ok {
+		ch <- prometheus.MustNewConstMetric(innodbCheckpointSyncFlushPointDesc, prometheus.GaugeValue, sync)
+	}
+	return nil
+}
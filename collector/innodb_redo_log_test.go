@@ -0,0 +1,145 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeInnodbRedoLog(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(innodbRedoLogVariablesQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"Variable_name", "Value"}).
+			AddRow("innodb_log_file_size", "50331648").
+			AddRow("innodb_log_files_in_group", "2"))
+	mock.ExpectQuery(sanitizeQuery(innodbRedoLogStatusQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"Variable_name", "Value"}))
+	mock.ExpectQuery(sanitizeQuery(innodbRedoLogMetricsQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"NAME", "COUNT"}).
+			AddRow("log_lsn_current", "1000000000").
+			AddRow("log_lsn_last_checkpoint", "990000000").
+			AddRow("log_max_modified_age_async", "78643200").
+			AddRow("log_max_modified_age_sync", "84272742"))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeInnodbRedoLog{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{}, value: 100663296, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 10000000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 78643200, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 84272742, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestScrapeInnodbRedoLogStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	// MySQL 8.0.30 with the log module counters disabled.
+	mock.ExpectQuery(sanitizeQuery(innodbRedoLogVariablesQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"Variable_name", "Value"}).
+			AddRow("innodb_redo_log_capacity", "104857600").
+			AddRow("innodb_log_file_size", "50331648").
+			AddRow("innodb_log_files_in_group", "2"))
+	mock.ExpectQuery(sanitizeQuery(innodbRedoLogStatusQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"Variable_name", "Value"}).
+			AddRow("Innodb_redo_log_capacity_resized", "209715200").
+			AddRow("Innodb_redo_log_checkpoint_lsn", "990000000").
+			AddRow("Innodb_redo_log_current_lsn", "1000000000").
+			AddRow("Innodb_redo_log_resize_status", "OK"))
+	mock.ExpectQuery(sanitizeQuery(innodbRedoLogMetricsQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"NAME", "COUNT"}))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeInnodbRedoLog{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{}, value: 209715200, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 10000000, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestScrapeInnodbRedoLogMariaDB(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	// MariaDB 10.5 has neither the capacity nor the number of log files,
+	// nor performance_schema.log_status.
+	mock.ExpectQuery(sanitizeQuery(innodbRedoLogVariablesQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"Variable_name", "Value"}).
+			AddRow("innodb_log_file_size", "100663296"))
+	mock.ExpectQuery(sanitizeQuery(innodbRedoLogStatusQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"Variable_name", "Value"}))
+	mock.ExpectQuery(sanitizeQuery(innodbRedoLogMetricsQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"NAME", "COUNT"}))
+	mock.ExpectQuery(sanitizeQuery(innodbRedoLogLogStatusQuery)).WillReturnError(
+		fmt.Errorf("Table 'performance_schema.log_status' doesn't exist"))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeInnodbRedoLog{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("No metrics", t, func() {
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,94 @@
+// Scrape `mysql.innodb_index_stats`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// Subsystem.
+const innodbIndex = "innodb_index"
+
+// innodbIndexSizeQuery reads the size of the indexes from the persistent
+// statistics, which are updated by ANALYZE TABLE and the automatic
+// recalculation of InnoDB.
+const innodbIndexSizeQuery = `
+	SELECT database_name, table_name, index_name, stat_value, stat_value * @@innodb_page_size
+	  FROM mysql.innodb_index_stats
+	  WHERE stat_name = 'size'
+	    AND CONCAT(database_name, '.', table_name) REGEXP ?
+	  ORDER BY stat_value DESC
+	  LIMIT %d
+	`
+
+// Tunable flags.
+var (
+	innodbIndexStatsFilter = kingpin.Flag(
+		"collect.mysql.innodb_index_stats.filter",
+		"RegEx schema.table filter for mysql.innodb_index_stats",
+	).Default(".*").String()
+	innodbIndexStatsLimit = kingpin.Flag(
+		"collect.mysql.innodb_index_stats.limit",
+		"Limit the number of indexes by size",
+	).Default("100").Int()
+)
+
+// Metric descriptors.
+var (
+	innodbIndexSizePagesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbIndex, "size_pages"),
+		"The number of pages of the index from the persistent statistics.",
+		[]string{"schema", "table", "index"}, nil,
+	)
+	innodbIndexSizeBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbIndex, "size_bytes"),
+		"The size of the index from the persistent statistics.",
+		[]string{"schema", "table", "index"}, nil,
+	)
+)
+
+// ScrapeInnodbIndexStats collects from `mysql.innodb_index_stats`.
+type ScrapeInnodbIndexStats struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeInnodbIndexStats) Name() string {
+	return "mysql.innodb_index_stats"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeInnodbIndexStats) Help() string {
+	return "Collect the size of the largest indexes from mysql.innodb_index_stats"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeInnodbIndexStats) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	innodbIndexSizeRows, err := db.Query(fmt.Sprintf(innodbIndexSizeQuery, *innodbIndexStatsLimit), *innodbIndexStatsFilter)
+	if err != nil {
+		return err
+	}
+	defer innodbIndexSizeRows.Close()
+
+	var (
+		schema, table, index string
+		pages, bytes         uint64
+	)
+
+	for innodbIndexSizeRows.Next() {
+		if err := innodbIndexSizeRows.Scan(&schema, &table, &index, &pages, &bytes); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			innodbIndexSizePagesDesc, prometheus.GaugeValue, float64(pages),
+			schema, table, index,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			innodbIndexSizeBytesDesc, prometheus.GaugeValue, float64(bytes),
+			schema, table, index,
+		)
+	}
+	return innodbIndexSizeRows.Err()
+}
@@ -0,0 +1,104 @@
+// Scrape `mysql.innodb_table_stats`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// Subsystem.
+const innodbTableStats = "innodb_table_stats"
+
+// innodbTableStatsQuery reads the stalest persistent optimizer statistics,
+// with their age by the clock of the server.
+const innodbTableStatsQuery = `
+	SELECT database_name, table_name, n_rows,
+	    UNIX_TIMESTAMP(last_update),
+	    TIMESTAMPDIFF(SECOND, last_update, NOW())
+	  FROM mysql.innodb_table_stats
+	  WHERE database_name NOT IN ('mysql', 'sys')
+	    AND CONCAT(database_name, '.', table_name) REGEXP ?
+	  ORDER BY last_update ASC
+	  LIMIT %d
+	`
+
+// Tunable flags.
+var (
+	innodbTableStatsFilter = kingpin.Flag(
+		"collect.mysql.innodb_table_stats.filter",
+		"RegEx schema.table filter for mysql.innodb_table_stats",
+	).Default(".*").String()
+	innodbTableStatsLimit = kingpin.Flag(
+		"collect.mysql.innodb_table_stats.limit",
+		"Limit the number of tables by age of the optimizer statistics",
+	).Default("20").Int()
+)
+
+// Metric descriptors.
+var (
+	innodbTableStatsLastUpdateDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbTableStats, "last_update_timestamp_seconds"),
+		"The time the persistent optimizer statistics of the table were last updated.",
+		[]string{"schema", "table"}, nil,
+	)
+	innodbTableStatsAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbTableStats, "age_seconds"),
+		"The age of the persistent optimizer statistics of the table.",
+		[]string{"schema", "table"}, nil,
+	)
+	innodbTableStatsRowsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, innodbTableStats, "rows"),
+		"The number of rows of the table at the last update of its persistent optimizer statistics.",
+		[]string{"schema", "table"}, nil,
+	)
+)
+
+// ScrapeInnodbTableStats collects from `mysql.innodb_table_stats`.
+type ScrapeInnodbTableStats struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeInnodbTableStats) Name() string {
+	return "mysql.innodb_table_stats"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeInnodbTableStats) Help() string {
+	return "Collect the age of the stalest persistent optimizer statistics from mysql.innodb_table_stats"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeInnodbTableStats) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	innodbTableStatsRows, err := db.Query(fmt.Sprintf(innodbTableStatsQuery, *innodbTableStatsLimit), *innodbTableStatsFilter)
+	if err != nil {
+		return err
+	}
+	defer innodbTableStatsRows.Close()
+
+	var (
+		schema, table         string
+		rows, lastUpdate, age float64
+	)
+
+	for innodbTableStatsRows.Next() {
+		if err := innodbTableStatsRows.Scan(&schema, &table, &rows, &lastUpdate, &age); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			innodbTableStatsLastUpdateDesc, prometheus.GaugeValue, lastUpdate,
+			schema, table,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			innodbTableStatsAgeDesc, prometheus.GaugeValue, age,
+			schema, table,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			innodbTableStatsRowsDesc, prometheus.GaugeValue, rows,
+			schema, table,
+		)
+	}
+	return innodbTableStatsRows.Err()
+}
@@ -0,0 +1,55 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeInnodbTableStats(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"database_name", "table_name", "n_rows", "UNIX_TIMESTAMP(last_update)", "TIMESTAMPDIFF(SECOND, last_update, NOW())"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("shop", "orders", "5200000", "1760000000", "2592000").
+		AddRow("shop", "customers", "81000", "1762000000", "592000")
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(innodbTableStatsQuery, 20))).WithArgs(".*").WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeInnodbTableStats{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	orders := labelMap{"schema": "shop", "table": "orders"}
+	customers := labelMap{"schema": "shop", "table": "customers"}
+	metricExpected := []MetricResult{
+		{labels: orders, value: 1760000000, metricType: dto.MetricType_GAUGE},
+		{labels: orders, value: 2592000, metricType: dto.MetricType_GAUGE},
+		{labels: orders, value: 5200000, metricType: dto.MetricType_GAUGE},
+		{labels: customers, value: 1762000000, metricType: dto.MetricType_GAUGE},
+		{labels: customers, value: 592000, metricType: dto.MetricType_GAUGE},
+		{labels: customers, value: 81000, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,99 @@
+// Scrape `mysql.user`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// account is the Metric subsystem we use.
+	account = "account"
+	// mysqlUserQuery requires the SELECT privilege on mysql.user, and the
+	// password expiry and locking columns of MySQL 5.7.
+	mysqlUserQuery = `
+	SELECT
+	    COUNT(*),
+	    ifnull(SUM(password_expired = 'Y'), 0),
+	    ifnull(SUM(account_locked = 'Y'), 0),
+	    ifnull(SUM(INSTR(Host, '%') > 0), 0),
+	    ifnull(SUM(plugin = 'mysql_native_password'), 0)
+	  FROM mysql.user
+	`
+	mysqlUserPasswordExpiryQuery = `
+	SELECT
+	    User, Host,
+	    TIMESTAMPDIFF(SECOND, NOW(), DATE_ADD(password_last_changed,
+	        INTERVAL ifnull(password_lifetime, @@default_password_lifetime) DAY))
+	  FROM mysql.user
+	  WHERE ifnull(password_lifetime, @@default_password_lifetime) > 0
+	    AND password_last_changed IS NOT NULL
+	    AND password_expired = 'N'
+	    AND account_locked = 'N'
+	`
+)
+
+// Metric descriptors.
+var (
+	accountsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, account, "accounts"),
+		"The number of accounts in mysql.user.",
+		nil, nil,
+	)
+	accountsPostureDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, account, "security_accounts"),
+		"The number of accounts with an expired password, locked, with a wildcard host or using mysql_native_password.",
+		[]string{"posture"}, nil,
+	)
+	accountPasswordExpiryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, account, "password_expiry_seconds"),
+		"The number of seconds until the password of the account expires.",
+		[]string{"user", "host"}, nil,
+	)
+)
+
+// ScrapeMysqlUser collects from `mysql.user`.
+type ScrapeMysqlUser struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeMysqlUser) Name() string {
+	return "mysql.user"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeMysqlUser) Help() string {
+	return "Collect account security posture from mysql.user"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeMysqlUser) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var total, expired, locked, wildcardHost, nativePassword uint64
+	if err := db.QueryRow(mysqlUserQuery).Scan(&total, &expired, &locked, &wildcardHost, &nativePassword); err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(accountsDesc, prometheus.GaugeValue, float64(total))
+	ch <- prometheus.MustNewConstMetric(accountsPostureDesc, prometheus.GaugeValue, float64(expired), "password_expired")
+	ch <- prometheus.MustNewConstMetric(accountsPostureDesc, prometheus.GaugeValue, float64(locked), "locked")
+	ch <- prometheus.MustNewConstMetric(accountsPostureDesc, prometheus.GaugeValue, float64(wildcardHost), "wildcard_host")
+	ch <- prometheus.MustNewConstMetric(accountsPostureDesc, prometheus.GaugeValue, float64(nativePassword), "mysql_native_password")
+
+	mysqlUserPasswordExpiryRows, err := db.Query(mysqlUserPasswordExpiryQuery)
+	if err != nil {
+		return err
+	}
+	defer mysqlUserPasswordExpiryRows.Close()
+
+	var (
+		user, host string
+		expiry     int64
+	)
+	for mysqlUserPasswordExpiryRows.Next() {
+		if err := mysqlUserPasswordExpiryRows.Scan(&user, &host, &expiry); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(accountPasswordExpiryDesc, prometheus.GaugeValue, float64(expiry), user, host)
+	}
+	return nil
+}
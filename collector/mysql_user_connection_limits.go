@@ -0,0 +1,103 @@
+// Scrape per-user connection limits from `mysql.user` and `performance_schema.users`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	maxUserConnectionsQuery = `SELECT @@max_user_connections`
+	// userConnectionLimitsQuery takes the highest per-account limit of the
+	// user, as performance_schema.users counts connections by user only.
+	userConnectionLimitsQuery = `
+	SELECT
+	    u.USER,
+	    u.CURRENT_CONNECTIONS,
+	    ifnull(MAX(m.max_user_connections), 0)
+	  FROM performance_schema.users u
+	  LEFT JOIN mysql.user m
+	    ON m.User = u.USER
+	  WHERE u.USER IS NOT NULL
+	  GROUP BY u.USER, u.CURRENT_CONNECTIONS
+	`
+)
+
+// Metric descriptors.
+var (
+	accountMaxUserConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, account, "max_user_connections"),
+		"The global maximum number of simultaneous connections per account, 0 if unlimited.",
+		nil, nil,
+	)
+	accountUserConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, account, "user_connections"),
+		"The current number of connections by user.",
+		[]string{"user"}, nil,
+	)
+	accountUserConnectionLimitDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, account, "user_connection_limit"),
+		"The effective maximum number of simultaneous connections of the user, from mysql.user or the global max_user_connections.",
+		[]string{"user"}, nil,
+	)
+	accountUserConnectionUtilizationDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, account, "user_connection_utilization_ratio"),
+		"The ratio of current connections to the effective connection limit of the user.",
+		[]string{"user"}, nil,
+	)
+)
+
+// ScrapeUserConnectionLimits collects per-user connection limit utilization.
+type ScrapeUserConnectionLimits struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeUserConnectionLimits) Name() string {
+	return "mysql.user_connection_limits"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeUserConnectionLimits) Help() string {
+	return "Collect current connections and max_user_connections utilization by user from performance_schema.users and mysql.user"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeUserConnectionLimits) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var globalLimit uint64
+	if err := db.QueryRow(maxUserConnectionsQuery).Scan(&globalLimit); err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(accountMaxUserConnectionsDesc, prometheus.GaugeValue, float64(globalLimit))
+
+	userConnectionLimitsRows, err := db.Query(userConnectionLimitsQuery)
+	if err != nil {
+		return err
+	}
+	defer userConnectionLimitsRows.Close()
+
+	var (
+		user               string
+		current, userLimit uint64
+	)
+	for userConnectionLimitsRows.Next() {
+		if err := userConnectionLimitsRows.Scan(&user, &current, &userLimit); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(accountUserConnectionsDesc, prometheus.GaugeValue, float64(current), user)
+
+		// An account limit of 0 falls back to the global limit, 0 again means unlimited.
+		limit := userLimit
+		if limit == 0 {
+			limit = globalLimit
+		}
+		if limit == 0 {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(accountUserConnectionLimitDesc, prometheus.GaugeValue, float64(limit), user)
+		ch <- prometheus.MustNewConstMetric(
+			accountUserConnectionUtilizationDesc, prometheus.GaugeValue, float64(current)/float64(limit), user,
+		)
+	}
+	return nil
+}
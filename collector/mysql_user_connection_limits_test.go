@@ -0,0 +1,54 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeUserConnectionLimits(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(maxUserConnectionsQuery)).WillReturnRows(sqlmock.NewRows([]string{"@@max_user_connections"}).AddRow(100))
+	columns := []string{"USER", "CURRENT_CONNECTIONS", "max_user_connections"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("app", "40", "50").
+		AddRow("report", "10", "0")
+	mock.ExpectQuery(sanitizeQuery(userConnectionLimitsQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeUserConnectionLimits{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{}, value: 100, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "app"}, value: 40, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "app"}, value: 50, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "app"}, value: 0.8, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "report"}, value: 10, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "report"}, value: 100, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "report"}, value: 0.1, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,54 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeMysqlUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(mysqlUserQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"COUNT(*)", "expired", "locked", "wildcard_host", "native_password"}).AddRow(12, 1, 4, 3, 5))
+	mock.ExpectQuery(sanitizeQuery(mysqlUserPasswordExpiryQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"User", "Host", "expiry"}).
+			AddRow("app", "10.0.0.%", "864000").
+			AddRow("legacy", "%", "-3600"))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeMysqlUser{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{}, value: 12, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"posture": "password_expired"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"posture": "locked"}, value: 4, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"posture": "wildcard_host"}, value: 3, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"posture": "mysql_native_password"}, value: 5, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "app", "host": "10.0.0.%"}, value: 864000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "legacy", "host": "%"}, value: -3600, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,25 @@
+package collector
+
+import (
+	"database/sql"
+)
+
+// Subsystem.
+const ndbinfo = "ndbinfo"
+
+// ndbClusterQuery checks whether the server is an SQL node of an NDB Cluster.
+const ndbClusterQuery = `
+	SELECT COUNT(*)
+	  FROM information_schema.ENGINES
+	 WHERE ENGINE = 'ndbcluster' AND SUPPORT IN ('YES', 'DEFAULT')
+	`
+
+// ndbClusterEnabled reports whether the ndbcluster engine is enabled, so the
+// ndbinfo collectors do nothing on servers without NDB.
+func ndbClusterEnabled(db *sql.DB) (bool, error) {
+	var count int
+	if err := db.QueryRow(ndbClusterQuery).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
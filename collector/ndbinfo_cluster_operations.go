@@ -0,0 +1,61 @@
+// Scrape `ndbinfo.cluster_operations`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const ndbinfoClusterOperationsQuery = `
+	SELECT node_id, operation_type, state, COUNT(*)
+	  FROM ndbinfo.cluster_operations
+	  GROUP BY node_id, operation_type, state
+	`
+
+// Metric descriptors.
+var (
+	ndbinfoClusterOperationsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, ndbinfo, "cluster_operations"),
+		"The number of ongoing operations on the data node by operation type and state.",
+		[]string{"node_id", "operation_type", "state"}, nil,
+	)
+)
+
+// ScrapeNDBInfoClusterOperations collects from `ndbinfo.cluster_operations`.
+type ScrapeNDBInfoClusterOperations struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeNDBInfoClusterOperations) Name() string {
+	return ndbinfo + ".cluster_operations"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeNDBInfoClusterOperations) Help() string {
+	return "Collect the ongoing operations of NDB Cluster data nodes from ndbinfo.cluster_operations"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeNDBInfoClusterOperations) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	if enabled, err := ndbClusterEnabled(db); err != nil || !enabled {
+		return err
+	}
+	operationsRows, err := db.Query(ndbinfoClusterOperationsQuery)
+	if err != nil {
+		return err
+	}
+	defer operationsRows.Close()
+
+	var (
+		nodeID, operationType, state string
+		count                        uint64
+	)
+	for operationsRows.Next() {
+		if err := operationsRows.Scan(&nodeID, &operationType, &state, &count); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(ndbinfoClusterOperationsDesc, prometheus.GaugeValue, float64(count), nodeID, operationType, state)
+	}
+	return operationsRows.Err()
+}
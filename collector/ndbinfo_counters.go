@@ -0,0 +1,62 @@
+// Scrape `ndbinfo.counters`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Counters are summed over the instances of multi-threaded blocks.
+const ndbinfoCountersQuery = `
+	SELECT node_id, block_name, counter_name, SUM(val)
+	  FROM ndbinfo.counters
+	  GROUP BY node_id, block_name, counter_name
+	`
+
+// Metric descriptors.
+var (
+	ndbinfoCounterDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, ndbinfo, "counter_total"),
+		"The value of the data node counter since the node was started, e.g. READS or TRANSACTIONS.",
+		[]string{"node_id", "block", "counter"}, nil,
+	)
+)
+
+// ScrapeNDBInfoCounters collects from `ndbinfo.counters`.
+type ScrapeNDBInfoCounters struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeNDBInfoCounters) Name() string {
+	return ndbinfo + ".counters"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeNDBInfoCounters) Help() string {
+	return "Collect the operation counters of NDB Cluster data nodes from ndbinfo.counters"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeNDBInfoCounters) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	if enabled, err := ndbClusterEnabled(db); err != nil || !enabled {
+		return err
+	}
+	countersRows, err := db.Query(ndbinfoCountersQuery)
+	if err != nil {
+		return err
+	}
+	defer countersRows.Close()
+
+	var (
+		nodeID, block, counter string
+		value                  uint64
+	)
+	for countersRows.Next() {
+		if err := countersRows.Scan(&nodeID, &block, &counter, &value); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(ndbinfoCounterDesc, prometheus.CounterValue, float64(value), nodeID, block, counter)
+	}
+	return countersRows.Err()
+}
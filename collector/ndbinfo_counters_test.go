@@ -0,0 +1,49 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeNDBInfoCounters(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(ndbClusterQuery)).WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+	columns := []string{"node_id", "block_name", "counter_name", "SUM(val)"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("1", "DBLQH", "OPERATIONS", "5210").
+		AddRow("1", "DBTC", "TRANSACTIONS", "130")
+	mock.ExpectQuery(sanitizeQuery(ndbinfoCountersQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeNDBInfoCounters{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"node_id": "1", "block": "DBLQH", "counter": "OPERATIONS"}, value: 5210, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"node_id": "1", "block": "DBTC", "counter": "TRANSACTIONS"}, value: 130, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
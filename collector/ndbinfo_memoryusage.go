@@ -0,0 +1,66 @@
+// Scrape `ndbinfo.memoryusage`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const ndbinfoMemoryUsageQuery = `
+	SELECT node_id, memory_type, used, total
+	  FROM ndbinfo.memoryusage
+	`
+
+// Metric descriptors.
+var (
+	ndbinfoMemoryUsedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, ndbinfo, "memory_used_bytes"),
+		"The memory used by the data node by memory type.",
+		[]string{"node_id", "memory_type"}, nil,
+	)
+	ndbinfoMemoryTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, ndbinfo, "memory_total_bytes"),
+		"The memory available to the data node by memory type.",
+		[]string{"node_id", "memory_type"}, nil,
+	)
+)
+
+// ScrapeNDBInfoMemoryUsage collects from `ndbinfo.memoryusage`.
+type ScrapeNDBInfoMemoryUsage struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeNDBInfoMemoryUsage) Name() string {
+	return ndbinfo + ".memoryusage"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeNDBInfoMemoryUsage) Help() string {
+	return "Collect the data and index memory usage of NDB Cluster data nodes from ndbinfo.memoryusage"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeNDBInfoMemoryUsage) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	if enabled, err := ndbClusterEnabled(db); err != nil || !enabled {
+		return err
+	}
+	memoryRows, err := db.Query(ndbinfoMemoryUsageQuery)
+	if err != nil {
+		return err
+	}
+	defer memoryRows.Close()
+
+	var (
+		nodeID, memoryType string
+		used, total        uint64
+	)
+	for memoryRows.Next() {
+		if err := memoryRows.Scan(&nodeID, &memoryType, &used, &total); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(ndbinfoMemoryUsedDesc, prometheus.GaugeValue, float64(used), nodeID, memoryType)
+		ch <- prometheus.MustNewConstMetric(ndbinfoMemoryTotalDesc, prometheus.GaugeValue, float64(total), nodeID, memoryType)
+	}
+	return memoryRows.Err()
+}
@@ -0,0 +1,51 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeNDBInfoMemoryUsage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(ndbClusterQuery)).WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+	columns := []string{"node_id", "memory_type", "used", "total"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("1", "Data memory", "1048576", "83886080").
+		AddRow("1", "Long message buffer", "262144", "67108864")
+	mock.ExpectQuery(sanitizeQuery(ndbinfoMemoryUsageQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeNDBInfoMemoryUsage{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"node_id": "1", "memory_type": "Data memory"}, value: 1048576, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"node_id": "1", "memory_type": "Data memory"}, value: 83886080, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"node_id": "1", "memory_type": "Long message buffer"}, value: 262144, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"node_id": "1", "memory_type": "Long message buffer"}, value: 67108864, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,72 @@
+// Scrape `ndbinfo.nodes`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const ndbinfoNodesQuery = `
+	SELECT node_id, uptime, status, start_phase
+	  FROM ndbinfo.nodes
+	`
+
+// Metric descriptors.
+var (
+	ndbinfoNodeUptimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, ndbinfo, "node_uptime_seconds"),
+		"The time since the data node was last started.",
+		[]string{"node_id"}, nil,
+	)
+	ndbinfoNodeStatusDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, ndbinfo, "node_status"),
+		"The status of the data node, e.g. STARTED, always 1.",
+		[]string{"node_id", "status"}, nil,
+	)
+	ndbinfoNodeStartPhaseDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, ndbinfo, "node_start_phase"),
+		"The current start phase of a starting data node, 0 once started.",
+		[]string{"node_id"}, nil,
+	)
+)
+
+// ScrapeNDBInfoNodes collects from `ndbinfo.nodes`.
+type ScrapeNDBInfoNodes struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeNDBInfoNodes) Name() string {
+	return ndbinfo + ".nodes"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeNDBInfoNodes) Help() string {
+	return "Collect the status and uptime of NDB Cluster data nodes from ndbinfo.nodes"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeNDBInfoNodes) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	if enabled, err := ndbClusterEnabled(db); err != nil || !enabled {
+		return err
+	}
+	nodesRows, err := db.Query(ndbinfoNodesQuery)
+	if err != nil {
+		return err
+	}
+	defer nodesRows.Close()
+
+	var (
+		nodeID, status     string
+		uptime, startPhase uint64
+	)
+	for nodesRows.Next() {
+		if err := nodesRows.Scan(&nodeID, &uptime, &status, &startPhase); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(ndbinfoNodeUptimeDesc, prometheus.GaugeValue, float64(uptime), nodeID)
+		ch <- prometheus.MustNewConstMetric(ndbinfoNodeStatusDesc, prometheus.GaugeValue, 1, nodeID, status)
+		ch <- prometheus.MustNewConstMetric(ndbinfoNodeStartPhaseDesc, prometheus.GaugeValue, float64(startPhase), nodeID)
+	}
+	return nodesRows.Err()
+}
@@ -0,0 +1,81 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeNDBInfoNodes(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(ndbClusterQuery)).WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+	columns := []string{"node_id", "uptime", "status", "start_phase"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("1", "86400", "STARTED", "0").
+		AddRow("2", "12", "STARTING", "4")
+	mock.ExpectQuery(sanitizeQuery(ndbinfoNodesQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeNDBInfoNodes{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"node_id": "1"}, value: 86400, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"node_id": "1", "status": "STARTED"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"node_id": "1"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"node_id": "2"}, value: 12, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"node_id": "2", "status": "STARTING"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"node_id": "2"}, value: 4, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestScrapeNDBInfoNodesWithoutNDB(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(ndbClusterQuery)).WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(0))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeNDBInfoNodes{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("No metrics without NDB", t, func() {
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
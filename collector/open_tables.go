@@ -0,0 +1,129 @@
+// Scrape `SHOW OPEN TABLES` and table cache sizing.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Subsystem.
+const tableCache = "table_cache"
+
+const (
+	tableCacheStatusQuery = `
+	SHOW GLOBAL STATUS
+	  WHERE Variable_name IN ('Open_tables', 'Open_table_definitions')
+	`
+	tableCacheVariablesQuery = `
+	SHOW GLOBAL VARIABLES
+	  WHERE Variable_name IN ('table_open_cache', 'table_definition_cache')
+	`
+	openTablesQuery = `SHOW OPEN TABLES`
+)
+
+// Metric descriptors.
+var (
+	tableCacheSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, tableCache, "size"),
+		"The number of open tables the table cache can hold (table_open_cache).",
+		nil, nil,
+	)
+	tableCacheDefinitionSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, tableCache, "definition_size"),
+		"The number of table definitions the definition cache can hold (table_definition_cache).",
+		nil, nil,
+	)
+	tableCacheFillRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, tableCache, "fill_ratio"),
+		"The ratio of open tables to table_open_cache.",
+		nil, nil,
+	)
+	tableCacheDefinitionFillRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, tableCache, "definition_fill_ratio"),
+		"The ratio of open table definitions to table_definition_cache.",
+		nil, nil,
+	)
+	tableCacheOpenTablesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, tableCache, "open_tables"),
+		"The number of tables in the table cache by database.",
+		[]string{"database"}, nil,
+	)
+	tableCacheInUseTablesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, tableCache, "in_use_tables"),
+		"The number of tables in the table cache currently locked or in use by database.",
+		[]string{"database"}, nil,
+	)
+)
+
+// ScrapeOpenTables collects from `SHOW OPEN TABLES` and the table cache variables.
+type ScrapeOpenTables struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeOpenTables) Name() string {
+	return "open_tables"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeOpenTables) Help() string {
+	return "Collect open table counts per database and table cache fill ratios"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeOpenTables) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	values := map[string]float64{}
+	for _, query := range []string{tableCacheStatusQuery, tableCacheVariablesQuery} {
+		if err := scrapeNamedValues(db, query, values); err != nil {
+			return err
+		}
+	}
+
+	if size, ok := values["table_open_cache"]; ok {
+		ch <- prometheus.MustNewConstMetric(tableCacheSizeDesc, prometheus.GaugeValue, size)
+		if open, ok := values["Open_tables"]; ok && size > 0 {
+			ch <- prometheus.MustNewConstMetric(tableCacheFillRatioDesc, prometheus.GaugeValue, open/size)
+		}
+	}
+	if size, ok := values["table_definition_cache"]; ok {
+		ch <- prometheus.MustNewConstMetric(tableCacheDefinitionSizeDesc, prometheus.GaugeValue, size)
+		if open, ok := values["Open_table_definitions"]; ok && size > 0 {
+			ch <- prometheus.MustNewConstMetric(tableCacheDefinitionFillRatioDesc, prometheus.GaugeValue, open/size)
+		}
+	}
+
+	openTablesRows, err := db.Query(openTablesQuery)
+	if err != nil {
+		return err
+	}
+	defer openTablesRows.Close()
+
+	var (
+		database, table   string
+		inUse, nameLocked uint64
+		databases         []string
+		openByDatabase    = map[string]uint64{}
+		inUseByDatabase   = map[string]uint64{}
+	)
+	for openTablesRows.Next() {
+		if err := openTablesRows.Scan(&database, &table, &inUse, &nameLocked); err != nil {
+			return err
+		}
+		if _, ok := openByDatabase[database]; !ok {
+			databases = append(databases, database)
+		}
+		openByDatabase[database]++
+		if inUse > 0 {
+			inUseByDatabase[database]++
+		}
+	}
+	for _, database := range databases {
+		ch <- prometheus.MustNewConstMetric(
+			tableCacheOpenTablesDesc, prometheus.GaugeValue, float64(openByDatabase[database]), database,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			tableCacheInUseTablesDesc, prometheus.GaugeValue, float64(inUseByDatabase[database]), database,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeOpenTables(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"Variable_name", "Value"}
+	mock.ExpectQuery(sanitizeQuery(tableCacheStatusQuery)).WillReturnRows(
+		sqlmock.NewRows(columns).
+			AddRow("Open_table_definitions", "300").
+			AddRow("Open_tables", "1000"))
+	mock.ExpectQuery(sanitizeQuery(tableCacheVariablesQuery)).WillReturnRows(
+		sqlmock.NewRows(columns).
+			AddRow("table_definition_cache", "1200").
+			AddRow("table_open_cache", "4000"))
+	mock.ExpectQuery(sanitizeQuery(openTablesQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"Database", "Table", "In_use", "Name_locked"}).
+			AddRow("db1", "t1", "1", "0").
+			AddRow("db1", "t2", "0", "0").
+			AddRow("db2", "t1", "0", "0"))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeOpenTables{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{}, value: 4000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 0.25, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 1200, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 0.25, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"database": "db1"}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"database": "db1"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"database": "db2"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"database": "db2"}, value: 0, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,135 @@
+// Scrape `performance_schema.accounts` and `performance_schema.hosts`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	perfAccountsQuery = `
+	SELECT
+	    ifnull(USER, '') as USER,
+	    ifnull(HOST, '') as HOST,
+	    CURRENT_CONNECTIONS,
+	    TOTAL_CONNECTIONS
+	  FROM performance_schema.accounts
+	`
+	perfHostsQuery = `
+	SELECT
+	    ifnull(HOST, '') as HOST,
+	    CURRENT_CONNECTIONS,
+	    TOTAL_CONNECTIONS
+	  FROM performance_schema.hosts
+	`
+)
+
+// Metric descriptors.
+var (
+	performanceSchemaAccountsCurrentConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "accounts_current_connections"),
+		"The number of current connections by account.",
+		[]string{"user", "host"}, nil,
+	)
+	performanceSchemaAccountsConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "accounts_connections_total"),
+		"The total number of connections by account.",
+		[]string{"user", "host"}, nil,
+	)
+	performanceSchemaHostsCurrentConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "hosts_current_connections"),
+		"The number of current connections by host.",
+		[]string{"host"}, nil,
+	)
+	performanceSchemaHostsConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "hosts_connections_total"),
+		"The total number of connections by host.",
+		[]string{"host"}, nil,
+	)
+)
+
+// ScrapePerfAccounts collects from `performance_schema.accounts`.
+type ScrapePerfAccounts struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapePerfAccounts) Name() string {
+	return performanceSchema + ".accounts"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapePerfAccounts) Help() string {
+	return "Collect current and total connections by account from performance_schema.accounts"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapePerfAccounts) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	perfAccountsRows, err := db.Query(perfAccountsQuery)
+	if err != nil {
+		return err
+	}
+	defer perfAccountsRows.Close()
+
+	var (
+		user, host     string
+		current, total uint64
+	)
+
+	for perfAccountsRows.Next() {
+		if err := perfAccountsRows.Scan(&user, &host, &current, &total); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaAccountsCurrentConnectionsDesc, prometheus.GaugeValue, float64(current),
+			user, host,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaAccountsConnectionsDesc, prometheus.CounterValue, float64(total),
+			user, host,
+		)
+	}
+	return nil
+}
+
+// ScrapePerfHosts collects from `performance_schema.hosts`.
+type ScrapePerfHosts struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapePerfHosts) Name() string {
+	return performanceSchema + ".hosts"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapePerfHosts) Help() string {
+	return "Collect current and total connections by host from performance_schema.hosts"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapePerfHosts) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	perfHostsRows, err := db.Query(perfHostsQuery)
+	if err != nil {
+		return err
+	}
+	defer perfHostsRows.Close()
+
+	var (
+		host           string
+		current, total uint64
+	)
+
+	for perfHostsRows.Next() {
+		if err := perfHostsRows.Scan(&host, &current, &total); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaHostsCurrentConnectionsDesc, prometheus.GaugeValue, float64(current),
+			host,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaHostsConnectionsDesc, prometheus.CounterValue, float64(total),
+			host,
+		)
+	}
+	return nil
+}
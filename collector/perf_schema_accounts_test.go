@@ -0,0 +1,50 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapePerfAccounts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"USER", "HOST", "CURRENT_CONNECTIONS", "TOTAL_CONNECTIONS"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("app", "10.0.0.1", "12", "3401").
+		AddRow("", "", "38", "40")
+	mock.ExpectQuery(sanitizeQuery(perfAccountsQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapePerfAccounts{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"user": "app", "host": "10.0.0.1"}, value: 12, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "app", "host": "10.0.0.1"}, value: 3401, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"user": "", "host": ""}, value: 38, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "", "host": ""}, value: 40, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
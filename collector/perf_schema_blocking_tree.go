@@ -0,0 +1,119 @@
+// Scrape the blocking tree from `performance_schema.data_lock_waits`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// perfBlockingTreeQuery returns one row per blocking relationship, with the
+// age of the blocker's transaction, or of its current state if it has none.
+const perfBlockingTreeQuery = `
+	SELECT DISTINCT
+	    w.REQUESTING_THREAD_ID,
+	    w.BLOCKING_THREAD_ID,
+	    ifnull(TIMESTAMPDIFF(SECOND, trx.trx_started, NOW()), ifnull(b.PROCESSLIST_TIME, 0))
+	  FROM performance_schema.data_lock_waits w
+	  LEFT JOIN performance_schema.threads b
+	    ON b.THREAD_ID = w.BLOCKING_THREAD_ID
+	  LEFT JOIN information_schema.innodb_trx trx
+	    ON trx.trx_mysql_thread_id = b.PROCESSLIST_ID
+	`
+
+// Metric descriptors.
+var (
+	performanceSchemaBlockingTreesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "blocking_trees"),
+		"The number of root blockers, i.e. sessions blocking others without waiting themselves.",
+		nil, nil,
+	)
+	performanceSchemaBlockingTreeMaxSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "blocking_tree_max_size"),
+		"The number of sessions directly or transitively blocked by the root of the largest blocking tree.",
+		nil, nil,
+	)
+	performanceSchemaBlockingTreeRootAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "blocking_tree_root_age_seconds"),
+		"The age of the transaction of the root of the largest blocking tree.",
+		nil, nil,
+	)
+)
+
+// ScrapePerfBlockingTree summarizes the blocking tree from `performance_schema.data_lock_waits`.
+type ScrapePerfBlockingTree struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapePerfBlockingTree) Name() string {
+	return performanceSchema + ".blocking_tree"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapePerfBlockingTree) Help() string {
+	return "Collect the size and root blocker age of the largest blocking tree from performance_schema.data_lock_waits"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapePerfBlockingTree) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	perfBlockingTreeRows, err := db.Query(perfBlockingTreeQuery)
+	if err != nil {
+		return err
+	}
+	defer perfBlockingTreeRows.Close()
+
+	var (
+		requesting, blocking uint64
+		age                  float64
+		blockers             []uint64
+	)
+	blocked := map[uint64][]uint64{}
+	waiting := map[uint64]bool{}
+	ages := map[uint64]float64{}
+	for perfBlockingTreeRows.Next() {
+		if err := perfBlockingTreeRows.Scan(&requesting, &blocking, &age); err != nil {
+			return err
+		}
+		if _, ok := blocked[blocking]; !ok {
+			blockers = append(blockers, blocking)
+		}
+		blocked[blocking] = append(blocked[blocking], requesting)
+		waiting[requesting] = true
+		ages[blocking] = age
+	}
+
+	var trees, maxSize int
+	var rootAge float64
+	for _, root := range blockers {
+		if waiting[root] {
+			continue
+		}
+		trees++
+		if size := blockingTreeSize(root, blocked); size > maxSize {
+			maxSize = size
+			rootAge = ages[root]
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(performanceSchemaBlockingTreesDesc, prometheus.GaugeValue, float64(trees))
+	ch <- prometheus.MustNewConstMetric(performanceSchemaBlockingTreeMaxSizeDesc, prometheus.GaugeValue, float64(maxSize))
+	ch <- prometheus.MustNewConstMetric(performanceSchemaBlockingTreeRootAgeDesc, prometheus.GaugeValue, rootAge)
+	return nil
+}
+
+// blockingTreeSize counts the sessions transitively blocked by root.
+func blockingTreeSize(root uint64, blocked map[uint64][]uint64) int {
+	seen := map[uint64]bool{root: true}
+	queue := []uint64{root}
+	for len(queue) > 0 {
+		thread := queue[0]
+		queue = queue[1:]
+		for _, waiter := range blocked[thread] {
+			if !seen[waiter] {
+				seen[waiter] = true
+				queue = append(queue, waiter)
+			}
+		}
+	}
+	return len(seen) - 1
+}
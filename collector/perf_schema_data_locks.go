@@ -0,0 +1,88 @@
+// Scrape `performance_schema.data_locks` and `performance_schema.data_lock_waits`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	perfDataLocksQuery = `
+	SELECT
+	    ifnull(OBJECT_SCHEMA, '') as OBJECT_SCHEMA,
+	    ifnull(OBJECT_NAME, '') as OBJECT_NAME,
+	    LOCK_TYPE, LOCK_MODE, LOCK_STATUS,
+	    COUNT(*)
+	  FROM performance_schema.data_locks
+	  GROUP BY OBJECT_SCHEMA, OBJECT_NAME, LOCK_TYPE, LOCK_MODE, LOCK_STATUS
+	`
+	perfDataLockWaitsQuery = `
+	SELECT COUNT(*)
+	  FROM performance_schema.data_lock_waits
+	`
+)
+
+// Metric descriptors.
+var (
+	performanceSchemaDataLocksDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "data_locks"),
+		"The number of data locks held or requested by schema, table, lock type, mode and status.",
+		[]string{"schema", "name", "lock_type", "lock_mode", "lock_status"}, nil,
+	)
+	performanceSchemaDataLockWaitsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "data_lock_waits"),
+		"The number of blocking relationships between data lock requests.",
+		nil, nil,
+	)
+)
+
+// ScrapePerfDataLocks collects from `performance_schema.data_locks` and `performance_schema.data_lock_waits`.
+type ScrapePerfDataLocks struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapePerfDataLocks) Name() string {
+	return performanceSchema + ".data_locks"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapePerfDataLocks) Help() string {
+	return "Collect metrics from performance_schema.data_locks and performance_schema.data_lock_waits"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapePerfDataLocks) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var waits uint64
+	if err := db.QueryRow(perfDataLockWaitsQuery).Scan(&waits); err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(
+		performanceSchemaDataLockWaitsDesc, prometheus.GaugeValue, float64(waits),
+	)
+
+	perfDataLocksRows, err := db.Query(perfDataLocksQuery)
+	if err != nil {
+		return err
+	}
+	defer perfDataLocksRows.Close()
+
+	var (
+		objectSchema, objectName       string
+		lockType, lockMode, lockStatus string
+		count                          uint64
+	)
+
+	for perfDataLocksRows.Next() {
+		if err := perfDataLocksRows.Scan(
+			&objectSchema, &objectName, &lockType, &lockMode, &lockStatus, &count,
+		); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaDataLocksDesc, prometheus.GaugeValue, float64(count),
+			objectSchema, objectName, lockType, lockMode, lockStatus,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapePerfDataLocks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(perfDataLockWaitsQuery)).WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2))
+
+	columns := []string{"OBJECT_SCHEMA", "OBJECT_NAME", "LOCK_TYPE", "LOCK_MODE", "LOCK_STATUS", "COUNT(*)"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("db1", "t1", "TABLE", "IX", "GRANTED", "3").
+		AddRow("db1", "t1", "RECORD", "X,REC_NOT_GAP", "GRANTED", "1").
+		AddRow("db1", "t1", "RECORD", "X,REC_NOT_GAP", "WAITING", "2")
+	mock.ExpectQuery(sanitizeQuery(perfDataLocksQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapePerfDataLocks{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "db1", "name": "t1", "lock_type": "TABLE", "lock_mode": "IX", "lock_status": "GRANTED"}, value: 3, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "db1", "name": "t1", "lock_type": "RECORD", "lock_mode": "X,REC_NOT_GAP", "lock_status": "GRANTED"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"schema": "db1", "name": "t1", "lock_type": "RECORD", "lock_mode": "X,REC_NOT_GAP", "lock_status": "WAITING"}, value: 2, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,91 @@
+// Scrape `performance_schema.events_errors_summary_global_by_error`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const perfErrorsQuery = `
+	SELECT
+	    ERROR_NUMBER,
+	    ifnull(ERROR_NAME, '') as ERROR_NAME,
+	    ifnull(SQL_STATE, '') as SQL_STATE,
+	    SUM_ERROR_RAISED,
+	    SUM_ERROR_HANDLED
+	  FROM performance_schema.events_errors_summary_global_by_error
+	  WHERE ERROR_NUMBER IS NOT NULL
+	    AND SUM_ERROR_RAISED > 0
+	  ORDER BY SUM_ERROR_RAISED DESC
+	  LIMIT %d
+	`
+
+// Tunable flags.
+var (
+	perfErrorsLimit = kingpin.Flag(
+		"collect.perf_schema.errors.limit",
+		"Limit the number of errors by times raised",
+	).Default("100").Int()
+)
+
+// Metric descriptors.
+var (
+	performanceSchemaErrorsRaisedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "errors_raised_total"),
+		"The total number of times an error was raised by error number.",
+		[]string{"error_number", "error_name", "sql_state"}, nil,
+	)
+	performanceSchemaErrorsHandledDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "errors_handled_total"),
+		"The total number of times an error was handled by an SQL exception handler by error number.",
+		[]string{"error_number", "error_name", "sql_state"}, nil,
+	)
+)
+
+// ScrapePerfErrors collects from `performance_schema.events_errors_summary_global_by_error`.
+type ScrapePerfErrors struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapePerfErrors) Name() string {
+	return performanceSchema + ".errors"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapePerfErrors) Help() string {
+	return "Collect metrics from performance_schema.events_errors_summary_global_by_error"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapePerfErrors) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	perfErrorsRows, err := db.Query(fmt.Sprintf(perfErrorsQuery, *perfErrorsLimit))
+	if err != nil {
+		return err
+	}
+	defer perfErrorsRows.Close()
+
+	var (
+		errorNumber, errorName, sqlState string
+		raised, handled                  uint64
+	)
+
+	for perfErrorsRows.Next() {
+		if err := perfErrorsRows.Scan(
+			&errorNumber, &errorName, &sqlState, &raised, &handled,
+		); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaErrorsRaisedDesc, prometheus.CounterValue, float64(raised),
+			errorNumber, errorName, sqlState,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaErrorsHandledDesc, prometheus.CounterValue, float64(handled),
+			errorNumber, errorName, sqlState,
+		)
+	}
+	return nil
+}
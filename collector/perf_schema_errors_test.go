@@ -0,0 +1,51 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapePerfErrors(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"ERROR_NUMBER", "ERROR_NAME", "SQL_STATE", "SUM_ERROR_RAISED", "SUM_ERROR_HANDLED"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("1213", "ER_LOCK_DEADLOCK", "40001", "42", "3").
+		AddRow("1040", "ER_CON_COUNT_ERROR", "08004", "7", "0")
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfErrorsQuery, 100))).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapePerfErrors{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"error_number": "1213", "error_name": "ER_LOCK_DEADLOCK", "sql_state": "40001"}, value: 42, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"error_number": "1213", "error_name": "ER_LOCK_DEADLOCK", "sql_state": "40001"}, value: 3, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"error_number": "1040", "error_name": "ER_CON_COUNT_ERROR", "sql_state": "08004"}, value: 7, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"error_number": "1040", "error_name": "ER_CON_COUNT_ERROR", "sql_state": "08004"}, value: 0, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
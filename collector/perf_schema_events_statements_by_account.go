@@ -0,0 +1,108 @@
+// Scrape `performance_schema.events_statements_summary_by_account_by_event_name`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const perfEventsStatementsByAccountQuery = `
+	SELECT
+	    ifnull(USER, '') as USER,
+	    ifnull(HOST, '') as HOST,
+	    SUM(COUNT_STAR) as COUNT_STAR,
+	    SUM(SUM_ERRORS) as SUM_ERRORS,
+	    SUM(SUM_WARNINGS) as SUM_WARNINGS,
+	    SUM(SUM_TIMER_WAIT) as SUM_TIMER_WAIT
+	  FROM performance_schema.events_statements_summary_by_account_by_event_name
+	  GROUP BY USER, HOST
+	  HAVING SUM(COUNT_STAR) > 0
+	  ORDER BY SUM(SUM_TIMER_WAIT) DESC
+	  LIMIT %d
+	`
+
+// Tunable flags.
+var perfEventsStatementsByAccountLimit = kingpin.Flag(
+	"collect.perf_schema.eventsstatementsbyaccount.limit",
+	"Limit the number of accounts, by statement response time, to collect statement summaries for",
+).Default("100").Int()
+
+// Metric descriptors.
+var (
+	performanceSchemaEventsStatementsByAccountDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "events_statements_by_account_total"),
+		"The total count of events statements by account.",
+		[]string{"user", "host"}, nil,
+	)
+	performanceSchemaEventsStatementsByAccountErrorsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "events_statements_by_account_errors_total"),
+		"The errors of events statements by account.",
+		[]string{"user", "host"}, nil,
+	)
+	performanceSchemaEventsStatementsByAccountWarningsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "events_statements_by_account_warnings_total"),
+		"The warnings of events statements by account.",
+		[]string{"user", "host"}, nil,
+	)
+	performanceSchemaEventsStatementsByAccountTimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "events_statements_by_account_seconds_total"),
+		"The total time of events statements by account.",
+		[]string{"user", "host"}, nil,
+	)
+)
+
+// ScrapePerfEventsStatementsByAccount collects from `performance_schema.events_statements_summary_by_account_by_event_name`.
+type ScrapePerfEventsStatementsByAccount struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapePerfEventsStatementsByAccount) Name() string {
+	return "perf_schema.eventsstatementsbyaccount"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapePerfEventsStatementsByAccount) Help() string {
+	return "Collect metrics from performance_schema.events_statements_summary_by_account_by_event_name"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapePerfEventsStatementsByAccount) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	// Timers here are returned in picoseconds.
+	perfSchemaEventsStatementsByAccountRows, err := db.Query(fmt.Sprintf(perfEventsStatementsByAccountQuery, *perfEventsStatementsByAccountLimit))
+	if err != nil {
+		return err
+	}
+	defer perfSchemaEventsStatementsByAccountRows.Close()
+
+	var (
+		user, host                         string
+		count, errors, warnings, queryTime uint64
+	)
+	for perfSchemaEventsStatementsByAccountRows.Next() {
+		if err := perfSchemaEventsStatementsByAccountRows.Scan(
+			&user, &host, &count, &errors, &warnings, &queryTime,
+		); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaEventsStatementsByAccountDesc, prometheus.CounterValue, float64(count),
+			user, host,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaEventsStatementsByAccountErrorsDesc, prometheus.CounterValue, float64(errors),
+			user, host,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaEventsStatementsByAccountWarningsDesc, prometheus.CounterValue, float64(warnings),
+			user, host,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaEventsStatementsByAccountTimeDesc, prometheus.CounterValue, float64(queryTime)/picoSeconds,
+			user, host,
+		)
+	}
+	return perfSchemaEventsStatementsByAccountRows.Err()
+}
@@ -0,0 +1,58 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapePerfEventsStatementsByAccount(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"USER", "HOST", "COUNT_STAR", "SUM_ERRORS", "SUM_WARNINGS", "SUM_TIMER_WAIT"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("tenant_a", "10.0.0.1", "120000", "35", "4", "92000000000000").
+		AddRow("", "", "800", "0", "0", "1500000000000")
+	query := fmt.Sprintf(perfEventsStatementsByAccountQuery, 100)
+	mock.ExpectQuery(sanitizeQuery(query)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapePerfEventsStatementsByAccount{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	tenant := labelMap{"user": "tenant_a", "host": "10.0.0.1"}
+	background := labelMap{"user": "", "host": ""}
+	metricExpected := []MetricResult{
+		{labels: tenant, value: 120000, metricType: dto.MetricType_COUNTER},
+		{labels: tenant, value: 35, metricType: dto.MetricType_COUNTER},
+		{labels: tenant, value: 4, metricType: dto.MetricType_COUNTER},
+		{labels: tenant, value: 92, metricType: dto.MetricType_COUNTER},
+		{labels: background, value: 800, metricType: dto.MetricType_COUNTER},
+		{labels: background, value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: background, value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: background, value: 1.5, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
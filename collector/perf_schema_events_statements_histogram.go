@@ -0,0 +1,296 @@
+// Scrape `performance_schema.events_statements_histogram_global` and
+// `performance_schema.events_statements_histogram_by_digest`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const (
+	perfEventsStatementsHistogramSumQuery = `
+	SELECT ifnull(SUM(SUM_TIMER_WAIT), 0)
+	  FROM performance_schema.events_statements_summary_global_by_event_name
+	`
+	perfEventsStatementsHistogramQuery = `
+	SELECT BUCKET_TIMER_HIGH, COUNT_BUCKET_AND_LOWER
+	  FROM performance_schema.events_statements_histogram_global
+	  WHERE COUNT_BUCKET > 0
+	  ORDER BY BUCKET_NUMBER
+	`
+	perfEventsStatementsHistogramByDigestQuery = `
+	SELECT
+	    ifnull(h.SCHEMA_NAME, 'NONE') as SCHEMA_NAME,
+	    h.DIGEST,
+	    d.SUM_TIMER_WAIT,
+	    h.BUCKET_TIMER_HIGH,
+	    h.COUNT_BUCKET_AND_LOWER
+	  FROM performance_schema.events_statements_histogram_by_digest h
+	  JOIN (
+	    SELECT SCHEMA_NAME, DIGEST, SUM_TIMER_WAIT
+	    FROM performance_schema.events_statements_summary_by_digest
+	    WHERE DIGEST IS NOT NULL
+	    ORDER BY COUNT_STAR DESC
+	    LIMIT %d
+	  ) d ON h.SCHEMA_NAME <=> d.SCHEMA_NAME AND h.DIGEST = d.DIGEST
+	  WHERE h.COUNT_BUCKET > 0
+	  ORDER BY h.SCHEMA_NAME, h.DIGEST, h.BUCKET_NUMBER
+	`
+)
+
+// Tunable flags.
+var (
+	perfEventsStatementsHistogramBuckets = kingpin.Flag(
+		"collect.perf_schema.eventsstatementshistogram.buckets",
+		"Comma separated list of histogram bucket upper bounds in seconds",
+	).Default("0.001,0.005,0.01,0.05,0.1,0.5,1,5,10,30").String()
+	perfEventsStatementsHistogramDigestLimit = kingpin.Flag(
+		"collect.perf_schema.eventsstatementshistogram.digest_limit",
+		"Limit the number of digests, by execution count, to collect latency histograms for. 0 disables per digest histograms",
+	).Default("20").Int()
+)
+
+// Metric descriptors.
+var (
+	performanceSchemaEventsStatementsLatencyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "events_statements_latency_seconds"),
+		"A histogram of the latency of all statements.",
+		nil, nil,
+	)
+	performanceSchemaEventsStatementsDigestLatencyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "events_statements_digest_latency_seconds"),
+		"A histogram of the latency of statements by digest.",
+		[]string{"schema", "digest"}, nil,
+	)
+)
+
+// ScrapePerfEventsStatementsHistogram collects from `performance_schema.events_statements_histogram_global`
+// and `performance_schema.events_statements_histogram_by_digest`.
+type ScrapePerfEventsStatementsHistogram struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapePerfEventsStatementsHistogram) Name() string {
+	return "perf_schema.eventsstatementshistogram"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapePerfEventsStatementsHistogram) Help() string {
+	return "Collect latency histograms from performance_schema.events_statements_histogram_global and _by_digest"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapePerfEventsStatementsHistogram) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	bounds, err := parseHistogramBuckets(*perfEventsStatementsHistogramBuckets)
+	if err != nil {
+		return err
+	}
+
+	// Timers here are returned in picoseconds.
+	var sum uint64
+	if err := db.QueryRow(perfEventsStatementsHistogramSumQuery).Scan(&sum); err != nil {
+		return err
+	}
+
+	histogramRows, err := db.Query(perfEventsStatementsHistogramQuery)
+	if err != nil {
+		return err
+	}
+	defer histogramRows.Close()
+
+	var timerHigh, countAndLower uint64
+	global := newLatencyHistogram(bounds)
+	for histogramRows.Next() {
+		if err := histogramRows.Scan(&timerHigh, &countAndLower); err != nil {
+			return err
+		}
+		global.observe(float64(timerHigh)/picoSeconds, countAndLower)
+	}
+	globalSum := float64(sum) / picoSeconds
+
+	if *perfEventsStatementsHistogramDigestLimit <= 0 {
+		ch <- global.metric(performanceSchemaEventsStatementsLatencyDesc, globalSum)
+		return nil
+	}
+	digestRows, err := db.Query(fmt.Sprintf(perfEventsStatementsHistogramByDigestQuery, *perfEventsStatementsHistogramDigestLimit))
+	if err != nil {
+		return err
+	}
+	defer digestRows.Close()
+
+	var (
+		schemaName, digest         string
+		lastSchemaName, lastDigest string
+		digestSum, lastDigestSum   uint64
+		histogram                  *latencyHistogram
+	)
+	for digestRows.Next() {
+		if err := digestRows.Scan(&schemaName, &digest, &digestSum, &timerHigh, &countAndLower); err != nil {
+			return err
+		}
+		if histogram == nil || schemaName != lastSchemaName || digest != lastDigest {
+			if histogram != nil {
+				ch <- histogram.metric(
+					performanceSchemaEventsStatementsDigestLatencyDesc, float64(lastDigestSum)/picoSeconds,
+					lastSchemaName, lastDigest,
+				)
+			}
+			histogram = newLatencyHistogram(bounds)
+			lastSchemaName, lastDigest = schemaName, digest
+		}
+		high := float64(timerHigh) / picoSeconds
+		global.offerExemplar(high, countAndLower-histogram.count, schemaName, digest)
+		histogram.observe(high, countAndLower)
+		lastDigestSum = digestSum
+	}
+	if histogram != nil {
+		ch <- histogram.metric(
+			performanceSchemaEventsStatementsDigestLatencyDesc, float64(lastDigestSum)/picoSeconds,
+			lastSchemaName, lastDigest,
+		)
+	}
+	ch <- global.metric(performanceSchemaEventsStatementsLatencyDesc, globalSum)
+	return nil
+}
+
+// parseHistogramBuckets parses a comma separated list of bucket upper bounds.
+func parseHistogramBuckets(s string) ([]float64, error) {
+	var bounds []float64
+	for _, b := range strings.Split(s, ",") {
+		bound, err := strconv.ParseFloat(strings.TrimSpace(b), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid histogram bucket %q: %s", b, err)
+		}
+		bounds = append(bounds, bound)
+	}
+	sort.Float64s(bounds)
+	return bounds, nil
+}
+
+// nativeHistogramSchema is the resolution of the native histograms. Their
+// buckets grow by a factor of 2^(1/8), a little coarser than the
+// performance_schema buckets growing by 10^(1/50), so that every
+// performance_schema bucket falls into a single native bucket.
+const nativeHistogramSchema = 3
+
+// latencyHistogram resamples the performance_schema buckets of a statement
+// latency histogram into both the configured classic buckets and native
+// histogram buckets.
+type latencyHistogram struct {
+	buckets   map[float64]uint64
+	native    map[int]int64
+	count     uint64
+	exemplars map[float64]digestExemplar
+}
+
+// digestExemplar is the digest with the most executions in a classic bucket.
+type digestExemplar struct {
+	count  uint64
+	value  float64
+	labels prometheus.Labels
+}
+
+func newLatencyHistogram(bounds []float64) *latencyHistogram {
+	buckets := make(map[float64]uint64, len(bounds))
+	for _, bound := range bounds {
+		buckets[bound] = 0
+	}
+	return &latencyHistogram{
+		buckets:   buckets,
+		native:    map[int]int64{},
+		exemplars: map[float64]digestExemplar{},
+	}
+}
+
+// observe adds a performance_schema bucket with the given upper bound in
+// seconds. performance_schema buckets must be observed in ascending order,
+// as their counts are already cumulative.
+func (h *latencyHistogram) observe(high float64, countAndLower uint64) {
+	for bound := range h.buckets {
+		if high <= bound {
+			h.buckets[bound] = countAndLower
+		}
+	}
+	// A native bucket with index i covers (2^((i-1)/2^schema), 2^(i/2^schema)].
+	index := int(math.Ceil(math.Log2(high) * (1 << nativeHistogramSchema)))
+	h.native[index] += int64(countAndLower - h.count)
+	h.count = countAndLower
+}
+
+// offerExemplar records that a digest ran count times with a latency in the
+// performance_schema bucket with the given upper bound in seconds. The digest
+// with the most executions in a classic bucket becomes its exemplar.
+func (h *latencyHistogram) offerExemplar(high float64, count uint64, schemaName, digest string) {
+	if count == 0 {
+		return
+	}
+	bound := math.Inf(1)
+	for b := range h.buckets {
+		if high <= b && b < bound {
+			bound = b
+		}
+	}
+	if e, ok := h.exemplars[bound]; ok && e.count >= count {
+		return
+	}
+	labels := prometheus.Labels{"schema": schemaName, "digest": digest}
+	if utf8.RuneCountInString("schemadigest"+schemaName+digest) > prometheus.ExemplarMaxRunes {
+		// Exemplar labels are limited in length, the digest alone
+		// still identifies the statement.
+		labels = prometheus.Labels{"digest": digest}
+	}
+	h.exemplars[bound] = digestExemplar{count: count, value: high, labels: labels}
+}
+
+// metric returns the histogram with both its classic and its native buckets.
+// Prometheus ingests the native buckets when scraping the protobuf format
+// with native histograms enabled, and the classic ones otherwise.
+func (h *latencyHistogram) metric(desc *prometheus.Desc, sum float64, labelValues ...string) prometheus.Metric {
+	var m prometheus.Metric = nativeAndClassicHistogram{
+		Metric: prometheus.MustNewConstHistogram(desc, h.count, sum, h.buckets, labelValues...),
+		native: prometheus.MustNewConstNativeHistogram(
+			desc, h.count, sum, h.native, nil, 0, nativeHistogramSchema, 0, time.Time{}, labelValues...,
+		),
+	}
+	if len(h.exemplars) == 0 {
+		return m
+	}
+	exemplars := make([]prometheus.Exemplar, 0, len(h.exemplars))
+	for _, e := range h.exemplars {
+		exemplars = append(exemplars, prometheus.Exemplar{Value: e.value, Labels: e.labels})
+	}
+	return prometheus.MustNewMetricWithExemplars(m, exemplars...)
+}
+
+// nativeAndClassicHistogram adds the buckets of a constant native histogram
+// to a constant classic histogram.
+type nativeAndClassicHistogram struct {
+	prometheus.Metric
+	native prometheus.Metric
+}
+
+func (h nativeAndClassicHistogram) Write(out *dto.Metric) error {
+	native := &dto.Metric{}
+	if err := h.native.Write(native); err != nil {
+		return err
+	}
+	if err := h.Metric.Write(out); err != nil {
+		return err
+	}
+	out.Histogram.Schema = native.Histogram.Schema
+	out.Histogram.ZeroThreshold = native.Histogram.ZeroThreshold
+	out.Histogram.ZeroCount = native.Histogram.ZeroCount
+	out.Histogram.PositiveSpan = native.Histogram.PositiveSpan
+	out.Histogram.PositiveDelta = native.Histogram.PositiveDelta
+	return nil
+}
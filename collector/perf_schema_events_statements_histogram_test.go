@@ -0,0 +1,145 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func readHistogram(m prometheus.Metric) (labelMap, uint64, float64, map[float64]uint64) {
+	pb := &dto.Metric{}
+	m.Write(pb)
+	labels := make(labelMap, len(pb.Label))
+	for _, v := range pb.Label {
+		labels[v.GetName()] = v.GetValue()
+	}
+	buckets := map[float64]uint64{}
+	for _, b := range pb.GetHistogram().GetBucket() {
+		buckets[b.GetUpperBound()] = b.GetCumulativeCount()
+	}
+	return labels, pb.GetHistogram().GetSampleCount(), pb.GetHistogram().GetSampleSum(), buckets
+}
+
+type exemplarResult struct {
+	labels labelMap
+	value  float64
+}
+
+// readExemplars returns the exemplars of a histogram keyed by the upper bound
+// of their bucket.
+func readExemplars(m prometheus.Metric) map[float64]exemplarResult {
+	pb := &dto.Metric{}
+	m.Write(pb)
+	exemplars := map[float64]exemplarResult{}
+	for _, b := range pb.GetHistogram().GetBucket() {
+		if b.GetExemplar() == nil {
+			continue
+		}
+		labels := labelMap{}
+		for _, l := range b.GetExemplar().GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		exemplars[b.GetUpperBound()] = exemplarResult{labels: labels, value: b.GetExemplar().GetValue()}
+	}
+	return exemplars
+}
+
+// readNativeHistogram returns the schema and the absolute counts of the
+// positive native buckets of a histogram, keyed by bucket index.
+func readNativeHistogram(m prometheus.Metric) (int32, map[int]int64) {
+	pb := &dto.Metric{}
+	m.Write(pb)
+	buckets := map[int]int64{}
+	var index int
+	var count int64
+	deltas := pb.GetHistogram().GetPositiveDelta()
+	for _, span := range pb.GetHistogram().GetPositiveSpan() {
+		index += int(span.GetOffset())
+		for i := uint32(0); i < span.GetLength(); i++ {
+			count += deltas[0]
+			deltas = deltas[1:]
+			buckets[index] = count
+			index++
+		}
+	}
+	return pb.GetHistogram().GetSchema(), buckets
+}
+
+func TestScrapePerfEventsStatementsHistogram(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(perfEventsStatementsHistogramSumQuery)).WillReturnRows(sqlmock.NewRows([]string{"SUM"}).AddRow("3000000000000"))
+
+	columns := []string{"BUCKET_TIMER_HIGH", "COUNT_BUCKET_AND_LOWER"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("10000000", "5").
+		AddRow("2000000000", "8").
+		AddRow("60000000000000", "9")
+	mock.ExpectQuery(sanitizeQuery(perfEventsStatementsHistogramQuery)).WillReturnRows(rows)
+
+	digestColumns := []string{"SCHEMA_NAME", "DIGEST", "SUM_TIMER_WAIT", "BUCKET_TIMER_HIGH", "COUNT_BUCKET_AND_LOWER"}
+	digestRows := sqlmock.NewRows(digestColumns).
+		AddRow("db1", "abc", "1000000000", "10000000", "4").
+		AddRow("db1", "abc", "1000000000", "20000000", "5").
+		AddRow("db2", "def", "40000000000", "20000000000", "2")
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfEventsStatementsHistogramByDigestQuery, 20))).WillReturnRows(digestRows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapePerfEventsStatementsHistogram{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("Metrics comparison", t, func() {
+		m := <-ch
+		labels, count, sum, buckets := readHistogram(m)
+		convey.So(labels, convey.ShouldResemble, labelMap{"schema": "db1", "digest": "abc"})
+		convey.So(count, convey.ShouldEqual, 5)
+		convey.So(sum, convey.ShouldEqual, 0.001)
+		convey.So(buckets[0.001], convey.ShouldEqual, 5)
+		_, native := readNativeHistogram(m)
+		convey.So(native, convey.ShouldResemble, map[int]int64{-132: 4, -124: 1})
+
+		m = <-ch
+		labels, count, _, buckets = readHistogram(m)
+		convey.So(labels, convey.ShouldResemble, labelMap{"schema": "db2", "digest": "def"})
+		convey.So(count, convey.ShouldEqual, 2)
+		convey.So(buckets[0.01], convey.ShouldEqual, 0)
+		convey.So(buckets[0.05], convey.ShouldEqual, 2)
+		_, native = readNativeHistogram(m)
+		convey.So(native, convey.ShouldResemble, map[int]int64{-45: 2})
+
+		m = <-ch
+		labels, count, sum, buckets = readHistogram(m)
+		convey.So(labels, convey.ShouldResemble, labelMap{})
+		convey.So(count, convey.ShouldEqual, 9)
+		convey.So(sum, convey.ShouldEqual, 3)
+		convey.So(buckets, convey.ShouldResemble, map[float64]uint64{
+			0.001: 5, 0.005: 8, 0.01: 8, 0.05: 8, 0.1: 8, 0.5: 8, 1: 8, 5: 8, 10: 8, 30: 8,
+		})
+		schema, native := readNativeHistogram(m)
+		convey.So(schema, convey.ShouldEqual, 3)
+		// 10us, 2ms and 60s fall into the native buckets -132, -71 and 48.
+		convey.So(native, convey.ShouldResemble, map[int]int64{-132: 5, -71: 3, 48: 1})
+		// The digest with the most executions in a bucket is its exemplar.
+		convey.So(readExemplars(m), convey.ShouldResemble, map[float64]exemplarResult{
+			0.001: {labels: labelMap{"schema": "db1", "digest": "abc"}, value: 0.00001},
+			0.05:  {labels: labelMap{"schema": "db2", "digest": "def"}, value: 0.02},
+		})
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,278 @@
+// Scrape `performance_schema.events_statements_history_long`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const (
+	perfEventsStatementsSampleQuery = `
+	SELECT
+	    ifnull(CURRENT_SCHEMA, 'NONE') as CURRENT_SCHEMA,
+	    DIGEST,
+	    LEFT(ifnull(DIGEST_TEXT, ''), %d) as DIGEST_TEXT,
+	    TIMER_WAIT,
+	    TIMER_END,
+	    ROWS_EXAMINED
+	  FROM performance_schema.events_statements_history_long
+	  WHERE DIGEST IS NOT NULL
+	    AND TIMER_WAIT IS NOT NULL
+	  ORDER BY TIMER_END DESC
+	  LIMIT %d
+	`
+	perfEventsStatementsSampleServerQuery = `SELECT @@hostname, @@port`
+)
+
+// Tunable flags.
+var (
+	perfEventsStatementsSampleLimit = kingpin.Flag(
+		"collect.perf_schema.eventsstatementssample.limit",
+		"Maximum number of recent statements sampled from events_statements_history_long",
+	).Default("100").Int()
+	perfEventsStatementsSampleDigestTextLimit = kingpin.Flag(
+		"collect.perf_schema.eventsstatementssample.digest_text_limit",
+		"Maximum length of the normalized statement text kept in the raw sample",
+	).Default("120").Int()
+	perfEventsStatementsSampleMaxDigests = kingpin.Flag(
+		"collect.perf_schema.eventsstatementssample.max_digests",
+		"Maximum number of digests with a latency histogram, statements of further digests are counted as 'other'",
+	).Default("1000").Int()
+	perfEventsStatementsSampleExpiryScrapes = kingpin.Flag(
+		"collect.perf_schema.eventsstatementssample.expiry_scrapes",
+		"Number of scrapes without new statements after which the latency histogram of a digest is dropped",
+	).Default("60").Int()
+)
+
+// Latency buckets, in seconds, for sampled statements.
+var perfEventsStatementsSampleBuckets = []float64{0.001, 0.01, 0.1, 1, 10}
+
+// Metric descriptors.
+var (
+	performanceSchemaEventsStatementsSampleLatencyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "events_statements_sample_seconds"),
+		"The latency distribution of the statements sampled since the exporter started, by digest.",
+		[]string{"schema", "digest"}, nil,
+	)
+	performanceSchemaEventsStatementsSampleRowsExaminedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "events_statements_sample_rows_examined"),
+		"The number of rows examined by recently sampled statements by digest.",
+		[]string{"schema", "digest"}, nil,
+	)
+)
+
+// StatementSample is a single statement taken from `performance_schema.events_statements_history_long`.
+type StatementSample struct {
+	Schema       string  `json:"schema"`
+	Digest       string  `json:"digest"`
+	DigestText   string  `json:"digest_text"`
+	Seconds      float64 `json:"seconds"`
+	RowsExamined uint64  `json:"rows_examined"`
+}
+
+// StatementSampleStatus is the most recent statement sample.
+type StatementSampleStatus struct {
+	Time    time.Time         `json:"time"`
+	Samples []StatementSample `json:"samples"`
+}
+
+// statementSampleState accumulates the latency histograms of the statements
+// sampled from a server, so that their buckets never go down.
+type statementSampleState struct {
+	// timerEnd is the TIMER_END of the newest statement counted, older
+	// statements still in the history were counted by a previous scrape.
+	timerEnd   uint64
+	scrapes    int
+	keys       [][2]string
+	histograms map[[2]string]*statementSampleHistogram
+	last       StatementSampleStatus
+}
+
+type statementSampleHistogram struct {
+	count   uint64
+	sum     float64
+	buckets map[float64]uint64
+	// lastScrape is the number of the last scrape with new statements.
+	lastScrape int
+}
+
+// statementSampleRow is a statement read from the history.
+type statementSampleRow struct {
+	sample   StatementSample
+	timerEnd uint64
+}
+
+// statementSamples holds the state of every server. The mutex is only held
+// while the state is read or updated, not while querying.
+var statementSamples = struct {
+	sync.Mutex
+	byServer map[string]*statementSampleState
+}{byServer: map[string]*statementSampleState{}}
+
+// LastStatementSamples returns the statements sampled by the most recent
+// scrape of each server, by host:port.
+func LastStatementSamples() map[string]StatementSampleStatus {
+	statementSamples.Lock()
+	defer statementSamples.Unlock()
+	samples := make(map[string]StatementSampleStatus, len(statementSamples.byServer))
+	for server, state := range statementSamples.byServer {
+		samples[server] = state.last
+	}
+	return samples
+}
+
+// ScrapePerfEventsStatementsSample collects from `performance_schema.events_statements_history_long`.
+type ScrapePerfEventsStatementsSample struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapePerfEventsStatementsSample) Name() string {
+	return "perf_schema.eventsstatementssample"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapePerfEventsStatementsSample) Help() string {
+	return "Collect a sample of recent statements from performance_schema.events_statements_history_long"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapePerfEventsStatementsSample) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var host, port string
+	if err := db.QueryRow(perfEventsStatementsSampleServerQuery).Scan(&host, &port); err != nil {
+		return err
+	}
+	server := host + ":" + port
+
+	perfQuery := fmt.Sprintf(
+		perfEventsStatementsSampleQuery,
+		*perfEventsStatementsSampleDigestTextLimit,
+		*perfEventsStatementsSampleLimit,
+	)
+	// Timers here are returned in picoseconds.
+	perfSchemaEventsStatementsSampleRows, err := db.Query(perfQuery)
+	if err != nil {
+		return err
+	}
+	defer perfSchemaEventsStatementsSampleRows.Close()
+
+	var (
+		rows                 []statementSampleRow
+		timerWait            uint64
+		rowsExaminedByDigest = map[[2]string]uint64{}
+		sampledKeys          [][2]string
+	)
+	for perfSchemaEventsStatementsSampleRows.Next() {
+		var row statementSampleRow
+		if err := perfSchemaEventsStatementsSampleRows.Scan(
+			&row.sample.Schema, &row.sample.Digest, &row.sample.DigestText, &timerWait, &row.timerEnd, &row.sample.RowsExamined,
+		); err != nil {
+			return err
+		}
+		row.sample.Seconds = float64(timerWait) / picoSeconds
+		rows = append(rows, row)
+
+		key := [2]string{row.sample.Schema, row.sample.Digest}
+		if _, ok := rowsExaminedByDigest[key]; !ok {
+			sampledKeys = append(sampledKeys, key)
+		}
+		rowsExaminedByDigest[key] += row.sample.RowsExamined
+	}
+	if err := perfSchemaEventsStatementsSampleRows.Err(); err != nil {
+		return err
+	}
+
+	for _, metric := range updateStatementSampleState(server, rows, time.Now()) {
+		ch <- metric
+	}
+	for _, key := range sampledKeys {
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaEventsStatementsSampleRowsExaminedDesc, prometheus.GaugeValue, float64(rowsExaminedByDigest[key]),
+			key[0], key[1],
+		)
+	}
+	return nil
+}
+
+// updateStatementSampleState adds the statements sampled from the server to
+// its latency histograms and returns the histograms. Statements already
+// counted by a previous scrape are skipped.
+func updateStatementSampleState(server string, rows []statementSampleRow, now time.Time) []prometheus.Metric {
+	statementSamples.Lock()
+	defer statementSamples.Unlock()
+
+	state, ok := statementSamples.byServer[server]
+	if !ok {
+		state = &statementSampleState{histograms: map[[2]string]*statementSampleHistogram{}}
+		statementSamples.byServer[server] = state
+	}
+	state.scrapes++
+
+	// Drop the digests without new statements, making room for new ones
+	// below the limit.
+	keys := state.keys[:0]
+	for _, key := range state.keys {
+		if state.scrapes-state.histograms[key].lastScrape > *perfEventsStatementsSampleExpiryScrapes {
+			delete(state.histograms, key)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	state.keys = keys
+
+	samples := make([]StatementSample, 0, len(rows))
+	for i, row := range rows {
+		// The newest statement comes first. Timers restart with the
+		// server, so an older one means everything is new again.
+		if i == 0 && row.timerEnd < state.timerEnd {
+			state.timerEnd = 0
+		}
+		samples = append(samples, row.sample)
+		if row.timerEnd <= state.timerEnd {
+			continue
+		}
+
+		key := [2]string{row.sample.Schema, row.sample.Digest}
+		histogram, ok := state.histograms[key]
+		if !ok {
+			if len(state.keys) >= *perfEventsStatementsSampleMaxDigests {
+				key = [2]string{"other", "other"}
+				histogram, ok = state.histograms[key]
+			}
+			if !ok {
+				histogram = &statementSampleHistogram{buckets: make(map[float64]uint64, len(perfEventsStatementsSampleBuckets))}
+				for _, bucket := range perfEventsStatementsSampleBuckets {
+					histogram.buckets[bucket] = 0
+				}
+				state.histograms[key] = histogram
+				state.keys = append(state.keys, key)
+			}
+		}
+		histogram.lastScrape = state.scrapes
+		histogram.count++
+		histogram.sum += row.sample.Seconds
+		for _, bucket := range perfEventsStatementsSampleBuckets {
+			if row.sample.Seconds <= bucket {
+				histogram.buckets[bucket]++
+			}
+		}
+	}
+	if len(rows) > 0 {
+		state.timerEnd = rows[0].timerEnd
+	}
+	state.last = StatementSampleStatus{Time: now, Samples: samples}
+
+	metrics := make([]prometheus.Metric, 0, len(state.keys))
+	for _, key := range state.keys {
+		histogram := state.histograms[key]
+		metrics = append(metrics, prometheus.MustNewConstHistogram(
+			performanceSchemaEventsStatementsSampleLatencyDesc, histogram.count, histogram.sum, histogram.buckets,
+			key[0], key[1],
+		))
+	}
+	return metrics
+}
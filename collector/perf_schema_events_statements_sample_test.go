@@ -0,0 +1,137 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapePerfEventsStatementsSample(t *testing.T) {
+	*perfEventsStatementsSampleMaxDigests = 1000
+	*perfEventsStatementsSampleExpiryScrapes = 60
+	statementSamples.byServer = map[string]*statementSampleState{}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	serverColumns := []string{"@@hostname", "@@port"}
+	columns := []string{"CURRENT_SCHEMA", "DIGEST", "DIGEST_TEXT", "TIMER_WAIT", "TIMER_END", "ROWS_EXAMINED"}
+	query := fmt.Sprintf(perfEventsStatementsSampleQuery, 120, 100)
+	mock.ExpectQuery(sanitizeQuery(perfEventsStatementsSampleServerQuery)).WillReturnRows(sqlmock.NewRows(serverColumns).AddRow("db1", "3306"))
+	rows := sqlmock.NewRows(columns).
+		AddRow("db1", "abc", "SELECT ? FROM t1", "5000000000", "3000", "10").
+		AddRow("db1", "abc", "SELECT ? FROM t1", "2000000000000", "2000", "30").
+		AddRow("db2", "def", "UPDATE t2 SET c = ?", "50000000", "1000", "1")
+	mock.ExpectQuery(sanitizeQuery(query)).WillReturnRows(rows)
+	// The next scrape sees one new statement, the others were counted already.
+	mock.ExpectQuery(sanitizeQuery(perfEventsStatementsSampleServerQuery)).WillReturnRows(sqlmock.NewRows(serverColumns).AddRow("db1", "3306"))
+	rows = sqlmock.NewRows(columns).
+		AddRow("db1", "abc", "SELECT ? FROM t1", "500000000", "4000", "5").
+		AddRow("db1", "abc", "SELECT ? FROM t1", "5000000000", "3000", "10").
+		AddRow("db1", "abc", "SELECT ? FROM t1", "2000000000000", "2000", "30")
+	mock.ExpectQuery(sanitizeQuery(query)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		for i := 0; i < 2; i++ {
+			if err = (ScrapePerfEventsStatementsSample{}).Scrape(db, ch); err != nil {
+				t.Errorf("error calling function on test: %s", err)
+			}
+		}
+		close(ch)
+	}()
+
+	readHistogram := func() (uint64, float64, map[float64]uint64) {
+		pb := &dto.Metric{}
+		(<-ch).Write(pb)
+		buckets := map[float64]uint64{}
+		for _, b := range pb.GetHistogram().GetBucket() {
+			buckets[b.GetUpperBound()] = b.GetCumulativeCount()
+		}
+		return pb.GetHistogram().GetSampleCount(), pb.GetHistogram().GetSampleSum(), buckets
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		count, sum, buckets := readHistogram()
+		convey.So(count, convey.ShouldEqual, 2)
+		convey.So(sum, convey.ShouldAlmostEqual, 2.005)
+		convey.So(buckets, convey.ShouldResemble, map[float64]uint64{0.001: 0, 0.01: 1, 0.1: 1, 1: 1, 10: 2})
+
+		count, _, _ = readHistogram()
+		convey.So(count, convey.ShouldEqual, 1)
+
+		got := readMetric(<-ch)
+		convey.So(got, convey.ShouldResemble, MetricResult{labels: labelMap{"schema": "db1", "digest": "abc"}, value: 40, metricType: dto.MetricType_GAUGE})
+		got = readMetric(<-ch)
+		convey.So(got, convey.ShouldResemble, MetricResult{labels: labelMap{"schema": "db2", "digest": "def"}, value: 1, metricType: dto.MetricType_GAUGE})
+
+		count, sum, buckets = readHistogram()
+		convey.So(count, convey.ShouldEqual, 3)
+		convey.So(sum, convey.ShouldAlmostEqual, 2.0055)
+		convey.So(buckets, convey.ShouldResemble, map[float64]uint64{0.001: 1, 0.01: 2, 0.1: 2, 1: 2, 10: 3})
+
+		count, _, _ = readHistogram()
+		convey.So(count, convey.ShouldEqual, 1)
+
+		got = readMetric(<-ch)
+		convey.So(got, convey.ShouldResemble, MetricResult{labels: labelMap{"schema": "db1", "digest": "abc"}, value: 45, metricType: dto.MetricType_GAUGE})
+	})
+
+	convey.Convey("Raw sample", t, func() {
+		samples := LastStatementSamples()["db1:3306"].Samples
+		convey.So(len(samples), convey.ShouldEqual, 3)
+		convey.So(samples[2], convey.ShouldResemble, StatementSample{
+			Schema: "db1", Digest: "abc", DigestText: "SELECT ? FROM t1", Seconds: 2, RowsExamined: 30,
+		})
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestUpdateStatementSampleState(t *testing.T) {
+	*perfEventsStatementsSampleMaxDigests = 2
+	*perfEventsStatementsSampleExpiryScrapes = 2
+	defer func() {
+		*perfEventsStatementsSampleMaxDigests = 1000
+		*perfEventsStatementsSampleExpiryScrapes = 60
+	}()
+	now := time.Now()
+	statementSamples.byServer = map[string]*statementSampleState{}
+
+	row := func(digest string, timerEnd uint64) statementSampleRow {
+		return statementSampleRow{sample: StatementSample{Schema: "db1", Digest: digest, Seconds: 1}, timerEnd: timerEnd}
+	}
+	digests := func(metrics []prometheus.Metric) []string {
+		var digests []string
+		for _, m := range metrics {
+			pb := &dto.Metric{}
+			m.Write(pb)
+			for _, l := range pb.GetLabel() {
+				if l.GetName() == "digest" {
+					digests = append(digests, l.GetValue())
+				}
+			}
+		}
+		return digests
+	}
+	convey.Convey("Statement sample state", t, func() {
+		metrics := updateStatementSampleState("db1:3306", []statementSampleRow{row("c", 3), row("b", 2), row("a", 1)}, now)
+		convey.So(digests(metrics), convey.ShouldResemble, []string{"c", "b", "other"})
+
+		updateStatementSampleState("db1:3306", []statementSampleRow{row("b", 4)}, now)
+		updateStatementSampleState("db1:3306", []statementSampleRow{row("b", 5)}, now)
+		metrics = updateStatementSampleState("db1:3306", []statementSampleRow{row("d", 6)}, now)
+		convey.So(digests(metrics), convey.ShouldResemble, []string{"b", "d"})
+	})
+}
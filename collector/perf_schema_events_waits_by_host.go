@@ -0,0 +1,84 @@
+// Scrape `performance_schema.events_waits_summary_by_host_by_event_name`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const perfEventsWaitsByHostQuery = `
+	SELECT ifnull(HOST, '') as HOST, EVENT_NAME, COUNT_STAR, SUM_TIMER_WAIT
+	  FROM performance_schema.events_waits_summary_by_host_by_event_name
+	  WHERE COUNT_STAR > 0 AND EVENT_NAME != 'idle'
+	  ORDER BY SUM_TIMER_WAIT DESC
+	  LIMIT %d
+	`
+
+// Tunable flags.
+var perfEventsWaitsByHostLimit = kingpin.Flag(
+	"collect.perf_schema.eventswaitsbyhost.limit",
+	"Limit the number of host and event name pairs, by wait time, to collect",
+).Default("250").Int()
+
+// Metric descriptors.
+var (
+	performanceSchemaEventsWaitsByHostDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "events_waits_by_host_total"),
+		"The total events waits by host and event name.",
+		[]string{"host", "event_name"}, nil,
+	)
+	performanceSchemaEventsWaitsByHostTimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "events_waits_by_host_seconds_total"),
+		"The total seconds of events waits by host and event name.",
+		[]string{"host", "event_name"}, nil,
+	)
+)
+
+// ScrapePerfEventsWaitsByHost collects from `performance_schema.events_waits_summary_by_host_by_event_name`.
+type ScrapePerfEventsWaitsByHost struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapePerfEventsWaitsByHost) Name() string {
+	return "perf_schema.eventswaitsbyhost"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapePerfEventsWaitsByHost) Help() string {
+	return "Collect metrics from performance_schema.events_waits_summary_by_host_by_event_name"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapePerfEventsWaitsByHost) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	// Timers here are returned in picoseconds.
+	perfSchemaEventsWaitsByHostRows, err := db.Query(fmt.Sprintf(perfEventsWaitsByHostQuery, *perfEventsWaitsByHostLimit))
+	if err != nil {
+		return err
+	}
+	defer perfSchemaEventsWaitsByHostRows.Close()
+
+	var (
+		host, eventName string
+		count, time     uint64
+	)
+
+	for perfSchemaEventsWaitsByHostRows.Next() {
+		if err := perfSchemaEventsWaitsByHostRows.Scan(
+			&host, &eventName, &count, &time,
+		); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaEventsWaitsByHostDesc, prometheus.CounterValue, float64(count),
+			host, eventName,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaEventsWaitsByHostTimeDesc, prometheus.CounterValue, float64(time)/picoSeconds,
+			host, eventName,
+		)
+	}
+	return perfSchemaEventsWaitsByHostRows.Err()
+}
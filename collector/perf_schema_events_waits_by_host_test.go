@@ -0,0 +1,52 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapePerfEventsWaitsByHost(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"HOST", "EVENT_NAME", "COUNT_STAR", "SUM_TIMER_WAIT"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("app-1", "wait/io/table/sql/handler", "52000", "4200000000000").
+		AddRow("", "wait/io/file/innodb/innodb_log_file", "900", "250000000000")
+	query := fmt.Sprintf(perfEventsWaitsByHostQuery, 250)
+	mock.ExpectQuery(sanitizeQuery(query)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapePerfEventsWaitsByHost{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"host": "app-1", "event_name": "wait/io/table/sql/handler"}, value: 52000, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"host": "app-1", "event_name": "wait/io/table/sql/handler"}, value: 4.2, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"host": "", "event_name": "wait/io/file/innodb/innodb_log_file"}, value: 900, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"host": "", "event_name": "wait/io/file/innodb/innodb_log_file"}, value: 0.25, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -4,6 +4,7 @@ package collector
 
 import (
 	"database/sql"
+	"regexp"
 	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -25,6 +26,16 @@ var (
 		"collect.perf_schema.file_instances.filter",
 		"RegEx file_name filter for performance_schema.file_summary_by_instance",
 	).Default(".*").String()
+	performanceSchemaFileInstancesExclude = kingpin.Flag(
+		"collect.perf_schema.file_instances.exclude",
+		"RegEx of file_name to exclude from performance_schema.file_summary_by_instance",
+	).Default("").String()
+)
+
+// Classification of the file instances.
+var (
+	fileInstanceUndoRE = regexp.MustCompile(`(^|/)(undo_?\d+|[^/]*\.ibu)$`)
+	fileInstanceTempRE = regexp.MustCompile(`(^|/)(ibtmp\d*|#innodb_temp/.*|#sql[^/]*)$`)
 )
 
 // Metric descriptors.
@@ -37,12 +48,12 @@ var (
 	performanceSchemaFileInstancesBytesDesc = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, performanceSchema, "file_instances_bytes"),
 		"The number of bytes processed by file read/write operations.",
-		[]string{"file_name", "event_name", "mode"}, nil,
+		[]string{"file_name", "event_name", "mode", "file_type"}, nil,
 	)
 	performanceSchemaFileInstancesCountDesc = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, performanceSchema, "file_instances_total"),
 		"The total number of file read/write operations.",
-		[]string{"file_name", "event_name", "mode"}, nil,
+		[]string{"file_name", "event_name", "mode", "file_type"}, nil,
 	)
 )
 
@@ -61,6 +72,14 @@ func (ScrapePerfFileInstances) Help() string {
 
 // Scrape collects data from database connection and sends it over channel as prometheus metric.
 func (ScrapePerfFileInstances) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var exclude *regexp.Regexp
+	if *performanceSchemaFileInstancesExclude != "" {
+		var err error
+		if exclude, err = regexp.Compile(*performanceSchemaFileInstancesExclude); err != nil {
+			return err
+		}
+	}
+
 	// Timers here are returned in picoseconds.
 	perfSchemaFileInstancesRows, err := db.Query(perfFileInstancesQuery, *performanceSchemaFileInstancesFilter)
 	if err != nil {
@@ -82,25 +101,50 @@ func (ScrapePerfFileInstances) Scrape(db *sql.DB, ch chan<- prometheus.Metric) e
 		); err != nil {
 			return err
 		}
+		if exclude != nil && exclude.MatchString(fileName) {
+			continue
+		}
 
+		fileType := fileInstanceType(fileName, eventName)
 		fileName = strings.TrimPrefix(fileName, *performanceSchemaFileInstancesRemovePrefix)
 		ch <- prometheus.MustNewConstMetric(
 			performanceSchemaFileInstancesCountDesc, prometheus.CounterValue, float64(countRead),
-			fileName, eventName, "read",
+			fileName, eventName, "read", fileType,
 		)
 		ch <- prometheus.MustNewConstMetric(
 			performanceSchemaFileInstancesCountDesc, prometheus.CounterValue, float64(countWrite),
-			fileName, eventName, "write",
+			fileName, eventName, "write", fileType,
 		)
 		ch <- prometheus.MustNewConstMetric(
 			performanceSchemaFileInstancesBytesDesc, prometheus.CounterValue, float64(sumBytesRead),
-			fileName, eventName, "read",
+			fileName, eventName, "read", fileType,
 		)
 		ch <- prometheus.MustNewConstMetric(
 			performanceSchemaFileInstancesBytesDesc, prometheus.CounterValue, float64(sumBytesWritten),
-			fileName, eventName, "write",
+			fileName, eventName, "write", fileType,
 		)
+	}
+	return perfSchemaFileInstancesRows.Err()
+}
 
+// fileInstanceType classifies a file instance as a datafile, binlog,
+// relaylog, redo, undo or temp file, or other.
+func fileInstanceType(fileName, eventName string) string {
+	switch {
+	case strings.HasPrefix(eventName, "wait/io/file/sql/binlog"):
+		return "binlog"
+	case strings.HasPrefix(eventName, "wait/io/file/sql/relaylog"):
+		return "relaylog"
+	case strings.HasPrefix(eventName, "wait/io/file/innodb/innodb_log_file"):
+		return "redo"
+	case fileInstanceUndoRE.MatchString(fileName):
+		return "undo"
+	case eventName == "wait/io/file/innodb/innodb_temp_file" || fileInstanceTempRE.MatchString(fileName):
+		return "temp"
+	case eventName == "wait/io/file/innodb/innodb_data_file",
+		eventName == "wait/io/file/myisam/dfile",
+		eventName == "wait/io/file/myisam/kfile":
+		return "datafile"
 	}
-	return nil
+	return "other"
 }
@@ -40,18 +40,18 @@ func TestScrapePerfFileInstances(t *testing.T) {
 	}()
 
 	metricExpected := []MetricResult{
-		{labels: labelMap{"file_name": "db1/file", "event_name": "event1", "mode": "read"}, value: 3, metricType: dto.MetricType_COUNTER},
-		{labels: labelMap{"file_name": "db1/file", "event_name": "event1", "mode": "write"}, value: 4, metricType: dto.MetricType_COUNTER},
-		{labels: labelMap{"file_name": "db1/file", "event_name": "event1", "mode": "read"}, value: 725, metricType: dto.MetricType_COUNTER},
-		{labels: labelMap{"file_name": "db1/file", "event_name": "event1", "mode": "write"}, value: 128, metricType: dto.MetricType_COUNTER},
-		{labels: labelMap{"file_name": "db2/file", "event_name": "event2", "mode": "read"}, value: 23, metricType: dto.MetricType_COUNTER},
-		{labels: labelMap{"file_name": "db2/file", "event_name": "event2", "mode": "write"}, value: 12, metricType: dto.MetricType_COUNTER},
-		{labels: labelMap{"file_name": "db2/file", "event_name": "event2", "mode": "read"}, value: 3123, metricType: dto.MetricType_COUNTER},
-		{labels: labelMap{"file_name": "db2/file", "event_name": "event2", "mode": "write"}, value: 967, metricType: dto.MetricType_COUNTER},
-		{labels: labelMap{"file_name": "db3/file", "event_name": "event3", "mode": "read"}, value: 45, metricType: dto.MetricType_COUNTER},
-		{labels: labelMap{"file_name": "db3/file", "event_name": "event3", "mode": "write"}, value: 32, metricType: dto.MetricType_COUNTER},
-		{labels: labelMap{"file_name": "db3/file", "event_name": "event3", "mode": "read"}, value: 1337, metricType: dto.MetricType_COUNTER},
-		{labels: labelMap{"file_name": "db3/file", "event_name": "event3", "mode": "write"}, value: 326, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"file_name": "db1/file", "event_name": "event1", "mode": "read", "file_type": "other"}, value: 3, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"file_name": "db1/file", "event_name": "event1", "mode": "write", "file_type": "other"}, value: 4, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"file_name": "db1/file", "event_name": "event1", "mode": "read", "file_type": "other"}, value: 725, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"file_name": "db1/file", "event_name": "event1", "mode": "write", "file_type": "other"}, value: 128, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"file_name": "db2/file", "event_name": "event2", "mode": "read", "file_type": "other"}, value: 23, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"file_name": "db2/file", "event_name": "event2", "mode": "write", "file_type": "other"}, value: 12, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"file_name": "db2/file", "event_name": "event2", "mode": "read", "file_type": "other"}, value: 3123, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"file_name": "db2/file", "event_name": "event2", "mode": "write", "file_type": "other"}, value: 967, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"file_name": "db3/file", "event_name": "event3", "mode": "read", "file_type": "other"}, value: 45, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"file_name": "db3/file", "event_name": "event3", "mode": "write", "file_type": "other"}, value: 32, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"file_name": "db3/file", "event_name": "event3", "mode": "read", "file_type": "other"}, value: 1337, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"file_name": "db3/file", "event_name": "event3", "mode": "write", "file_type": "other"}, value: 326, metricType: dto.MetricType_COUNTER},
 	}
 	convey.Convey("Metrics comparison", t, func() {
 		for _, expect := range metricExpected {
@@ -65,3 +65,77 @@ func TestScrapePerfFileInstances(t *testing.T) {
 		t.Errorf("there were unfulfilled exceptions: %s", err)
 	}
 }
+
+func TestScrapePerfFileInstancesExclude(t *testing.T) {
+	_, err := kingpin.CommandLine.Parse([]string{
+		"--collect.perf_schema.file_instances.filter", "",
+		"--collect.perf_schema.file_instances.exclude", `\.ibd$`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer kingpin.CommandLine.Parse([]string{})
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"FILE_NAME", "EVENT_NAME", "COUNT_READ", "COUNT_WRITE", "SUM_NUMBER_OF_BYTES_READ", "SUM_NUMBER_OF_BYTES_WRITE"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("/var/lib/mysql/shop/orders.ibd", "wait/io/file/innodb/innodb_data_file", "3", "4", "725", "128").
+		AddRow("/var/lib/mysql/binlog.000042", "wait/io/file/sql/binlog", "0", "12", "0", "967")
+	mock.ExpectQuery(sanitizeQuery(perfFileInstancesQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapePerfFileInstances{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	binlog := func(mode string) labelMap {
+		return labelMap{"file_name": "binlog.000042", "event_name": "wait/io/file/sql/binlog", "mode": mode, "file_type": "binlog"}
+	}
+	metricExpected := []MetricResult{
+		{labels: binlog("read"), value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: binlog("write"), value: 12, metricType: dto.MetricType_COUNTER},
+		{labels: binlog("read"), value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: binlog("write"), value: 967, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestFileInstanceType(t *testing.T) {
+	convey.Convey("File instances are classified", t, func() {
+		for _, c := range []struct{ fileName, eventName, fileType string }{
+			{"/var/lib/mysql/shop/orders.ibd", "wait/io/file/innodb/innodb_data_file", "datafile"},
+			{"/var/lib/mysql/ibdata1", "wait/io/file/innodb/innodb_data_file", "datafile"},
+			{"/var/lib/mysql/undo_001", "wait/io/file/innodb/innodb_data_file", "undo"},
+			{"/var/lib/mysql/undo_ext.ibu", "wait/io/file/innodb/innodb_data_file", "undo"},
+			{"/var/lib/mysql/ibtmp1", "wait/io/file/innodb/innodb_data_file", "temp"},
+			{"/var/lib/mysql/#innodb_temp/temp_1.ibt", "wait/io/file/innodb/innodb_temp_file", "temp"},
+			{"/var/lib/mysql/binlog.index", "wait/io/file/sql/binlog_index", "binlog"},
+			{"/var/lib/mysql/relay.000007", "wait/io/file/sql/relaylog", "relaylog"},
+			{"/var/lib/mysql/#innodb_redo/#ib_redo9", "wait/io/file/innodb/innodb_log_file", "redo"},
+			{"/var/lib/mysql/shop/legacy.MYD", "wait/io/file/myisam/dfile", "datafile"},
+			{"/var/lib/mysql/auto.cnf", "wait/io/file/sql/misc", "other"},
+		} {
+			convey.So(fileInstanceType(c.fileName, c.eventName), convey.ShouldEqual, c.fileType)
+		}
+	})
+}
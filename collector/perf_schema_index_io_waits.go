@@ -6,6 +6,7 @@ import (
 	"database/sql"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
 )
 
 const perfIndexIOWaitsQuery = `
@@ -14,8 +15,22 @@ const perfIndexIOWaitsQuery = `
 	    SUM_TIMER_FETCH, SUM_TIMER_INSERT, SUM_TIMER_UPDATE, SUM_TIMER_DELETE
 	  FROM performance_schema.table_io_waits_summary_by_index_usage
 	  WHERE OBJECT_SCHEMA NOT IN ('mysql', 'performance_schema')
+	    AND CONCAT(OBJECT_SCHEMA, '.', OBJECT_NAME) REGEXP ?
+	    AND (? = '' OR CONCAT(OBJECT_SCHEMA, '.', OBJECT_NAME) NOT REGEXP ?)
 	`
 
+// Tunable flags.
+var (
+	perfIndexIOWaitsFilter = kingpin.Flag(
+		"collect.perf_schema.indexiowaits.filter",
+		"RegEx schema.table filter for performance_schema.table_io_waits_summary_by_index_usage",
+	).Default(".*").String()
+	perfIndexIOWaitsExclude = kingpin.Flag(
+		"collect.perf_schema.indexiowaits.exclude",
+		"RegEx of schema.table to exclude from performance_schema.table_io_waits_summary_by_index_usage",
+	).Default("").String()
+)
+
 // Metric descriptors.
 var (
 	performanceSchemaIndexWaitsDesc = prometheus.NewDesc(
@@ -45,7 +60,7 @@ func (ScrapePerfIndexIOWaits) Help() string {
 
 // Scrape collects data from database connection and sends it over channel as prometheus metric.
 func (ScrapePerfIndexIOWaits) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
-	perfSchemaIndexWaitsRows, err := db.Query(perfIndexIOWaitsQuery)
+	perfSchemaIndexWaitsRows, err := db.Query(perfIndexIOWaitsQuery, *perfIndexIOWaitsFilter, *perfIndexIOWaitsExclude, *perfIndexIOWaitsExclude)
 	if err != nil {
 		return err
 	}
@@ -21,7 +21,7 @@ func TestScrapePerfIndexIOWaits(t *testing.T) {
 		// Note, timers are in picoseconds.
 		AddRow("database", "table", "index", "10", "11", "12", "13", "14000000000000", "15000000000000", "16000000000000", "17000000000000").
 		AddRow("database", "table", "NONE", "20", "21", "22", "23", "24000000000000", "25000000000000", "26000000000000", "27000000000000")
-	mock.ExpectQuery(sanitizeQuery(perfIndexIOWaitsQuery)).WillReturnRows(rows)
+	mock.ExpectQuery(sanitizeQuery(perfIndexIOWaitsQuery)).WithArgs(".*", "", "").WillReturnRows(rows)
 
 	ch := make(chan prometheus.Metric)
 	go func() {
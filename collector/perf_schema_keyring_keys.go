@@ -0,0 +1,152 @@
+// Scrape `performance_schema.keyring_keys` and the active keyring backend.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	// keyring is the Metric subsystem we use for the backend information.
+	keyring               = "keyring"
+	perfKeyringKeysQuery  = `SELECT KEY_ID FROM performance_schema.keyring_keys`
+	keyringComponentQuery = `SELECT STATUS_KEY, STATUS_VALUE FROM performance_schema.keyring_component_status`
+	keyringPluginQuery    = `
+	SELECT PLUGIN_NAME, PLUGIN_STATUS
+	  FROM information_schema.plugins
+	  WHERE PLUGIN_NAME LIKE 'keyring%'
+	`
+)
+
+// Known key id prefixes used by the server for its own keys.
+var keyringKeyTypes = []struct {
+	prefix, keyType string
+}{
+	{"INNODBKey", "innodb_master"},
+	{"MySQLReplicationKey", "binlog"},
+	{"MySQLRedoKey", "redo"},
+	{"MySQLUndoKey", "undo"},
+	{"percona_", "percona"},
+}
+
+// Metric descriptors.
+var (
+	performanceSchemaKeyringKeysDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "keyring_keys"),
+		"The number of keys in the keyring by key type.",
+		[]string{"key_type"}, nil,
+	)
+	keyringBackendInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, keyring, "backend_info"),
+		"Information about the active keyring component or plugin.",
+		[]string{"kind", "name", "status"}, nil,
+	)
+)
+
+// ScrapePerfKeyringKeys collects from `performance_schema.keyring_keys`.
+type ScrapePerfKeyringKeys struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapePerfKeyringKeys) Name() string {
+	return performanceSchema + ".keyring_keys"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapePerfKeyringKeys) Help() string {
+	return "Collect metrics from performance_schema.keyring_keys and the keyring backend status"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapePerfKeyringKeys) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	if err := scrapeKeyringBackend(db, ch); err != nil {
+		return err
+	}
+
+	perfKeyringKeysRows, err := db.Query(perfKeyringKeysQuery)
+	if err != nil {
+		return err
+	}
+	defer perfKeyringKeysRows.Close()
+
+	var keyID string
+	keyCounts := map[string]uint64{}
+	for _, t := range keyringKeyTypes {
+		keyCounts[t.keyType] = 0
+	}
+	keyCounts["other"] = 0
+
+	for perfKeyringKeysRows.Next() {
+		if err := perfKeyringKeysRows.Scan(&keyID); err != nil {
+			return err
+		}
+		keyCounts[keyringKeyType(keyID)]++
+	}
+
+	for keyType, count := range keyCounts {
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaKeyringKeysDesc, prometheus.GaugeValue, float64(count),
+			keyType,
+		)
+	}
+	return nil
+}
+
+// scrapeKeyringBackend reports the keyring component if one is loaded,
+// otherwise any installed keyring plugins.
+func scrapeKeyringBackend(db *sql.DB, ch chan<- prometheus.Metric) error {
+	componentRows, err := db.Query(keyringComponentQuery)
+	if err == nil {
+		defer componentRows.Close()
+
+		var key, value string
+		status := map[string]string{}
+		for componentRows.Next() {
+			if err := componentRows.Scan(&key, &value); err != nil {
+				return err
+			}
+			status[key] = value
+		}
+		if status["Component_name"] != "" {
+			ch <- prometheus.MustNewConstMetric(
+				keyringBackendInfoDesc, prometheus.GaugeValue, 1,
+				"component", status["Component_name"], status["Component_status"],
+			)
+			return nil
+		}
+		componentRows.Close()
+	} else {
+		// keyring_component_status only exists as of MySQL 8.0.24.
+		log.Debugln("Error querying keyring component status:", err)
+	}
+
+	pluginRows, err := db.Query(keyringPluginQuery)
+	if err != nil {
+		return err
+	}
+	defer pluginRows.Close()
+
+	var name, status string
+	for pluginRows.Next() {
+		if err := pluginRows.Scan(&name, &status); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			keyringBackendInfoDesc, prometheus.GaugeValue, 1,
+			"plugin", name, status,
+		)
+	}
+	return nil
+}
+
+func keyringKeyType(keyID string) string {
+	for _, t := range keyringKeyTypes {
+		if strings.HasPrefix(keyID, t.prefix) {
+			return t.keyType
+		}
+	}
+	return "other"
+}
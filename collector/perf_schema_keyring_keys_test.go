@@ -0,0 +1,60 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapePerfKeyringKeys(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	componentRows := sqlmock.NewRows([]string{"STATUS_KEY", "STATUS_VALUE"}).
+		AddRow("Component_name", "component_keyring_file").
+		AddRow("Author", "Oracle Corporation").
+		AddRow("Component_status", "Active")
+	mock.ExpectQuery(sanitizeQuery(keyringComponentQuery)).WillReturnRows(componentRows)
+
+	keyRows := sqlmock.NewRows([]string{"KEY_ID"}).
+		AddRow("INNODBKey-8dc5a8f9-1234-11ea-a0b7-0242ac110002-1").
+		AddRow("INNODBKey-8dc5a8f9-1234-11ea-a0b7-0242ac110002-2").
+		AddRow("MySQLReplicationKey_1_1").
+		AddRow("app_key")
+	mock.ExpectQuery(sanitizeQuery(perfKeyringKeysQuery)).WillReturnRows(keyRows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapePerfKeyringKeys{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("Metrics comparison", t, func() {
+		got := readMetric(<-ch)
+		convey.So(got, convey.ShouldResemble, MetricResult{
+			labels: labelMap{"kind": "component", "name": "component_keyring_file", "status": "Active"}, value: 1, metricType: dto.MetricType_GAUGE,
+		})
+
+		keyCounts := map[string]float64{}
+		for m := range ch {
+			got := readMetric(m)
+			keyCounts[got.labels["key_type"]] = got.value
+		}
+		convey.So(keyCounts, convey.ShouldResemble, map[string]float64{
+			"innodb_master": 2, "binlog": 1, "redo": 0, "undo": 0, "percona": 0, "other": 1,
+		})
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,87 @@
+// Scrape `performance_schema.log_status`.
+
+package collector
+
+import (
+	"database/sql"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const perfLogStatusQuery = `
+	SELECT
+	    ifnull(JSON_UNQUOTE(JSON_EXTRACT(LOCAL, '$.binary_log_file')), '') as BINARY_LOG_FILE,
+	    ifnull(JSON_EXTRACT(LOCAL, '$.binary_log_position'), 0) as BINARY_LOG_POSITION,
+	    ifnull(JSON_EXTRACT(STORAGE_ENGINES, '$.InnoDB.LSN'), 0) as LSN,
+	    ifnull(JSON_EXTRACT(STORAGE_ENGINES, '$.InnoDB.LSN_checkpoint'), 0) as LSN_CHECKPOINT
+	  FROM performance_schema.log_status
+	`
+
+// Metric descriptors.
+var (
+	performanceSchemaLogStatusBinlogFileDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "log_status_binlog_file_number"),
+		"The number of the current binary log file from performance_schema.log_status.",
+		nil, nil,
+	)
+	performanceSchemaLogStatusBinlogPositionDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "log_status_binlog_position_bytes"),
+		"The position in the current binary log file from performance_schema.log_status.",
+		nil, nil,
+	)
+	performanceSchemaLogStatusLSNDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "log_status_innodb_lsn"),
+		"The current InnoDB log sequence number from performance_schema.log_status.",
+		nil, nil,
+	)
+	performanceSchemaLogStatusLSNCheckpointDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "log_status_innodb_lsn_checkpoint"),
+		"The InnoDB log sequence number of the last checkpoint from performance_schema.log_status.",
+		nil, nil,
+	)
+)
+
+// ScrapePerfLogStatus collects from `performance_schema.log_status`.
+type ScrapePerfLogStatus struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapePerfLogStatus) Name() string {
+	return performanceSchema + ".log_status"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapePerfLogStatus) Help() string {
+	return "Collect metrics from performance_schema.log_status"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapePerfLogStatus) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var (
+		binlogFile                      string
+		binlogPosition, lsn, checkpoint uint64
+	)
+	if err := db.QueryRow(perfLogStatusQuery).Scan(
+		&binlogFile, &binlogPosition, &lsn, &checkpoint,
+	); err != nil {
+		return err
+	}
+
+	// The binary log is disabled when no file is reported.
+	if match := logRE.FindStringSubmatch(binlogFile); match != nil {
+		fileNumber, _ := strconv.ParseFloat(match[1], 64)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaLogStatusBinlogFileDesc, prometheus.GaugeValue, fileNumber,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaLogStatusBinlogPositionDesc, prometheus.GaugeValue, float64(binlogPosition),
+		)
+	}
+	ch <- prometheus.MustNewConstMetric(
+		performanceSchemaLogStatusLSNDesc, prometheus.CounterValue, float64(lsn),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		performanceSchemaLogStatusLSNCheckpointDesc, prometheus.CounterValue, float64(checkpoint),
+	)
+	return nil
+}
@@ -0,0 +1,258 @@
+// Scrape `performance_schema.memory_summary_by_account_by_event_name` and
+// `performance_schema.memory_summary_by_host_by_event_name`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const (
+	perfMemorySummaryByAccountQuery = `
+	SELECT
+	    ifnull(USER, '') as USER,
+	    ifnull(HOST, '') as HOST,
+	    EVENT_NAME,
+	    CURRENT_NUMBER_OF_BYTES_USED,
+	    SUM_NUMBER_OF_BYTES_ALLOC,
+	    SUM_NUMBER_OF_BYTES_FREE
+	  FROM performance_schema.memory_summary_by_account_by_event_name
+	  WHERE CURRENT_NUMBER_OF_BYTES_USED > 0
+	  ORDER BY CURRENT_NUMBER_OF_BYTES_USED DESC
+	  LIMIT %d
+	`
+	perfMemorySummaryByAccountOtherQuery = `
+	SELECT ifnull(SUM(CURRENT_NUMBER_OF_BYTES_USED), 0) - (
+	    SELECT ifnull(SUM(CURRENT_NUMBER_OF_BYTES_USED), 0)
+	    FROM (
+	      SELECT CURRENT_NUMBER_OF_BYTES_USED
+	      FROM performance_schema.memory_summary_by_account_by_event_name
+	      WHERE CURRENT_NUMBER_OF_BYTES_USED > 0
+	      ORDER BY CURRENT_NUMBER_OF_BYTES_USED DESC
+	      LIMIT %d
+	    ) top
+	  )
+	  FROM performance_schema.memory_summary_by_account_by_event_name
+	  WHERE CURRENT_NUMBER_OF_BYTES_USED > 0
+	`
+	perfMemorySummaryByHostQuery = `
+	SELECT
+	    ifnull(HOST, '') as HOST,
+	    EVENT_NAME,
+	    CURRENT_NUMBER_OF_BYTES_USED,
+	    SUM_NUMBER_OF_BYTES_ALLOC,
+	    SUM_NUMBER_OF_BYTES_FREE
+	  FROM performance_schema.memory_summary_by_host_by_event_name
+	  WHERE CURRENT_NUMBER_OF_BYTES_USED > 0
+	  ORDER BY CURRENT_NUMBER_OF_BYTES_USED DESC
+	  LIMIT %d
+	`
+	perfMemorySummaryByHostOtherQuery = `
+	SELECT ifnull(SUM(CURRENT_NUMBER_OF_BYTES_USED), 0) - (
+	    SELECT ifnull(SUM(CURRENT_NUMBER_OF_BYTES_USED), 0)
+	    FROM (
+	      SELECT CURRENT_NUMBER_OF_BYTES_USED
+	      FROM performance_schema.memory_summary_by_host_by_event_name
+	      WHERE CURRENT_NUMBER_OF_BYTES_USED > 0
+	      ORDER BY CURRENT_NUMBER_OF_BYTES_USED DESC
+	      LIMIT %d
+	    ) top
+	  )
+	  FROM performance_schema.memory_summary_by_host_by_event_name
+	  WHERE CURRENT_NUMBER_OF_BYTES_USED > 0
+	`
+)
+
+// Tunable flags.
+var (
+	perfMemorySummaryLimit = kingpin.Flag(
+		"collect.perf_schema.memory_summary.limit",
+		"Limit the number of memory summary rows by current bytes used",
+	).Default("50").Int()
+	perfMemorySummaryRemovePrefix = kingpin.Flag(
+		"collect.perf_schema.memory_summary.remove_prefix",
+		"Remove instrument prefix in performance_schema memory summaries",
+	).Default("memory/").String()
+)
+
+// Metric descriptors.
+var (
+	performanceSchemaMemoryByAccountBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "memory_by_account_bytes"),
+		"The number of bytes currently used by account and memory event name.",
+		[]string{"user", "host", "event_name"}, nil,
+	)
+	performanceSchemaMemoryByAccountAllocDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "memory_by_account_alloc_bytes_total"),
+		"The total number of bytes allocated by account and memory event name.",
+		[]string{"user", "host", "event_name"}, nil,
+	)
+	performanceSchemaMemoryByAccountFreeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "memory_by_account_free_bytes_total"),
+		"The total number of bytes freed by account and memory event name.",
+		[]string{"user", "host", "event_name"}, nil,
+	)
+	performanceSchemaMemoryByAccountOtherBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "memory_by_account_other_bytes"),
+		"The number of bytes currently used by the account and memory event name pairs outside the top rows.",
+		nil, nil,
+	)
+	performanceSchemaMemoryByHostBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "memory_by_host_bytes"),
+		"The number of bytes currently used by host and memory event name.",
+		[]string{"host", "event_name"}, nil,
+	)
+	performanceSchemaMemoryByHostAllocDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "memory_by_host_alloc_bytes_total"),
+		"The total number of bytes allocated by host and memory event name.",
+		[]string{"host", "event_name"}, nil,
+	)
+	performanceSchemaMemoryByHostFreeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "memory_by_host_free_bytes_total"),
+		"The total number of bytes freed by host and memory event name.",
+		[]string{"host", "event_name"}, nil,
+	)
+	performanceSchemaMemoryByHostOtherBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "memory_by_host_other_bytes"),
+		"The number of bytes currently used by the host and memory event name pairs outside the top rows.",
+		nil, nil,
+	)
+)
+
+// ScrapePerfMemoryByAccount collects from `performance_schema.memory_summary_by_account_by_event_name`.
+type ScrapePerfMemoryByAccount struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapePerfMemoryByAccount) Name() string {
+	return performanceSchema + ".memory_by_account"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapePerfMemoryByAccount) Help() string {
+	return "Collect metrics from performance_schema.memory_summary_by_account_by_event_name"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapePerfMemoryByAccount) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	perfMemoryRows, err := db.Query(fmt.Sprintf(perfMemorySummaryByAccountQuery, *perfMemorySummaryLimit))
+	if err != nil {
+		return err
+	}
+	defer perfMemoryRows.Close()
+
+	var (
+		user, host, eventName string
+		current, alloc, free  int64
+	)
+
+	for perfMemoryRows.Next() {
+		if err := perfMemoryRows.Scan(
+			&user, &host, &eventName, &current, &alloc, &free,
+		); err != nil {
+			return err
+		}
+		eventName = strings.TrimPrefix(eventName, *perfMemorySummaryRemovePrefix)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaMemoryByAccountBytesDesc, prometheus.GaugeValue, float64(current),
+			user, host, eventName,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaMemoryByAccountAllocDesc, prometheus.CounterValue, float64(alloc),
+			user, host, eventName,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaMemoryByAccountFreeDesc, prometheus.CounterValue, float64(free),
+			user, host, eventName,
+		)
+	}
+	if err := perfMemoryRows.Err(); err != nil {
+		return err
+	}
+
+	// Rows move in and out of the top as usage shifts, keep the rest
+	// summed up so the total stays accounted for. The total and the top
+	// are taken by a single statement, so that they match closely.
+	var other int64
+	if err := db.QueryRow(fmt.Sprintf(perfMemorySummaryByAccountOtherQuery, *perfMemorySummaryLimit)).Scan(&other); err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(
+		performanceSchemaMemoryByAccountOtherBytesDesc, prometheus.GaugeValue, float64(clampOtherBytes(other)),
+	)
+	return nil
+}
+
+// ScrapePerfMemoryByHost collects from `performance_schema.memory_summary_by_host_by_event_name`.
+type ScrapePerfMemoryByHost struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapePerfMemoryByHost) Name() string {
+	return performanceSchema + ".memory_by_host"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapePerfMemoryByHost) Help() string {
+	return "Collect metrics from performance_schema.memory_summary_by_host_by_event_name"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapePerfMemoryByHost) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	perfMemoryRows, err := db.Query(fmt.Sprintf(perfMemorySummaryByHostQuery, *perfMemorySummaryLimit))
+	if err != nil {
+		return err
+	}
+	defer perfMemoryRows.Close()
+
+	var (
+		host, eventName      string
+		current, alloc, free int64
+	)
+
+	for perfMemoryRows.Next() {
+		if err := perfMemoryRows.Scan(
+			&host, &eventName, &current, &alloc, &free,
+		); err != nil {
+			return err
+		}
+		eventName = strings.TrimPrefix(eventName, *perfMemorySummaryRemovePrefix)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaMemoryByHostBytesDesc, prometheus.GaugeValue, float64(current),
+			host, eventName,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaMemoryByHostAllocDesc, prometheus.CounterValue, float64(alloc),
+			host, eventName,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaMemoryByHostFreeDesc, prometheus.CounterValue, float64(free),
+			host, eventName,
+		)
+	}
+	if err := perfMemoryRows.Err(); err != nil {
+		return err
+	}
+
+	var other int64
+	if err := db.QueryRow(fmt.Sprintf(perfMemorySummaryByHostOtherQuery, *perfMemorySummaryLimit)).Scan(&other); err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(
+		performanceSchemaMemoryByHostOtherBytesDesc, prometheus.GaugeValue, float64(clampOtherBytes(other)),
+	)
+	return nil
+}
+
+// clampOtherBytes keeps the bytes outside the top rows from going negative,
+// performance_schema tables are not consistent snapshots even within a
+// single statement.
+func clampOtherBytes(other int64) int64 {
+	if other < 0 {
+		return 0
+	}
+	return other
+}
@@ -0,0 +1,98 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapePerfMemoryByAccount(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"USER", "HOST", "EVENT_NAME", "CURRENT_NUMBER_OF_BYTES_USED", "SUM_NUMBER_OF_BYTES_ALLOC", "SUM_NUMBER_OF_BYTES_FREE"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("app", "10.0.0.1", "memory/sql/THD::main_mem_root", "4096", "10240", "6144").
+		AddRow("", "", "memory/innodb/buf_buf_pool", "1048576", "1048576", "0")
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfMemorySummaryByAccountQuery, 50))).WillReturnRows(rows)
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfMemorySummaryByAccountOtherQuery, 50))).WillReturnRows(
+		sqlmock.NewRows([]string{"OTHER"}).AddRow("8192"))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapePerfMemoryByAccount{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"user": "app", "host": "10.0.0.1", "event_name": "sql/THD::main_mem_root"}, value: 4096, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "app", "host": "10.0.0.1", "event_name": "sql/THD::main_mem_root"}, value: 10240, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"user": "app", "host": "10.0.0.1", "event_name": "sql/THD::main_mem_root"}, value: 6144, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"user": "", "host": "", "event_name": "innodb/buf_buf_pool"}, value: 1048576, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "", "host": "", "event_name": "innodb/buf_buf_pool"}, value: 1048576, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"user": "", "host": "", "event_name": "innodb/buf_buf_pool"}, value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 8192, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestScrapePerfMemoryByHost(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"HOST", "EVENT_NAME", "CURRENT_NUMBER_OF_BYTES_USED", "SUM_NUMBER_OF_BYTES_ALLOC", "SUM_NUMBER_OF_BYTES_FREE"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("10.0.0.1", "memory/sql/THD::main_mem_root", "4096", "10240", "6144")
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfMemorySummaryByHostQuery, 50))).WillReturnRows(rows)
+	// Memory freed while the statement runs can make the remainder negative.
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfMemorySummaryByHostOtherQuery, 50))).WillReturnRows(
+		sqlmock.NewRows([]string{"OTHER"}).AddRow("-1024"))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapePerfMemoryByHost{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"host": "10.0.0.1", "event_name": "sql/THD::main_mem_root"}, value: 4096, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"host": "10.0.0.1", "event_name": "sql/THD::main_mem_root"}, value: 10240, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"host": "10.0.0.1", "event_name": "sql/THD::main_mem_root"}, value: 6144, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{}, value: 0, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,68 @@
+// Scrape `performance_schema.metadata_locks`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const perfMetadataLocksQuery = `
+	SELECT
+	    OBJECT_TYPE,
+	    ifnull(OBJECT_SCHEMA, '') as OBJECT_SCHEMA,
+	    LOCK_STATUS,
+	    COUNT(*)
+	  FROM performance_schema.metadata_locks
+	  GROUP BY OBJECT_TYPE, OBJECT_SCHEMA, LOCK_STATUS
+	`
+
+// Metric descriptors.
+var (
+	performanceSchemaMetadataLocksDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "metadata_locks"),
+		"The number of metadata lock requests by object type, schema and lock status.",
+		[]string{"object_type", "schema", "lock_status"}, nil,
+	)
+)
+
+// ScrapePerfMetadataLocks collects from `performance_schema.metadata_locks`.
+type ScrapePerfMetadataLocks struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapePerfMetadataLocks) Name() string {
+	return performanceSchema + ".metadata_locks"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapePerfMetadataLocks) Help() string {
+	return "Collect metrics from performance_schema.metadata_locks"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapePerfMetadataLocks) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	perfMetadataLocksRows, err := db.Query(perfMetadataLocksQuery)
+	if err != nil {
+		return err
+	}
+	defer perfMetadataLocksRows.Close()
+
+	var (
+		objectType, objectSchema, lockStatus string
+		count                                uint64
+	)
+
+	for perfMetadataLocksRows.Next() {
+		if err := perfMetadataLocksRows.Scan(
+			&objectType, &objectSchema, &lockStatus, &count,
+		); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaMetadataLocksDesc, prometheus.GaugeValue, float64(count),
+			objectType, objectSchema, lockStatus,
+		)
+	}
+	return nil
+}
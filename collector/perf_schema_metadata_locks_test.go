@@ -0,0 +1,50 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapePerfMetadataLocks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"OBJECT_TYPE", "OBJECT_SCHEMA", "LOCK_STATUS", "COUNT(*)"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("TABLE", "db1", "GRANTED", "12").
+		AddRow("TABLE", "db1", "PENDING", "5").
+		AddRow("GLOBAL", "", "GRANTED", "1")
+	mock.ExpectQuery(sanitizeQuery(perfMetadataLocksQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapePerfMetadataLocks{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"object_type": "TABLE", "schema": "db1", "lock_status": "GRANTED"}, value: 12, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"object_type": "TABLE", "schema": "db1", "lock_status": "PENDING"}, value: 5, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"object_type": "GLOBAL", "schema": "", "lock_status": "GRANTED"}, value: 1, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,102 @@
+// Scrape `performance_schema.prepared_statements_instances`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const perfPreparedStatementsQuery = `
+	SELECT
+	    ifnull(t.PROCESSLIST_USER, '') as USER,
+	    ifnull(p.STATEMENT_NAME, '') as STATEMENT_NAME,
+	    COUNT(*),
+	    SUM(p.COUNT_EXECUTE),
+	    SUM(p.SUM_TIMER_EXECUTE),
+	    SUM(p.COUNT_REPREPARE)
+	  FROM performance_schema.prepared_statements_instances p
+	  LEFT JOIN performance_schema.threads t
+	    ON t.THREAD_ID = p.OWNER_THREAD_ID
+	  GROUP BY t.PROCESSLIST_USER, p.STATEMENT_NAME
+	`
+
+// Metric descriptors.
+var (
+	performanceSchemaPreparedStatementsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "prepared_statements_instances"),
+		"The number of prepared statement instances by owner user and statement name.",
+		[]string{"user", "statement_name"}, nil,
+	)
+	performanceSchemaPreparedStatementsExecuteDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "prepared_statements_executions"),
+		"The number of executions of the currently prepared statements by owner user and statement name.",
+		[]string{"user", "statement_name"}, nil,
+	)
+	performanceSchemaPreparedStatementsExecuteTimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "prepared_statements_execution_seconds"),
+		"The time spent executing the currently prepared statements by owner user and statement name.",
+		[]string{"user", "statement_name"}, nil,
+	)
+	performanceSchemaPreparedStatementsReprepareDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "prepared_statements_reprepares"),
+		"The number of times the currently prepared statements were reprepared by owner user and statement name.",
+		[]string{"user", "statement_name"}, nil,
+	)
+)
+
+// ScrapePerfPreparedStatements collects from `performance_schema.prepared_statements_instances`.
+type ScrapePerfPreparedStatements struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapePerfPreparedStatements) Name() string {
+	return performanceSchema + ".prepared_statements"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapePerfPreparedStatements) Help() string {
+	return "Collect metrics from performance_schema.prepared_statements_instances"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapePerfPreparedStatements) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	// Timers here are returned in picoseconds. The sums only cover statements
+	// that are still prepared and drop when one is deallocated, so they are
+	// exported as gauges rather than counters.
+	perfPreparedStatementsRows, err := db.Query(perfPreparedStatementsQuery)
+	if err != nil {
+		return err
+	}
+	defer perfPreparedStatementsRows.Close()
+
+	var (
+		user, statementName                        string
+		count, executions, executeTime, reprepares uint64
+	)
+
+	for perfPreparedStatementsRows.Next() {
+		if err := perfPreparedStatementsRows.Scan(
+			&user, &statementName, &count, &executions, &executeTime, &reprepares,
+		); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaPreparedStatementsDesc, prometheus.GaugeValue, float64(count),
+			user, statementName,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaPreparedStatementsExecuteDesc, prometheus.GaugeValue, float64(executions),
+			user, statementName,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaPreparedStatementsExecuteTimeDesc, prometheus.GaugeValue, float64(executeTime)/picoSeconds,
+			user, statementName,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaPreparedStatementsReprepareDesc, prometheus.GaugeValue, float64(reprepares),
+			user, statementName,
+		)
+	}
+	return nil
+}
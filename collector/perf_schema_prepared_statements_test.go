@@ -0,0 +1,55 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapePerfPreparedStatements(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"USER", "STATEMENT_NAME", "COUNT(*)", "SUM(p.COUNT_EXECUTE)", "SUM(p.SUM_TIMER_EXECUTE)", "SUM(p.COUNT_REPREPARE)"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("app", "stmt1", "2", "150", "3000000000000", "1").
+		AddRow("", "", "1", "0", "0", "0")
+	mock.ExpectQuery(sanitizeQuery(perfPreparedStatementsQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapePerfPreparedStatements{}).Scrape(db, ch); err != nil {
+			panic(fmt.Sprintf("error calling function on test: %s", err))
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"user": "app", "statement_name": "stmt1"}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "app", "statement_name": "stmt1"}, value: 150, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "app", "statement_name": "stmt1"}, value: 3, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "app", "statement_name": "stmt1"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "", "statement_name": ""}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "", "statement_name": ""}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "", "statement_name": ""}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"user": "", "statement_name": ""}, value: 0, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,144 @@
+// Scrape `performance_schema.replication_group_members`.
+
+package collector
+
+import (
+	"database/sql"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Subsystem.
+const groupReplication = "group_replication"
+
+const (
+	perfReplicationGroupMembersQuery = `
+	SELECT MEMBER_ID, MEMBER_HOST, MEMBER_PORT, MEMBER_STATE, MEMBER_ROLE
+	  FROM performance_schema.replication_group_members
+	  WHERE MEMBER_ID != ''
+	`
+	perfReplicationGroupServerQuery = `SELECT @@server_uuid`
+)
+
+// Metric descriptors.
+var (
+	groupReplicationMemberLabels = []string{"member_id", "member_host", "member_port"}
+
+	groupReplicationMemberInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, groupReplication, "member_info"),
+		"The state and role of the group member, always 1.",
+		append(groupReplicationMemberLabels, "member_state", "member_role"), nil,
+	)
+	groupReplicationIsPrimaryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, groupReplication, "is_primary"),
+		"Whether the group member is a primary.",
+		groupReplicationMemberLabels, nil,
+	)
+	groupReplicationPrimaryChangesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, groupReplication, "primary_changes_total"),
+		"The number of changes of the primary of the group observed by the exporter.",
+		nil, nil,
+	)
+)
+
+// groupPrimaries keeps the primary last seen through every server and the
+// number of changes observed since, as the server keeps no such history.
+var groupPrimaries = struct {
+	sync.Mutex
+	byServer map[string]*groupPrimary
+}{byServer: map[string]*groupPrimary{}}
+
+type groupPrimary struct {
+	memberID string
+	changes  uint64
+}
+
+// observe records the current primary, counting a change if it differs from
+// the previous primary seen.
+func (p *groupPrimary) observe(memberID string) {
+	if memberID == "" || memberID == p.memberID {
+		return
+	}
+	if p.memberID != "" {
+		p.changes++
+	}
+	p.memberID = memberID
+}
+
+// ScrapePerfReplicationGroupMembers collects from `performance_schema.replication_group_members`.
+type ScrapePerfReplicationGroupMembers struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapePerfReplicationGroupMembers) Name() string {
+	return performanceSchema + ".replication_group_members"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapePerfReplicationGroupMembers) Help() string {
+	return "Collect the state and role of the group members and primary changes from performance_schema.replication_group_members"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapePerfReplicationGroupMembers) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var server string
+	if err := db.QueryRow(perfReplicationGroupServerQuery).Scan(&server); err != nil {
+		return err
+	}
+
+	perfReplicationGroupMembersRows, err := db.Query(perfReplicationGroupMembersQuery)
+	if err != nil {
+		return err
+	}
+	defer perfReplicationGroupMembersRows.Close()
+
+	var (
+		memberID, host, port, state, role string
+		primary                           string
+		members                           int
+	)
+	for perfReplicationGroupMembersRows.Next() {
+		if err := perfReplicationGroupMembersRows.Scan(&memberID, &host, &port, &state, &role); err != nil {
+			return err
+		}
+		members++
+		isPrimary := 0.0
+		if role == "PRIMARY" {
+			isPrimary = 1
+			// In multi-primary mode every member is a primary, none changes.
+			if primary == "" {
+				primary = memberID
+			} else {
+				primary = "multi-primary"
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(
+			groupReplicationMemberInfoDesc, prometheus.GaugeValue, 1,
+			memberID, host, port, state, role,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			groupReplicationIsPrimaryDesc, prometheus.GaugeValue, isPrimary,
+			memberID, host, port,
+		)
+	}
+	if err := perfReplicationGroupMembersRows.Err(); err != nil {
+		return err
+	}
+	// Servers that are not group members have no primary to follow.
+	if members == 0 {
+		return nil
+	}
+
+	groupPrimaries.Lock()
+	p, ok := groupPrimaries.byServer[server]
+	if !ok {
+		p = &groupPrimary{}
+		groupPrimaries.byServer[server] = p
+	}
+	p.observe(primary)
+	changes := p.changes
+	groupPrimaries.Unlock()
+
+	ch <- prometheus.MustNewConstMetric(groupReplicationPrimaryChangesDesc, prometheus.CounterValue, float64(changes))
+	return nil
+}
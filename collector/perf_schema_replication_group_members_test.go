@@ -0,0 +1,74 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapePerfReplicationGroupMembers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"MEMBER_ID", "MEMBER_HOST", "MEMBER_PORT", "MEMBER_STATE", "MEMBER_ROLE"}
+	// The primary fails over from member a to member b between the scrapes.
+	for _, primary := range []string{"a", "b"} {
+		mock.ExpectQuery(sanitizeQuery(perfReplicationGroupServerQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"@@server_uuid"}).AddRow("b"))
+		rows := sqlmock.NewRows(columns)
+		for _, member := range []string{"a", "b"} {
+			role := "SECONDARY"
+			if member == primary {
+				role = "PRIMARY"
+			}
+			rows.AddRow(member, "db-"+member, "3306", "ONLINE", role)
+		}
+		mock.ExpectQuery(sanitizeQuery(perfReplicationGroupMembersQuery)).WillReturnRows(rows)
+	}
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		for i := 0; i < 2; i++ {
+			if err = (ScrapePerfReplicationGroupMembers{}).Scrape(db, ch); err != nil {
+				t.Errorf("error calling function on test: %s", err)
+			}
+		}
+		close(ch)
+	}()
+
+	member := func(id string) labelMap {
+		return labelMap{"member_id": id, "member_host": "db-" + id, "member_port": "3306"}
+	}
+	info := func(id, role string) labelMap {
+		return labelMap{"member_id": id, "member_host": "db-" + id, "member_port": "3306", "member_state": "ONLINE", "member_role": role}
+	}
+	metricExpected := []MetricResult{
+		{labels: info("a", "PRIMARY"), value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: member("a"), value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: info("b", "SECONDARY"), value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: member("b"), value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: info("a", "SECONDARY"), value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: member("a"), value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: info("b", "PRIMARY"), value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: member("b"), value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 1, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
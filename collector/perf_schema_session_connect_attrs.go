@@ -0,0 +1,80 @@
+// Scrape `performance_schema.session_connect_attrs`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const perfSessionConnectAttrsQuery = `
+	SELECT program_name, client_name, COUNT(*)
+	  FROM (
+	    SELECT
+	        ifnull(MAX(CASE WHEN ATTR_NAME = 'program_name' THEN ATTR_VALUE END), '') as program_name,
+	        ifnull(MAX(CASE WHEN ATTR_NAME = '_client_name' THEN ATTR_VALUE END), '') as client_name
+	      FROM performance_schema.session_connect_attrs
+	      GROUP BY PROCESSLIST_ID
+	  ) attrs
+	  GROUP BY program_name, client_name
+	  ORDER BY COUNT(*) DESC
+	  LIMIT %d
+	`
+
+// Tunable flags.
+var (
+	perfSessionConnectAttrsLimit = kingpin.Flag(
+		"collect.perf_schema.session_connect_attrs.limit",
+		"Limit the number of program and client name combinations by connection count",
+	).Default("50").Int()
+)
+
+// Metric descriptors.
+var (
+	performanceSchemaSessionConnectAttrsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "session_connect_attrs_connections"),
+		"The number of current connections by the program_name and _client_name connection attributes.",
+		[]string{"program_name", "client_name"}, nil,
+	)
+)
+
+// ScrapePerfSessionConnectAttrs collects from `performance_schema.session_connect_attrs`.
+type ScrapePerfSessionConnectAttrs struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapePerfSessionConnectAttrs) Name() string {
+	return performanceSchema + ".session_connect_attrs"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapePerfSessionConnectAttrs) Help() string {
+	return "Collect connection counts by program and client name from performance_schema.session_connect_attrs"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapePerfSessionConnectAttrs) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	perfSessionConnectAttrsRows, err := db.Query(fmt.Sprintf(perfSessionConnectAttrsQuery, *perfSessionConnectAttrsLimit))
+	if err != nil {
+		return err
+	}
+	defer perfSessionConnectAttrsRows.Close()
+
+	var (
+		programName, clientName string
+		count                   uint64
+	)
+
+	for perfSessionConnectAttrsRows.Next() {
+		if err := perfSessionConnectAttrsRows.Scan(&programName, &clientName, &count); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaSessionConnectAttrsDesc, prometheus.GaugeValue, float64(count),
+			programName, clientName,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapePerfSessionConnectAttrs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"program_name", "client_name", "COUNT(*)"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("billing", "libmysql", "240").
+		AddRow("", "Go-MySQL-Driver", "3")
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(perfSessionConnectAttrsQuery, 50))).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapePerfSessionConnectAttrs{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"program_name": "billing", "client_name": "libmysql"}, value: 240, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"program_name": "", "client_name": "Go-MySQL-Driver"}, value: 3, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,110 @@
+// Scrape `performance_schema.socket_summary_by_event_name`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const perfSocketEventsQuery = `
+	SELECT
+	    EVENT_NAME,
+	    COUNT_READ, SUM_TIMER_READ, SUM_NUMBER_OF_BYTES_READ,
+	    COUNT_WRITE, SUM_TIMER_WRITE, SUM_NUMBER_OF_BYTES_WRITE,
+	    COUNT_MISC, SUM_TIMER_MISC
+	  FROM performance_schema.socket_summary_by_event_name
+	`
+
+// Metric descriptors.
+var (
+	performanceSchemaSocketEventsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "socket_events_total"),
+		"The total socket events by event name/mode.",
+		[]string{"event_name", "mode"}, nil,
+	)
+	performanceSchemaSocketEventsTimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "socket_events_seconds_total"),
+		"The total seconds of socket events by event name/mode.",
+		[]string{"event_name", "mode"}, nil,
+	)
+	performanceSchemaSocketEventsBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "socket_events_bytes_total"),
+		"The total bytes of socket events by event name/mode.",
+		[]string{"event_name", "mode"}, nil,
+	)
+)
+
+// ScrapePerfSocketEvents collects from `performance_schema.socket_summary_by_event_name`.
+type ScrapePerfSocketEvents struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapePerfSocketEvents) Name() string {
+	return "perf_schema.socket_events"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapePerfSocketEvents) Help() string {
+	return "Collect metrics from performance_schema.socket_summary_by_event_name"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapePerfSocketEvents) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	// Timers here are returned in picoseconds.
+	perfSchemaSocketEventsRows, err := db.Query(perfSocketEventsQuery)
+	if err != nil {
+		return err
+	}
+	defer perfSchemaSocketEventsRows.Close()
+
+	var (
+		eventName                         string
+		countRead, timeRead, bytesRead    uint64
+		countWrite, timeWrite, bytesWrite uint64
+		countMisc, timeMisc               uint64
+	)
+	for perfSchemaSocketEventsRows.Next() {
+		if err := perfSchemaSocketEventsRows.Scan(
+			&eventName,
+			&countRead, &timeRead, &bytesRead,
+			&countWrite, &timeWrite, &bytesWrite,
+			&countMisc, &timeMisc,
+		); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaSocketEventsDesc, prometheus.CounterValue, float64(countRead),
+			eventName, "read",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaSocketEventsTimeDesc, prometheus.CounterValue, float64(timeRead)/picoSeconds,
+			eventName, "read",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaSocketEventsBytesDesc, prometheus.CounterValue, float64(bytesRead),
+			eventName, "read",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaSocketEventsDesc, prometheus.CounterValue, float64(countWrite),
+			eventName, "write",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaSocketEventsTimeDesc, prometheus.CounterValue, float64(timeWrite)/picoSeconds,
+			eventName, "write",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaSocketEventsBytesDesc, prometheus.CounterValue, float64(bytesWrite),
+			eventName, "write",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaSocketEventsDesc, prometheus.CounterValue, float64(countMisc),
+			eventName, "misc",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaSocketEventsTimeDesc, prometheus.CounterValue, float64(timeMisc)/picoSeconds,
+			eventName, "misc",
+		)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapePerfSocketEvents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{
+		"EVENT_NAME",
+		"COUNT_READ", "SUM_TIMER_READ", "SUM_NUMBER_OF_BYTES_READ",
+		"COUNT_WRITE", "SUM_TIMER_WRITE", "SUM_NUMBER_OF_BYTES_WRITE",
+		"COUNT_MISC", "SUM_TIMER_MISC",
+	}
+	rows := sqlmock.NewRows(columns).
+		AddRow("wait/io/socket/sql/client_connection", "10", "2000000000000", "4096", "20", "1000000000000", "8192", "5", "500000000000")
+	mock.ExpectQuery(sanitizeQuery(perfSocketEventsQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapePerfSocketEvents{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	eventName := "wait/io/socket/sql/client_connection"
+	metricExpected := []MetricResult{
+		{labels: labelMap{"event_name": eventName, "mode": "read"}, value: 10, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"event_name": eventName, "mode": "read"}, value: 2, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"event_name": eventName, "mode": "read"}, value: 4096, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"event_name": eventName, "mode": "write"}, value: 20, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"event_name": eventName, "mode": "write"}, value: 1, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"event_name": eventName, "mode": "write"}, value: 8192, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"event_name": eventName, "mode": "misc"}, value: 5, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"event_name": eventName, "mode": "misc"}, value: 0.5, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"database/sql"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
 )
 
 const perfTableIOWaitsQuery = `
@@ -15,8 +16,22 @@ const perfTableIOWaitsQuery = `
 	    SUM_TIMER_FETCH, SUM_TIMER_INSERT, SUM_TIMER_UPDATE, SUM_TIMER_DELETE
 	  FROM performance_schema.table_io_waits_summary_by_table
 	  WHERE OBJECT_SCHEMA NOT IN ('mysql', 'performance_schema')
+	    AND CONCAT(OBJECT_SCHEMA, '.', OBJECT_NAME) REGEXP ?
+	    AND (? = '' OR CONCAT(OBJECT_SCHEMA, '.', OBJECT_NAME) NOT REGEXP ?)
 	`
 
+// Tunable flags.
+var (
+	perfTableIOWaitsFilter = kingpin.Flag(
+		"collect.perf_schema.tableiowaits.filter",
+		"RegEx schema.table filter for performance_schema.table_io_waits_summary_by_table",
+	).Default(".*").String()
+	perfTableIOWaitsExclude = kingpin.Flag(
+		"collect.perf_schema.tableiowaits.exclude",
+		"RegEx of schema.table to exclude from performance_schema.table_io_waits_summary_by_table",
+	).Default("").String()
+)
+
 // Metric descriptors.
 var (
 	performanceSchemaTableWaitsDesc = prometheus.NewDesc(
@@ -46,7 +61,7 @@ func (ScrapePerfTableIOWaits) Help() string {
 
 // Scrape collects data from database connection and sends it over channel as prometheus metric.
 func (ScrapePerfTableIOWaits) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
-	perfSchemaTableWaitsRows, err := db.Query(perfTableIOWaitsQuery)
+	perfSchemaTableWaitsRows, err := db.Query(perfTableIOWaitsQuery, *perfTableIOWaitsFilter, *perfTableIOWaitsExclude, *perfTableIOWaitsExclude)
 	if err != nil {
 		return err
 	}
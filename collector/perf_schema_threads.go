@@ -0,0 +1,82 @@
+// Scrape `performance_schema.threads`.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const perfThreadsQuery = `
+	SELECT
+	    ifnull(PROCESSLIST_COMMAND, '') as PROCESSLIST_COMMAND,
+	    ifnull(PROCESSLIST_STATE, '') as PROCESSLIST_STATE,
+	    INSTRUMENTED,
+	    COUNT(*),
+	    ifnull(SUM(PROCESSLIST_TIME), 0)
+	  FROM performance_schema.threads
+	  WHERE TYPE = 'FOREGROUND'
+	    AND PROCESSLIST_ID != connection_id()
+	  GROUP BY PROCESSLIST_COMMAND, PROCESSLIST_STATE, INSTRUMENTED
+	`
+
+// Metric descriptors.
+var (
+	performanceSchemaThreadsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "threads"),
+		"The number of foreground threads by command, state and whether they are instrumented.",
+		[]string{"command", "state", "instrumented"}, nil,
+	)
+	performanceSchemaThreadsTimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "threads_seconds"),
+		"The number of seconds foreground threads have spent in their current state by command, state and whether they are instrumented.",
+		[]string{"command", "state", "instrumented"}, nil,
+	)
+)
+
+// ScrapePerfThreads collects from `performance_schema.threads`.
+type ScrapePerfThreads struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapePerfThreads) Name() string {
+	return performanceSchema + ".threads"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapePerfThreads) Help() string {
+	return "Collect thread counts by command and state from performance_schema.threads"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapePerfThreads) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	perfThreadsRows, err := db.Query(perfThreadsQuery)
+	if err != nil {
+		return err
+	}
+	defer perfThreadsRows.Close()
+
+	var (
+		command, state, instrumented string
+		count, time                  uint64
+	)
+
+	for perfThreadsRows.Next() {
+		if err := perfThreadsRows.Scan(&command, &state, &instrumented, &count, &time); err != nil {
+			return err
+		}
+		command = strings.ToLower(command)
+		state = strings.ToLower(state)
+		instrumented = strings.ToLower(instrumented)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaThreadsDesc, prometheus.GaugeValue, float64(count),
+			command, state, instrumented,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaThreadsTimeDesc, prometheus.GaugeValue, float64(time),
+			command, state, instrumented,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapePerfThreads(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"PROCESSLIST_COMMAND", "PROCESSLIST_STATE", "INSTRUMENTED", "COUNT(*)", "SUM(PROCESSLIST_TIME)"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("Sleep", "", "YES", "120", "3600").
+		AddRow("Query", "Sending data", "YES", "3", "2").
+		AddRow("Daemon", "Waiting on empty queue", "NO", "1", "86400")
+	mock.ExpectQuery(sanitizeQuery(perfThreadsQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapePerfThreads{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"command": "sleep", "state": "", "instrumented": "yes"}, value: 120, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"command": "sleep", "state": "", "instrumented": "yes"}, value: 3600, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"command": "query", "state": "sending data", "instrumented": "yes"}, value: 3, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"command": "query", "state": "sending data", "instrumented": "yes"}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"command": "daemon", "state": "waiting on empty queue", "instrumented": "no"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"command": "daemon", "state": "waiting on empty queue", "instrumented": "no"}, value: 86400, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,89 @@
+// Scrape `performance_schema.variables_info` and `performance_schema.persisted_variables`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	perfVariablesInfoQuery = `
+	SELECT VARIABLE_SOURCE, COUNT(*)
+	  FROM performance_schema.variables_info
+	  GROUP BY VARIABLE_SOURCE
+	`
+	perfPersistedVariablesQuery = `
+	SELECT VARIABLE_NAME, ifnull(VARIABLE_VALUE, '') as VARIABLE_VALUE
+	  FROM performance_schema.persisted_variables
+	`
+)
+
+// Metric descriptors.
+var (
+	performanceSchemaVariablesBySourceDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "variables_by_source"),
+		"The number of system variables by the source they were most recently set from. Every source other than COMPILED differs from the compile-time default.",
+		[]string{"source"}, nil,
+	)
+	performanceSchemaPersistedVariableInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, performanceSchema, "persisted_variable_info"),
+		"Information about a variable persisted with SET PERSIST.",
+		[]string{"variable_name", "value"}, nil,
+	)
+)
+
+// ScrapePerfVariablesInfo collects from `performance_schema.variables_info` and `performance_schema.persisted_variables`.
+type ScrapePerfVariablesInfo struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapePerfVariablesInfo) Name() string {
+	return performanceSchema + ".variables_info"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapePerfVariablesInfo) Help() string {
+	return "Collect metrics from performance_schema.variables_info and performance_schema.persisted_variables"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapePerfVariablesInfo) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	variablesInfoRows, err := db.Query(perfVariablesInfoQuery)
+	if err != nil {
+		return err
+	}
+	defer variablesInfoRows.Close()
+
+	var (
+		source string
+		count  uint64
+	)
+	for variablesInfoRows.Next() {
+		if err := variablesInfoRows.Scan(&source, &count); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaVariablesBySourceDesc, prometheus.GaugeValue, float64(count),
+			source,
+		)
+	}
+
+	persistedVariablesRows, err := db.Query(perfPersistedVariablesQuery)
+	if err != nil {
+		return err
+	}
+	defer persistedVariablesRows.Close()
+
+	var name, value string
+	for persistedVariablesRows.Next() {
+		if err := persistedVariablesRows.Scan(&name, &value); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			performanceSchemaPersistedVariableInfoDesc, prometheus.GaugeValue, 1,
+			name, value,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapePerfVariablesInfo(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	infoRows := sqlmock.NewRows([]string{"VARIABLE_SOURCE", "COUNT(*)"}).
+		AddRow("COMPILED", "550").
+		AddRow("EXPLICIT", "12").
+		AddRow("PERSISTED", "2")
+	mock.ExpectQuery(sanitizeQuery(perfVariablesInfoQuery)).WillReturnRows(infoRows)
+
+	persistedRows := sqlmock.NewRows([]string{"VARIABLE_NAME", "VARIABLE_VALUE"}).
+		AddRow("max_connections", "500").
+		AddRow("innodb_io_capacity", "2000")
+	mock.ExpectQuery(sanitizeQuery(perfPersistedVariablesQuery)).WillReturnRows(persistedRows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapePerfVariablesInfo{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"source": "COMPILED"}, value: 550, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"source": "EXPLICIT"}, value: 12, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"source": "PERSISTED"}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"variable_name": "max_connections", "value": "500"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"variable_name": "innodb_io_capacity", "value": "2000"}, value: 1, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
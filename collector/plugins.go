@@ -0,0 +1,79 @@
+// Scrape `information_schema.plugins` and `mysql.component`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	pluginsQuery = `
+	SELECT PLUGIN_NAME, PLUGIN_STATUS, PLUGIN_TYPE, ifnull(PLUGIN_LIBRARY, '') as PLUGIN_LIBRARY
+	  FROM information_schema.plugins
+	`
+	// componentsQuery is available as of MySQL 8.0.
+	componentsQuery = `SELECT component_urn FROM mysql.component`
+)
+
+// Metric descriptors.
+var (
+	pluginInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "plugin_info"),
+		"Information about an installed plugin.",
+		[]string{"name", "status", "type", "library"}, nil,
+	)
+	componentInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "component_info"),
+		"Information about an installed component.",
+		[]string{"urn"}, nil,
+	)
+)
+
+// ScrapePlugins collects from `information_schema.plugins` and `mysql.component`.
+type ScrapePlugins struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapePlugins) Name() string {
+	return "plugins"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapePlugins) Help() string {
+	return "Collect installed plugins and components from information_schema.plugins and mysql.component"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapePlugins) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	pluginsRows, err := db.Query(pluginsQuery)
+	if err != nil {
+		return err
+	}
+	defer pluginsRows.Close()
+
+	var name, status, pluginType, library string
+	for pluginsRows.Next() {
+		if err := pluginsRows.Scan(&name, &status, &pluginType, &library); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(pluginInfoDesc, prometheus.GaugeValue, 1, name, status, pluginType, library)
+	}
+
+	componentsRows, err := db.Query(componentsQuery)
+	if err != nil {
+		log.Debugln("Error querying mysql.component, skipping components:", err)
+		return nil
+	}
+	defer componentsRows.Close()
+
+	var urn string
+	for componentsRows.Next() {
+		if err := componentsRows.Scan(&urn); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(componentInfoDesc, prometheus.GaugeValue, 1, urn)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapePlugins(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"PLUGIN_NAME", "PLUGIN_STATUS", "PLUGIN_TYPE", "PLUGIN_LIBRARY"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("InnoDB", "ACTIVE", "STORAGE ENGINE", "").
+		AddRow("rpl_semi_sync_source", "ACTIVE", "REPLICATION", "semisync_source.so")
+	mock.ExpectQuery(sanitizeQuery(pluginsQuery)).WillReturnRows(rows)
+	mock.ExpectQuery(sanitizeQuery(componentsQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"component_urn"}).AddRow("file://component_validate_password"))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapePlugins{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"name": "InnoDB", "status": "ACTIVE", "type": "STORAGE ENGINE", "library": ""}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"name": "rpl_semi_sync_source", "status": "ACTIVE", "type": "REPLICATION", "library": "semisync_source.so"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"urn": "file://component_validate_password"}, value: 1, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestScrapePluginsWithoutComponents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"PLUGIN_NAME", "PLUGIN_STATUS", "PLUGIN_TYPE", "PLUGIN_LIBRARY"}
+	mock.ExpectQuery(sanitizeQuery(pluginsQuery)).WillReturnRows(
+		sqlmock.NewRows(columns).AddRow("audit_log", "DISABLED", "AUDIT", "audit_log.so"))
+	mock.ExpectQuery(sanitizeQuery(componentsQuery)).WillReturnError(fmt.Errorf("Table 'mysql.component' doesn't exist"))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapePlugins{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("Metrics comparison", t, func() {
+		got := readMetric(<-ch)
+		convey.So(got, convey.ShouldResemble, MetricResult{labels: labelMap{"name": "audit_log", "status": "DISABLED", "type": "AUDIT", "library": "audit_log.so"}, value: 1, metricType: dto.MetricType_GAUGE})
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,26 @@
+package collector
+
+import (
+	"strings"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// Subsystem.
+const proxysql = "proxysql"
+
+// Tunable flags.
+var proxysqlMode = kingpin.Flag(
+	"exporter.proxysql",
+	"Scrape the admin interface of ProxySQL, usually on port 6032, with the proxysql collectors only.",
+).Default("false").Bool()
+
+// ProxySQLMode reports whether the exporter scrapes the admin interface of ProxySQL.
+func ProxySQLMode() bool {
+	return *proxysqlMode
+}
+
+// IsProxySQLScraper reports whether the scraper reads the admin interface of ProxySQL.
+func IsProxySQLScraper(scraper Scraper) bool {
+	return strings.HasPrefix(scraper.Name(), proxysql+".")
+}
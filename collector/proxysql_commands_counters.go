@@ -0,0 +1,77 @@
+// Scrape `stats_mysql_commands_counters` of the ProxySQL admin interface.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const proxysqlCommandsCountersQuery = `
+	SELECT Command, Total_Time_us, Total_cnt,
+	       cnt_100us, cnt_500us, cnt_1ms, cnt_5ms, cnt_10ms, cnt_50ms,
+	       cnt_100ms, cnt_500ms, cnt_1s, cnt_5s, cnt_10s, cnt_INFs
+	  FROM stats_mysql_commands_counters
+	`
+
+// proxysqlCommandsBuckets are the upper bounds, in seconds, of the cnt_* columns.
+var proxysqlCommandsBuckets = []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10}
+
+// Metric descriptors.
+var (
+	proxysqlCommandsLatencyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, proxysql, "commands_latency_seconds"),
+		"The execution time of the commands run through ProxySQL by command type.",
+		[]string{"command"}, nil,
+	)
+)
+
+// ScrapeProxySQLCommandsCounters collects from `stats_mysql_commands_counters` of ProxySQL.
+type ScrapeProxySQLCommandsCounters struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeProxySQLCommandsCounters) Name() string {
+	return proxysql + ".commands_counters"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeProxySQLCommandsCounters) Help() string {
+	return "Collect command latency histograms from the ProxySQL admin interface (ProxySQL mode only)"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeProxySQLCommandsCounters) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	commandsRows, err := db.Query(proxysqlCommandsCountersQuery)
+	if err != nil {
+		return err
+	}
+	defer commandsRows.Close()
+
+	var (
+		command          string
+		totalTime, total uint64
+		// One count per bucket plus the +Inf one.
+		counts = make([]uint64, len(proxysqlCommandsBuckets)+1)
+	)
+	dest := []interface{}{&command, &totalTime, &total}
+	for i := range counts {
+		dest = append(dest, &counts[i])
+	}
+	for commandsRows.Next() {
+		if err := commandsRows.Scan(dest...); err != nil {
+			return err
+		}
+		buckets := make(map[float64]uint64, len(proxysqlCommandsBuckets))
+		var cumulative uint64
+		for i, le := range proxysqlCommandsBuckets {
+			cumulative += counts[i]
+			buckets[le] = cumulative
+		}
+		ch <- prometheus.MustNewConstHistogram(
+			proxysqlCommandsLatencyDesc, total, float64(totalTime)/1e6, buckets,
+			command,
+		)
+	}
+	return commandsRows.Err()
+}
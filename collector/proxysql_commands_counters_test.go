@@ -0,0 +1,60 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeProxySQLCommandsCounters(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"Command", "Total_Time_us", "Total_cnt", "cnt_100us", "cnt_500us", "cnt_1ms", "cnt_5ms", "cnt_10ms", "cnt_50ms", "cnt_100ms", "cnt_500ms", "cnt_1s", "cnt_5s", "cnt_10s", "cnt_INFs"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("SELECT", "2500000", "100", "10", "20", "30", "20", "10", "5", "2", "1", "1", "0", "0", "1")
+	mock.ExpectQuery(sanitizeQuery(proxysqlCommandsCountersQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeProxySQLCommandsCounters{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	expectHistogram := prometheus.MustNewConstHistogram(proxysqlCommandsLatencyDesc, 100, 2.5, map[float64]uint64{
+		0.0001: 10,
+		0.0005: 30,
+		0.001:  60,
+		0.005:  80,
+		0.01:   90,
+		0.05:   95,
+		0.1:    97,
+		0.5:    98,
+		1:      99,
+		5:      99,
+		10:     99,
+	}, "SELECT")
+	expectPb := &dto.Metric{}
+	expectHistogram.Write(expectPb)
+
+	gotPb := &dto.Metric{}
+	gotHistogram := <-ch
+	gotHistogram.Write(gotPb)
+	convey.Convey("Histogram comparison", t, func() {
+		convey.So(gotPb.Histogram, convey.ShouldResemble, expectPb.Histogram)
+		convey.So(gotPb.Label, convey.ShouldResemble, expectPb.Label)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,152 @@
+// Scrape `stats_mysql_connection_pool` of the ProxySQL admin interface.
+
+package collector
+
+import (
+	"database/sql"
+	"net"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const proxysqlConnectionPoolQuery = `
+	SELECT hostgroup, srv_host, srv_port, status,
+	       ConnUsed, ConnFree, ConnOK, ConnERR,
+	       Queries, Bytes_data_sent, Bytes_data_recv, Latency_us
+	  FROM stats_mysql_connection_pool
+	`
+
+// proxysqlBackendStatus maps the status of a backend to the value of the status metric.
+var proxysqlBackendStatus = map[string]float64{
+	"ONLINE":       1,
+	"SHUNNED":      2,
+	"OFFLINE_SOFT": 3,
+	"OFFLINE_HARD": 4,
+}
+
+// Metric descriptors.
+var (
+	proxysqlConnectionPoolLabels = []string{"hostgroup", "endpoint"}
+
+	proxysqlConnectionPoolStatusDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, proxysql, "connection_pool_status"),
+		"The status of the backend server (1 - ONLINE, 2 - SHUNNED, 3 - OFFLINE_SOFT, 4 - OFFLINE_HARD).",
+		proxysqlConnectionPoolLabels, nil,
+	)
+	proxysqlConnectionPoolConnUsedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, proxysql, "connection_pool_conn_used"),
+		"The number of connections currently used by ProxySQL to send queries to the backend server.",
+		proxysqlConnectionPoolLabels, nil,
+	)
+	proxysqlConnectionPoolConnFreeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, proxysql, "connection_pool_conn_free"),
+		"The number of idle connections kept open to the backend server.",
+		proxysqlConnectionPoolLabels, nil,
+	)
+	proxysqlConnectionPoolConnOKDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, proxysql, "connection_pool_conn_ok_total"),
+		"The number of connections to the backend server established successfully.",
+		proxysqlConnectionPoolLabels, nil,
+	)
+	proxysqlConnectionPoolConnErrDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, proxysql, "connection_pool_conn_err_total"),
+		"The number of connections to the backend server that were not established successfully.",
+		proxysqlConnectionPoolLabels, nil,
+	)
+	proxysqlConnectionPoolQueriesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, proxysql, "connection_pool_queries_total"),
+		"The number of queries routed to the backend server.",
+		proxysqlConnectionPoolLabels, nil,
+	)
+	proxysqlConnectionPoolBytesSentDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, proxysql, "connection_pool_bytes_data_sent_total"),
+		"The amount of data sent to the backend server, excluding metadata.",
+		proxysqlConnectionPoolLabels, nil,
+	)
+	proxysqlConnectionPoolBytesRecvDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, proxysql, "connection_pool_bytes_data_recv_total"),
+		"The amount of data received from the backend server, excluding metadata.",
+		proxysqlConnectionPoolLabels, nil,
+	)
+	proxysqlConnectionPoolLatencyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, proxysql, "connection_pool_latency_seconds"),
+		"The current ping time to the backend server as reported by the ProxySQL monitor.",
+		proxysqlConnectionPoolLabels, nil,
+	)
+)
+
+// ScrapeProxySQLConnectionPool collects from `stats_mysql_connection_pool` of ProxySQL.
+type ScrapeProxySQLConnectionPool struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeProxySQLConnectionPool) Name() string {
+	return proxysql + ".connection_pool"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeProxySQLConnectionPool) Help() string {
+	return "Collect backend connection pool stats from the ProxySQL admin interface (ProxySQL mode only)"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeProxySQLConnectionPool) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	poolRows, err := db.Query(proxysqlConnectionPoolQuery)
+	if err != nil {
+		return err
+	}
+	defer poolRows.Close()
+
+	var (
+		hostgroup, host, port, status       string
+		connUsed, connFree, connOK, connErr uint64
+		queries, bytesSent, bytesRecv       uint64
+		latency                             uint64
+	)
+	for poolRows.Next() {
+		if err := poolRows.Scan(
+			&hostgroup, &host, &port, &status,
+			&connUsed, &connFree, &connOK, &connErr,
+			&queries, &bytesSent, &bytesRecv, &latency,
+		); err != nil {
+			return err
+		}
+		endpoint := net.JoinHostPort(host, port)
+		ch <- prometheus.MustNewConstMetric(
+			proxysqlConnectionPoolStatusDesc, prometheus.GaugeValue, proxysqlBackendStatus[status],
+			hostgroup, endpoint,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			proxysqlConnectionPoolConnUsedDesc, prometheus.GaugeValue, float64(connUsed),
+			hostgroup, endpoint,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			proxysqlConnectionPoolConnFreeDesc, prometheus.GaugeValue, float64(connFree),
+			hostgroup, endpoint,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			proxysqlConnectionPoolConnOKDesc, prometheus.CounterValue, float64(connOK),
+			hostgroup, endpoint,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			proxysqlConnectionPoolConnErrDesc, prometheus.CounterValue, float64(connErr),
+			hostgroup, endpoint,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			proxysqlConnectionPoolQueriesDesc, prometheus.CounterValue, float64(queries),
+			hostgroup, endpoint,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			proxysqlConnectionPoolBytesSentDesc, prometheus.CounterValue, float64(bytesSent),
+			hostgroup, endpoint,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			proxysqlConnectionPoolBytesRecvDesc, prometheus.CounterValue, float64(bytesRecv),
+			hostgroup, endpoint,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			proxysqlConnectionPoolLatencyDesc, prometheus.GaugeValue, float64(latency)/1e6,
+			hostgroup, endpoint,
+		)
+	}
+	return poolRows.Err()
+}
@@ -0,0 +1,59 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeProxySQLConnectionPool(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"hostgroup", "srv_host", "srv_port", "status", "ConnUsed", "ConnFree", "ConnOK", "ConnERR", "Queries", "Bytes_data_sent", "Bytes_data_recv", "Latency_us"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("10", "db1", "3306", "ONLINE", "2", "8", "120", "3", "5000", "1024", "4096", "250").
+		AddRow("20", "db2", "3306", "SHUNNED", "0", "0", "10", "7", "0", "0", "0", "0")
+	mock.ExpectQuery(sanitizeQuery(proxysqlConnectionPoolQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeProxySQLConnectionPool{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"hostgroup": "10", "endpoint": "db1:3306"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"hostgroup": "10", "endpoint": "db1:3306"}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"hostgroup": "10", "endpoint": "db1:3306"}, value: 8, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"hostgroup": "10", "endpoint": "db1:3306"}, value: 120, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"hostgroup": "10", "endpoint": "db1:3306"}, value: 3, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"hostgroup": "10", "endpoint": "db1:3306"}, value: 5000, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"hostgroup": "10", "endpoint": "db1:3306"}, value: 1024, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"hostgroup": "10", "endpoint": "db1:3306"}, value: 4096, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"hostgroup": "10", "endpoint": "db1:3306"}, value: 0.00025, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"hostgroup": "20", "endpoint": "db2:3306"}, value: 2, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		// Drain the remaining metrics of the second backend.
+		for range ch {
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
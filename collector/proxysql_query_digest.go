@@ -0,0 +1,91 @@
+// Scrape `stats_mysql_query_digest` of the ProxySQL admin interface.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// Digests are summed over the client addresses ProxySQL may track them by.
+const proxysqlQueryDigestQuery = `
+	SELECT hostgroup, schemaname, username, digest, SUBSTR(MAX(digest_text), 1, %d),
+	       SUM(count_star), SUM(sum_time)
+	  FROM stats_mysql_query_digest
+	  GROUP BY hostgroup, schemaname, username, digest
+	  ORDER BY SUM(sum_time) DESC
+	  LIMIT %d
+	`
+
+// Tunable flags.
+var (
+	proxysqlQueryDigestLimit = kingpin.Flag(
+		"collect.proxysql.query_digest.limit",
+		"Limit the number of ProxySQL query digests by total execution time",
+	).Default("250").Int()
+	proxysqlQueryDigestTextLimit = kingpin.Flag(
+		"collect.proxysql.query_digest.digest_text_limit",
+		"Maximum length of the normalized ProxySQL query text",
+	).Default("120").Int()
+)
+
+// Metric descriptors.
+var (
+	proxysqlQueryDigestLabels = []string{"hostgroup", "schema", "user", "digest", "digest_text"}
+
+	proxysqlQueryDigestCountDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, proxysql, "query_digest_total"),
+		"The number of queries run through ProxySQL by digest.",
+		proxysqlQueryDigestLabels, nil,
+	)
+	proxysqlQueryDigestTimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, proxysql, "query_digest_seconds_total"),
+		"The total execution time of the queries run through ProxySQL by digest.",
+		proxysqlQueryDigestLabels, nil,
+	)
+)
+
+// ScrapeProxySQLQueryDigest collects from `stats_mysql_query_digest` of ProxySQL.
+type ScrapeProxySQLQueryDigest struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeProxySQLQueryDigest) Name() string {
+	return proxysql + ".query_digest"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeProxySQLQueryDigest) Help() string {
+	return "Collect query digest stats from the ProxySQL admin interface (ProxySQL mode only)"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeProxySQLQueryDigest) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	query := fmt.Sprintf(proxysqlQueryDigestQuery, *proxysqlQueryDigestTextLimit, *proxysqlQueryDigestLimit)
+	digestRows, err := db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer digestRows.Close()
+
+	var (
+		hostgroup, schema, user, digest, digestText string
+		count, sumTime                              uint64
+	)
+	for digestRows.Next() {
+		if err := digestRows.Scan(&hostgroup, &schema, &user, &digest, &digestText, &count, &sumTime); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			proxysqlQueryDigestCountDesc, prometheus.CounterValue, float64(count),
+			hostgroup, schema, user, digest, digestText,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			proxysqlQueryDigestTimeDesc, prometheus.CounterValue, float64(sumTime)/1e6,
+			hostgroup, schema, user, digest, digestText,
+		)
+	}
+	return digestRows.Err()
+}
@@ -0,0 +1,50 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeProxySQLQueryDigest(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"hostgroup", "schemaname", "username", "digest", "digest_text", "count_star", "sum_time"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("10", "app", "web", "0x3B2A7C9F1E", "SELECT * FROM users WHERE id = ?", "1500", "3000000")
+	query := fmt.Sprintf(proxysqlQueryDigestQuery, *proxysqlQueryDigestTextLimit, *proxysqlQueryDigestLimit)
+	mock.ExpectQuery(sanitizeQuery(query)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeProxySQLQueryDigest{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	labels := labelMap{"hostgroup": "10", "schema": "app", "user": "web", "digest": "0x3B2A7C9F1E", "digest_text": "SELECT * FROM users WHERE id = ?"}
+	metricExpected := []MetricResult{
+		{labels: labels, value: 1500, metricType: dto.MetricType_COUNTER},
+		{labels: labels, value: 3, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,123 @@
+// Scrape replica binlog dump threads from `performance_schema.threads`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// replicasConnectedQuery groups the binlog dump threads, one per connected
+// replica, by replica host.
+const replicasConnectedQuery = `
+	SELECT
+	    ifnull(PROCESSLIST_HOST, '') as PROCESSLIST_HOST,
+	    PROCESSLIST_COMMAND,
+	    COUNT(*),
+	    MAX(ifnull(PROCESSLIST_TIME, 0))
+	  FROM performance_schema.threads
+	  WHERE PROCESSLIST_COMMAND IN ('Binlog Dump', 'Binlog Dump GTID')
+	  GROUP BY PROCESSLIST_HOST, PROCESSLIST_COMMAND
+	`
+
+// replicasBinlogDumpStateQuery reads the state of the binlog dump threads.
+// The source does not expose the binlog file and position a replica last
+// requested, but the state tells whether the dump thread has sent all of the
+// binlog and is waiting for more updates, or is still sending or reading it.
+const replicasBinlogDumpStateQuery = `
+	SELECT
+	    ifnull(PROCESSLIST_HOST, '') as PROCESSLIST_HOST,
+	    PROCESSLIST_COMMAND,
+	    ifnull(PROCESSLIST_STATE, '') as PROCESSLIST_STATE,
+	    COUNT(*)
+	  FROM performance_schema.threads
+	  WHERE PROCESSLIST_COMMAND IN ('Binlog Dump', 'Binlog Dump GTID')
+	  GROUP BY PROCESSLIST_HOST, PROCESSLIST_COMMAND, PROCESSLIST_STATE
+	`
+
+// Metric descriptors.
+var (
+	replicasConnectedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slavehosts, "replicas_connected"),
+		"The number of replicas connected, i.e. of binlog dump threads.",
+		nil, nil,
+	)
+	replicasConnectedByHostDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slavehosts, "replicas_connected_by_host"),
+		"The number of binlog dump threads by replica host and dump command (Binlog Dump or Binlog Dump GTID).",
+		[]string{"host", "command"}, nil,
+	)
+	replicasBinlogDumpStateSecondsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slavehosts, "binlog_dump_state_seconds"),
+		"The longest time a binlog dump thread of the replica host has been in its current state, e.g. waiting for more updates after sending the last binlog event requested.",
+		[]string{"host", "command"}, nil,
+	)
+	replicasBinlogDumpThreadsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slavehosts, "binlog_dump_threads"),
+		"The number of binlog dump threads by replica host, dump command and thread state, e.g. 'Source has sent all binlog to replica; waiting for more updates'.",
+		[]string{"host", "command", "state"}, nil,
+	)
+)
+
+// ScrapeReplicasConnected collects binlog dump threads from `performance_schema.threads`.
+type ScrapeReplicasConnected struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeReplicasConnected) Name() string {
+	return "replicas_connected"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeReplicasConnected) Help() string {
+	return "Collect the number of connected replicas and the state of their binlog dump threads from performance_schema.threads"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeReplicasConnected) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	replicasConnectedRows, err := db.Query(replicasConnectedQuery)
+	if err != nil {
+		return err
+	}
+	defer replicasConnectedRows.Close()
+
+	var (
+		host, command string
+		count, time   uint64
+		total         uint64
+	)
+	for replicasConnectedRows.Next() {
+		if err := replicasConnectedRows.Scan(&host, &command, &count, &time); err != nil {
+			return err
+		}
+		total += count
+		ch <- prometheus.MustNewConstMetric(
+			replicasConnectedByHostDesc, prometheus.GaugeValue, float64(count), host, command,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			replicasBinlogDumpStateSecondsDesc, prometheus.GaugeValue, float64(time), host, command,
+		)
+	}
+	// Always report the total, so that a disconnected replica can be
+	// alerted on even when it was the last one.
+	ch <- prometheus.MustNewConstMetric(
+		replicasConnectedDesc, prometheus.GaugeValue, float64(total),
+	)
+
+	stateRows, err := db.Query(replicasBinlogDumpStateQuery)
+	if err != nil {
+		return err
+	}
+	defer stateRows.Close()
+
+	var state string
+	for stateRows.Next() {
+		if err := stateRows.Scan(&host, &command, &state, &count); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			replicasBinlogDumpThreadsDesc, prometheus.GaugeValue, float64(count), host, command, state,
+		)
+	}
+	return nil
+}
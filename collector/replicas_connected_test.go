@@ -0,0 +1,60 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeReplicasConnected(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"PROCESSLIST_HOST", "PROCESSLIST_COMMAND", "COUNT(*)", "MAX(PROCESSLIST_TIME)"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("10.0.0.2", "Binlog Dump GTID", "1", "3").
+		AddRow("10.0.0.3", "Binlog Dump", "2", "120")
+	mock.ExpectQuery(sanitizeQuery(replicasConnectedQuery)).WillReturnRows(rows)
+	stateColumns := []string{"PROCESSLIST_HOST", "PROCESSLIST_COMMAND", "PROCESSLIST_STATE", "COUNT(*)"}
+	stateRows := sqlmock.NewRows(stateColumns).
+		AddRow("10.0.0.2", "Binlog Dump GTID", "Source has sent all binlog to replica; waiting for more updates", "1").
+		AddRow("10.0.0.3", "Binlog Dump", "Sending binlog event to replica", "1").
+		AddRow("10.0.0.3", "Binlog Dump", "Source has sent all binlog to replica; waiting for more updates", "1")
+	mock.ExpectQuery(sanitizeQuery(replicasBinlogDumpStateQuery)).WillReturnRows(stateRows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeReplicasConnected{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"host": "10.0.0.2", "command": "Binlog Dump GTID"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"host": "10.0.0.2", "command": "Binlog Dump GTID"}, value: 3, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"host": "10.0.0.3", "command": "Binlog Dump"}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"host": "10.0.0.3", "command": "Binlog Dump"}, value: 120, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 3, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"host": "10.0.0.2", "command": "Binlog Dump GTID", "state": "Source has sent all binlog to replica; waiting for more updates"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"host": "10.0.0.3", "command": "Binlog Dump", "state": "Sending binlog event to replica"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"host": "10.0.0.3", "command": "Binlog Dump", "state": "Source has sent all binlog to replica; waiting for more updates"}, value: 1, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,129 @@
+// Scrape the replication configuration from `SHOW GLOBAL VARIABLES` and `SHOW SLAVE STATUS`.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const replicationConfigVariablesQuery = `
+	SHOW GLOBAL VARIABLES WHERE Variable_name IN (
+	  'gtid_mode', 'binlog_format',
+	  'log_slave_updates', 'log_replica_updates',
+	  'slave_parallel_workers', 'replica_parallel_workers'
+	)
+	`
+
+// replicationFilters are the columns of SHOW SLAVE STATUS listing the
+// replication filters, exported as labels of the same name in lower case.
+var replicationFilters = []string{
+	"Replicate_Do_DB",
+	"Replicate_Ignore_DB",
+	"Replicate_Do_Table",
+	"Replicate_Ignore_Table",
+	"Replicate_Wild_Do_Table",
+	"Replicate_Wild_Ignore_Table",
+}
+
+// Metric descriptors.
+var replicationConfigInfoDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "replication", "config_info"),
+	"The replication configuration of the server and, for replicas, the filters of each channel, always 1.",
+	append([]string{
+		"channel_name", "connection_name",
+		"gtid_mode", "binlog_format", "log_replica_updates", "replica_parallel_workers",
+	}, replicationFilterLabels()...), nil,
+)
+
+func replicationFilterLabels() []string {
+	labels := make([]string, len(replicationFilters))
+	for i, filter := range replicationFilters {
+		labels[i] = strings.ToLower(filter)
+	}
+	return labels
+}
+
+// ScrapeReplicationConfig collects the replication configuration.
+type ScrapeReplicationConfig struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeReplicationConfig) Name() string {
+	return "replication_config"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeReplicationConfig) Help() string {
+	return "Collect the replication configuration and filters as labels of mysql_replication_config_info"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeReplicationConfig) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	variablesRows, err := db.Query(replicationConfigVariablesQuery)
+	if err != nil {
+		return err
+	}
+	variables := map[string]string{}
+	var name, value string
+	for variablesRows.Next() {
+		if err := variablesRows.Scan(&name, &value); err != nil {
+			variablesRows.Close()
+			return err
+		}
+		// The replica names replace the slave ones in MySQL 8.0.26.
+		name = strings.Replace(strings.ToLower(name), "slave", "replica", 1)
+		variables[name] = value
+	}
+	variablesRows.Close()
+	if err := variablesRows.Err(); err != nil {
+		return err
+	}
+	server := []string{
+		variables["gtid_mode"], variables["binlog_format"],
+		variables["log_replica_updates"], variables["replica_parallel_workers"],
+	}
+
+	slaveStatusRows, err := querySlaveStatus(db)
+	if err != nil {
+		return err
+	}
+	defer slaveStatusRows.Close()
+	slaveCols, err := slaveStatusRows.Columns()
+	if err != nil {
+		return err
+	}
+
+	channels := 0
+	for slaveStatusRows.Next() {
+		scanArgs := make([]interface{}, len(slaveCols))
+		for i := range scanArgs {
+			scanArgs[i] = &sql.RawBytes{}
+		}
+		if err := slaveStatusRows.Scan(scanArgs...); err != nil {
+			return err
+		}
+		labels := []string{
+			columnValue(scanArgs, slaveCols, "Channel_Name"),    // MySQL & Percona
+			columnValue(scanArgs, slaveCols, "Connection_name"), // MariaDB
+		}
+		labels = append(labels, server...)
+		for _, filter := range replicationFilters {
+			labels = append(labels, columnValue(scanArgs, slaveCols, filter))
+		}
+		ch <- prometheus.MustNewConstMetric(replicationConfigInfoDesc, prometheus.GaugeValue, 1, labels...)
+		channels++
+	}
+	if err := slaveStatusRows.Err(); err != nil {
+		return err
+	}
+
+	// Servers that are not replicas still report their configuration.
+	if channels == 0 {
+		labels := append([]string{"", ""}, server...)
+		labels = append(labels, make([]string, len(replicationFilters))...)
+		ch <- prometheus.MustNewConstMetric(replicationConfigInfoDesc, prometheus.GaugeValue, 1, labels...)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeReplicationConfig(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"Variable_name", "Value"}).
+		AddRow("binlog_format", "ROW").
+		AddRow("gtid_mode", "ON").
+		AddRow("log_slave_updates", "ON").
+		AddRow("slave_parallel_workers", "4")
+	mock.ExpectQuery(sanitizeQuery(replicationConfigVariablesQuery)).WillReturnRows(rows)
+	columns := []string{"Master_Host", "Replicate_Do_DB", "Replicate_Ignore_DB", "Replicate_Wild_Ignore_Table", "Channel_Name"}
+	rows = sqlmock.NewRows(columns).
+		AddRow("10.0.0.1", "", "scratch", "shop.tmp_%", "")
+	mock.ExpectQuery(sanitizeQuery("SHOW SLAVE STATUS")).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeReplicationConfig{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{
+			"channel_name": "", "connection_name": "",
+			"gtid_mode": "ON", "binlog_format": "ROW", "log_replica_updates": "ON", "replica_parallel_workers": "4",
+			"replicate_do_db": "", "replicate_ignore_db": "scratch", "replicate_do_table": "", "replicate_ignore_table": "",
+			"replicate_wild_do_table": "", "replicate_wild_ignore_table": "shop.tmp_%",
+		}, value: 1, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestScrapeReplicationConfigSource(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"Variable_name", "Value"}).
+		AddRow("binlog_format", "ROW").
+		AddRow("gtid_mode", "OFF").
+		AddRow("log_replica_updates", "OFF").
+		AddRow("replica_parallel_workers", "0")
+	mock.ExpectQuery(sanitizeQuery(replicationConfigVariablesQuery)).WillReturnRows(rows)
+	mock.ExpectQuery(sanitizeQuery("SHOW SLAVE STATUS")).WillReturnRows(sqlmock.NewRows([]string{"Master_Host"}))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeReplicationConfig{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("Metrics comparison", t, func() {
+		got := readMetric(<-ch)
+		convey.So(got, convey.ShouldResemble, MetricResult{labels: labelMap{
+			"channel_name": "", "connection_name": "",
+			"gtid_mode": "OFF", "binlog_format": "ROW", "log_replica_updates": "OFF", "replica_parallel_workers": "0",
+			"replicate_do_db": "", "replicate_ignore_db": "", "replicate_do_table": "", "replicate_ignore_table": "",
+			"replicate_wild_do_table": "", "replicate_wild_ignore_table": "",
+		}, value: 1, metricType: dto.MetricType_GAUGE})
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,91 @@
+// Scrape replica read-consistency probes.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const (
+	// replicationConsistency is the Metric subsystem we use.
+	replicationConsistency = "replication_consistency"
+)
+
+// Tunable flags.
+var (
+	replicationConsistencyQuery = kingpin.Flag(
+		"collect.replication_consistency.query",
+		"Probe query returning probe id, staleness in seconds and a mismatch flag for each consistency row written on the primary",
+	).Default("SELECT id, UNIX_TIMESTAMP(NOW(6)) - UNIX_TIMESTAMP(ts), checksum <> CRC32(payload) FROM `heartbeat`.`consistency`").String()
+)
+
+// Metric descriptors.
+var (
+	replicationConsistencyStalenessDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, replicationConsistency, "staleness_seconds"),
+		"Seconds since the consistency row read on this server was written on the primary.",
+		[]string{"probe"}, nil,
+	)
+	replicationConsistencyMismatchDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, replicationConsistency, "mismatch"),
+		"Whether the consistency row read on this server does not match its checksum (1 for mismatch, 0 for match).",
+		[]string{"probe"}, nil,
+	)
+)
+
+// ScrapeReplicationConsistency runs a read-consistency probe against rows
+// written on the primary, for example by a scheduled event:
+// CREATE TABLE consistency (
+//  id                    varchar(64) NOT NULL PRIMARY KEY,
+//  ts                    timestamp(6) NOT NULL,
+//  payload               varchar(255) NOT NULL,
+//  checksum              int unsigned NOT NULL
+// );
+type ScrapeReplicationConsistency struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeReplicationConsistency) Name() string {
+	return replicationConsistency
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeReplicationConsistency) Help() string {
+	return "Collect staleness and mismatch flags from a replica read-consistency probe"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeReplicationConsistency) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	consistencyRows, err := db.Query(*replicationConsistencyQuery)
+	if err != nil {
+		return err
+	}
+	defer consistencyRows.Close()
+
+	var (
+		probe     string
+		staleness float64
+		mismatch  bool
+	)
+
+	for consistencyRows.Next() {
+		if err := consistencyRows.Scan(&probe, &staleness, &mismatch); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			replicationConsistencyStalenessDesc, prometheus.GaugeValue, staleness,
+			probe,
+		)
+		mismatchVal := 0.0
+		if mismatch {
+			mismatchVal = 1
+		}
+		ch <- prometheus.MustNewConstMetric(
+			replicationConsistencyMismatchDesc, prometheus.GaugeValue, mismatchVal,
+			probe,
+		)
+	}
+	return nil
+}
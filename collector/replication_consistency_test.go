@@ -0,0 +1,50 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeReplicationConsistency(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"id", "staleness", "mismatch"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("main", "1.5", "0").
+		AddRow("orders", "120", "1")
+	mock.ExpectQuery(sanitizeQuery(*replicationConsistencyQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeReplicationConsistency{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"probe": "main"}, value: 1.5, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"probe": "main"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"probe": "orders"}, value: 120, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"probe": "orders"}, value: 1, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,109 @@
+// Scrape errant and missing GTIDs from `performance_schema.replication_connection_status`.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Subsystem.
+const replication = "replication"
+
+const replicationGTIDMissingQuery = `
+	SELECT
+	    CHANNEL_NAME,
+	    SOURCE_UUID,
+	    GTID_SUBTRACT(RECEIVED_TRANSACTION_SET, @@GLOBAL.gtid_executed) AS MISSING
+	  FROM performance_schema.replication_connection_status
+	`
+
+// Errant transactions are the ones the replica wrote itself. Comparing
+// gtid_executed with a channel's RECEIVED_TRANSACTION_SET would also count
+// the transactions of every other channel, and everything executed before
+// relay_log_recovery reset the received set.
+const replicationGTIDErrantQuery = `SELECT @@server_uuid, @@GLOBAL.gtid_executed`
+
+// Metric descriptors.
+var (
+	replicationGTIDRangesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, replication, "gtid_ranges"),
+		"The number of GTID intervals executed on the replica with its own server_uuid (errant) or received from the source but not yet executed (missing).",
+		[]string{"channel_name", "source_uuid", "kind"}, nil,
+	)
+	replicationGTIDTransactionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, replication, "gtid_transactions"),
+		"The number of transactions executed on the replica with its own server_uuid (errant) or received from the source but not yet executed (missing).",
+		[]string{"channel_name", "source_uuid", "kind"}, nil,
+	)
+)
+
+// ScrapeReplicationGTIDErrant collects errant GTIDs and the GTIDs received but not yet executed.
+type ScrapeReplicationGTIDErrant struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeReplicationGTIDErrant) Name() string {
+	return replication + ".gtid_errant"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeReplicationGTIDErrant) Help() string {
+	return "Collect errant GTIDs written by the replica itself and the GTIDs received from each source but not yet executed"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeReplicationGTIDErrant) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	replicationGTIDMissingRows, err := db.Query(replicationGTIDMissingQuery)
+	if err != nil {
+		return err
+	}
+	defer replicationGTIDMissingRows.Close()
+
+	var (
+		channels                         int
+		channelName, sourceUUID, missing string
+	)
+	for replicationGTIDMissingRows.Next() {
+		if err := replicationGTIDMissingRows.Scan(&channelName, &sourceUUID, &missing); err != nil {
+			return err
+		}
+		channels++
+		sendGTIDSetSize(ch, parseGTIDSet(missing), channelName, sourceUUID, "missing")
+	}
+	if err := replicationGTIDMissingRows.Err(); err != nil {
+		return err
+	}
+	// Not a replica, so nothing it wrote is errant.
+	if channels == 0 {
+		return nil
+	}
+
+	var serverUUID, gtidExecuted string
+	if err := db.QueryRow(replicationGTIDErrantQuery).Scan(&serverUUID, &gtidExecuted); err != nil {
+		return err
+	}
+	serverUUID = strings.ToLower(serverUUID)
+	errant := gtidSet{}
+	for source, intervals := range parseGTIDSet(gtidExecuted) {
+		// Tagged GTIDs are keyed as uuid:tag.
+		if source == serverUUID || strings.HasPrefix(source, serverUUID+":") {
+			errant[source] = intervals
+		}
+	}
+	sendGTIDSetSize(ch, errant, "", serverUUID, "errant")
+	return nil
+}
+
+func sendGTIDSetSize(ch chan<- prometheus.Metric, set gtidSet, channelName, sourceUUID, kind string) {
+	ranges, transactions := set.size()
+	ch <- prometheus.MustNewConstMetric(
+		replicationGTIDRangesDesc, prometheus.GaugeValue, float64(ranges),
+		channelName, sourceUUID, kind,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		replicationGTIDTransactionsDesc, prometheus.GaugeValue, float64(transactions),
+		channelName, sourceUUID, kind,
+	)
+}
@@ -0,0 +1,105 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeReplicationGTIDErrant(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	source := "3e11fa47-71ca-11e1-9e33-c80aa9429562"
+	replica := "5e11fa47-71ca-11e1-9e33-c80aa9429562"
+	columns := []string{"CHANNEL_NAME", "SOURCE_UUID", "MISSING"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("", source, source+":101-110")
+	mock.ExpectQuery(sanitizeQuery(replicationGTIDMissingQuery)).WillReturnRows(rows)
+	rows = sqlmock.NewRows([]string{"@@server_uuid", "@@GLOBAL.gtid_executed"}).
+		AddRow(replica, source+":1-100,\n"+replica+":1-3:8")
+	mock.ExpectQuery(sanitizeQuery(replicationGTIDErrantQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeReplicationGTIDErrant{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"channel_name": "", "source_uuid": source, "kind": "missing"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "", "source_uuid": source, "kind": "missing"}, value: 10, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "", "source_uuid": replica, "kind": "errant"}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "", "source_uuid": replica, "kind": "errant"}, value: 4, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestScrapeReplicationGTIDErrantMultiSource(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	sourceA := "3e11fa47-71ca-11e1-9e33-c80aa9429562"
+	sourceB := "4e11fa47-71ca-11e1-9e33-c80aa9429562"
+	replica := "5e11fa47-71ca-11e1-9e33-c80aa9429562"
+	columns := []string{"CHANNEL_NAME", "SOURCE_UUID", "MISSING"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("a", sourceA, "").
+		AddRow("b", sourceB, sourceB+":51-52")
+	mock.ExpectQuery(sanitizeQuery(replicationGTIDMissingQuery)).WillReturnRows(rows)
+	// Each channel only received its own source's transactions, and channel
+	// b lost the start of its received set to relay_log_recovery. None of
+	// that is errant: only the replica's own GTIDs are.
+	rows = sqlmock.NewRows([]string{"@@server_uuid", "@@GLOBAL.gtid_executed"}).
+		AddRow(replica, sourceA+":1-100,"+sourceB+":1-50")
+	mock.ExpectQuery(sanitizeQuery(replicationGTIDErrantQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeReplicationGTIDErrant{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"channel_name": "a", "source_uuid": sourceA, "kind": "missing"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "a", "source_uuid": sourceA, "kind": "missing"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "b", "source_uuid": sourceB, "kind": "missing"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "b", "source_uuid": sourceB, "kind": "missing"}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "", "source_uuid": replica, "kind": "errant"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel_name": "", "source_uuid": replica, "kind": "errant"}, value: 0, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,122 @@
+// Scrape the replication role of the server.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	replicationRoleReadOnlyQuery = `
+	SHOW GLOBAL VARIABLES WHERE Variable_name IN ('read_only', 'super_read_only')
+	`
+	replicationRoleReplicasQuery = `
+	SELECT COUNT(*)
+	  FROM performance_schema.threads
+	  WHERE PROCESSLIST_COMMAND IN ('Binlog Dump', 'Binlog Dump GTID')
+	`
+)
+
+// replicationRoles are the possible roles of the server, exported as a state set.
+var replicationRoles = []string{"source", "replica", "intermediate"}
+
+// Metric descriptors.
+var replicationRoleDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "replication", "role"),
+	"Whether the server has the replication role: a source, a replica, or an intermediate replica with replicas of its own.",
+	[]string{"role"}, nil,
+)
+
+// ScrapeReplicationRole collects the replication role of the server.
+type ScrapeReplicationRole struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeReplicationRole) Name() string {
+	return "replication_role"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeReplicationRole) Help() string {
+	return "Collect the replication role from read_only, the replication channels and the connected replicas"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeReplicationRole) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	readOnlyRows, err := db.Query(replicationRoleReadOnlyQuery)
+	if err != nil {
+		return err
+	}
+	readOnly := false
+	var name, value string
+	for readOnlyRows.Next() {
+		if err := readOnlyRows.Scan(&name, &value); err != nil {
+			readOnlyRows.Close()
+			return err
+		}
+		if on, ok := parseStatus([]byte(value)); ok && on == 1 {
+			readOnly = true
+		}
+	}
+	readOnlyRows.Close()
+	if err := readOnlyRows.Err(); err != nil {
+		return err
+	}
+
+	slaveStatusRows, err := querySlaveStatus(db)
+	if err != nil {
+		return err
+	}
+	slaveCols, err := slaveStatusRows.Columns()
+	if err != nil {
+		slaveStatusRows.Close()
+		return err
+	}
+	channels := 0
+	for slaveStatusRows.Next() {
+		scanArgs := make([]interface{}, len(slaveCols))
+		for i := range scanArgs {
+			scanArgs[i] = &sql.RawBytes{}
+		}
+		if err := slaveStatusRows.Scan(scanArgs...); err != nil {
+			slaveStatusRows.Close()
+			return err
+		}
+		if columnValue(scanArgs, slaveCols, "Master_Host") != "" || columnValue(scanArgs, slaveCols, "Source_Host") != "" {
+			channels++
+		}
+	}
+	slaveStatusRows.Close()
+	if err := slaveStatusRows.Err(); err != nil {
+		return err
+	}
+
+	var replicas uint64
+	if err := db.QueryRow(replicationRoleReplicasQuery).Scan(&replicas); err != nil {
+		return err
+	}
+
+	role := replicationRole(readOnly, channels, replicas)
+	for _, r := range replicationRoles {
+		active := 0.0
+		if r == role {
+			active = 1
+		}
+		ch <- prometheus.MustNewConstMetric(replicationRoleDesc, prometheus.GaugeValue, active, r)
+	}
+	return nil
+}
+
+// replicationRole derives the role of the server. A read only server
+// without replication channels, e.g. a replica whose replication was reset,
+// is still a replica.
+func replicationRole(readOnly bool, channels int, replicas uint64) string {
+	switch {
+	case channels > 0 && replicas > 0:
+		return "intermediate"
+	case channels > 0 || readOnly:
+		return "replica"
+	}
+	return "source"
+}
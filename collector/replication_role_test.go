@@ -0,0 +1,61 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeReplicationRole(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"Variable_name", "Value"}).
+		AddRow("read_only", "ON").
+		AddRow("super_read_only", "ON")
+	mock.ExpectQuery(sanitizeQuery(replicationRoleReadOnlyQuery)).WillReturnRows(rows)
+	rows = sqlmock.NewRows([]string{"Master_Host", "Channel_Name"}).AddRow("10.0.0.1", "")
+	mock.ExpectQuery(sanitizeQuery("SHOW SLAVE STATUS")).WillReturnRows(rows)
+	mock.ExpectQuery(sanitizeQuery(replicationRoleReplicasQuery)).WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeReplicationRole{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"role": "source"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"role": "replica"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"role": "intermediate"}, value: 1, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestReplicationRole(t *testing.T) {
+	convey.Convey("Replication role", t, func() {
+		convey.So(replicationRole(false, 0, 0), convey.ShouldEqual, "source")
+		convey.So(replicationRole(false, 0, 3), convey.ShouldEqual, "source")
+		convey.So(replicationRole(true, 1, 0), convey.ShouldEqual, "replica")
+		convey.So(replicationRole(true, 0, 0), convey.ShouldEqual, "replica")
+		convey.So(replicationRole(true, 1, 2), convey.ShouldEqual, "intermediate")
+	})
+}
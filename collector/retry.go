@@ -0,0 +1,80 @@
+package collector
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// MySQL error numbers considered transient.
+const (
+	errLockWaitTimeout = 1205
+	errLockDeadlock    = 1213
+)
+
+// Tunable flags.
+var (
+	scrapeRetryCount = kingpin.Flag(
+		"exporter.retry.count",
+		"Number of times a collector is retried after a transient error (bad connection, deadlock, lock wait timeout).",
+	).Default("0").Int()
+	scrapeRetryBackoff = kingpin.Flag(
+		"exporter.retry.backoff",
+		"Time to wait before the first retry of a collector. Doubled on every further retry.",
+	).Default("100ms").Duration()
+)
+
+// isTransientError reports whether err is likely to go away when the query is retried.
+func isTransientError(err error) bool {
+	switch err {
+	case driver.ErrBadConn, mysql.ErrInvalidConn:
+		return true
+	}
+	if mysqlErr, ok := err.(*mysql.MySQLError); ok {
+		switch mysqlErr.Number {
+		case errLockWaitTimeout, errLockDeadlock:
+			return true
+		}
+	}
+	return false
+}
+
+// scrapeWithRetry runs the scraper, retrying it with exponential backoff on
+// transient errors. Metrics of a failed attempt are discarded so a retry
+// never sends the same series twice.
+func scrapeWithRetry(db *sql.DB, scraper Scraper, ch chan<- prometheus.Metric) error {
+	if *scrapeRetryCount <= 0 {
+		return scraper.Scrape(db, ch)
+	}
+
+	backoff := *scrapeRetryBackoff
+	for attempt := 0; ; attempt++ {
+		buf := make(chan prometheus.Metric)
+		done := make(chan []prometheus.Metric)
+		go func() {
+			metrics := []prometheus.Metric{}
+			for m := range buf {
+				metrics = append(metrics, m)
+			}
+			done <- metrics
+		}()
+		err := scraper.Scrape(db, buf)
+		close(buf)
+		metrics := <-done
+
+		if err == nil || attempt >= *scrapeRetryCount || !isTransientError(err) {
+			for _, m := range metrics {
+				ch <- m
+			}
+			return err
+		}
+		log.Debugf("Retrying collect.%s in %s after transient error: %s", scraper.Name(), backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
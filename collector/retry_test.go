@@ -0,0 +1,76 @@
+package collector
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+var flakyDesc = prometheus.NewDesc("mysql_flaky", "Test metric.", nil, nil)
+
+// flakyScraper sends a metric and fails with err for the first failures attempts.
+type flakyScraper struct {
+	attempts *int
+	failures int
+	err      error
+}
+
+func (flakyScraper) Name() string { return "flaky" }
+func (flakyScraper) Help() string { return "Fail a number of times" }
+func (s flakyScraper) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	*s.attempts++
+	ch <- prometheus.MustNewConstMetric(flakyDesc, prometheus.GaugeValue, float64(*s.attempts))
+	if *s.attempts <= s.failures {
+		return s.err
+	}
+	return nil
+}
+
+func TestScrapeWithRetry(t *testing.T) {
+	*scrapeRetryCount = 2
+	*scrapeRetryBackoff = time.Millisecond
+	defer func() { *scrapeRetryCount = 0 }()
+
+	run := func(s flakyScraper) ([]MetricResult, error) {
+		ch := make(chan prometheus.Metric)
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- scrapeWithRetry(nil, s, ch)
+			close(ch)
+		}()
+		got := []MetricResult{}
+		for m := range ch {
+			got = append(got, readMetric(m))
+		}
+		return got, <-errCh
+	}
+
+	convey.Convey("Transient error is retried", t, func() {
+		attempts := 0
+		got, err := run(flakyScraper{attempts: &attempts, failures: 1, err: &mysql.MySQLError{Number: 1213}})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(attempts, convey.ShouldEqual, 2)
+		convey.So(got, convey.ShouldResemble, []MetricResult{{labels: labelMap{}, value: 2, metricType: dto.MetricType_GAUGE}})
+	})
+
+	convey.Convey("Retries are bounded", t, func() {
+		attempts := 0
+		_, err := run(flakyScraper{attempts: &attempts, failures: 5, err: driver.ErrBadConn})
+		convey.So(err, convey.ShouldEqual, driver.ErrBadConn)
+		convey.So(attempts, convey.ShouldEqual, 3)
+	})
+
+	convey.Convey("Other errors are not retried", t, func() {
+		attempts := 0
+		_, err := run(flakyScraper{attempts: &attempts, failures: 1, err: fmt.Errorf("syntax error")})
+		convey.So(err, convey.ShouldNotBeNil)
+		convey.So(attempts, convey.ShouldEqual, 1)
+	})
+}
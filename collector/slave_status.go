@@ -15,6 +15,17 @@ const (
 	slaveStatus = "slave_status"
 )
 
+// slaveThreadStates lists the possible states of the replication threads,
+// exported as state sets so that dashboards can show why a thread is down.
+var slaveThreadStates = []struct {
+	name    string
+	columns []string
+	states  []string
+}{
+	{"io_running", []string{"Slave_IO_Running", "Replica_IO_Running"}, []string{"yes", "no", "connecting"}},
+	{"sql_running", []string{"Slave_SQL_Running", "Replica_SQL_Running"}, []string{"yes", "no"}},
+}
+
 var slaveStatusQueries = [2]string{"SHOW ALL SLAVES STATUS", "SHOW SLAVE STATUS"}
 var slaveStatusQuerySuffixes = [3]string{" NONBLOCKING", " NOLOCK", ""}
 
@@ -35,21 +46,8 @@ func columnValue(scanArgs []interface{}, slaveCols []string, colName string) str
 	return string(*scanArgs[columnIndex].(*sql.RawBytes))
 }
 
-// ScrapeSlaveStatus collects from `SHOW SLAVE STATUS`.
-type ScrapeSlaveStatus struct{}
-
-// Name of the Scraper. Should be unique.
-func (ScrapeSlaveStatus) Name() string {
-	return slaveStatus
-}
-
-// Help describes the role of the Scraper.
-func (ScrapeSlaveStatus) Help() string {
-	return "Collect from SHOW SLAVE STATUS"
-}
-
-// Scrape collects data from database connection and sends it over channel as prometheus metric.
-func (ScrapeSlaveStatus) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+// querySlaveStatus runs the SHOW SLAVE STATUS syntax supported by the server.
+func querySlaveStatus(db *sql.DB) (*sql.Rows, error) {
 	var (
 		slaveStatusRows *sql.Rows
 		err             error
@@ -69,6 +67,25 @@ func (ScrapeSlaveStatus) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
 			break
 		}
 	}
+	return slaveStatusRows, err
+}
+
+// ScrapeSlaveStatus collects from `SHOW SLAVE STATUS`.
+type ScrapeSlaveStatus struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeSlaveStatus) Name() string {
+	return slaveStatus
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeSlaveStatus) Help() string {
+	return "Collect from SHOW SLAVE STATUS"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeSlaveStatus) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	slaveStatusRows, err := querySlaveStatus(db)
 	if err != nil {
 		return err
 	}
@@ -112,6 +129,72 @@ func (ScrapeSlaveStatus) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
 				)
 			}
 		}
+
+		// Seconds_Behind_Master is NULL when replication is broken, in which
+		// case it is not exported at all, rather than as a misleading 0.
+		for _, col := range []string{"Seconds_Behind_Master", "Seconds_Behind_Source"} {
+			idx := columnIndex(slaveCols, col)
+			if idx == -1 {
+				continue
+			}
+			unknown := 0.0
+			if *scanArgs[idx].(*sql.RawBytes) == nil {
+				unknown = 1
+			}
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName(namespace, slaveStatus, "sql_delay_unknown"),
+					"Whether the replication delay is unknown, i.e. Seconds_Behind_Master is NULL.",
+					[]string{"master_host", "master_uuid", "channel_name", "connection_name"},
+					nil,
+				),
+				prometheus.GaugeValue,
+				unknown,
+				masterHost, masterUUID, channelName, connectionName,
+			)
+			break
+		}
+
+		for _, thread := range slaveThreadStates {
+			var (
+				value string
+				found bool
+			)
+			for _, col := range thread.columns {
+				if columnIndex(slaveCols, col) != -1 {
+					value = strings.ToLower(columnValue(scanArgs, slaveCols, col))
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+			desc := prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, slaveStatus, thread.name),
+				"Whether the replication thread is in the given state.",
+				[]string{"master_host", "master_uuid", "channel_name", "connection_name", "state"},
+				nil,
+			)
+			known := false
+			for _, state := range thread.states {
+				active := 0.0
+				if state == value {
+					active = 1
+					known = true
+				}
+				ch <- prometheus.MustNewConstMetric(
+					desc, prometheus.GaugeValue, active,
+					masterHost, masterUUID, channelName, connectionName, state,
+				)
+			}
+			if !known && value != "" {
+				ch <- prometheus.MustNewConstMetric(
+					desc, prometheus.GaugeValue, 1,
+					masterHost, masterUUID, channelName, connectionName, value,
+				)
+			}
+		}
 	}
 	return nil
 }
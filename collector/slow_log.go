@@ -0,0 +1,314 @@
+// Scrape `mysql.slow_log`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// Subsystem.
+const slowLog = "slow_log"
+
+const (
+	slowLogOutputQuery = `SELECT @@log_output, @@hostname, @@port`
+	slowLogCursorQuery = `
+	SELECT start_time, thread_id
+	  FROM mysql.slow_log
+	  ORDER BY start_time DESC, thread_id DESC
+	  LIMIT 1
+	`
+	// slowLogQuery pages through the slow log on start_time and thread_id,
+	// so that rows sharing the start_time of the last row read are not
+	// skipped when the row limit cuts through them.
+	slowLogQuery = `
+	SELECT
+	    start_time,
+	    thread_id,
+	    user_host,
+	    TIME_TO_SEC(query_time) + MICROSECOND(query_time) / 1000000,
+	    rows_examined,
+	    CONVERT(sql_text USING utf8)
+	  FROM mysql.slow_log
+	  WHERE start_time > ? OR (start_time = ? AND thread_id > ?)
+	  ORDER BY start_time, thread_id
+	  LIMIT %d
+	`
+)
+
+// Tunable flags.
+var (
+	slowLogLimit = kingpin.Flag(
+		"collect.slow_log.limit",
+		"Maximum number of user and statement combinations tracked, further ones are counted as 'other'",
+	).Default("100").Int()
+	slowLogRowLimit = kingpin.Flag(
+		"collect.slow_log.row_limit",
+		"Maximum number of mysql.slow_log rows read per scrape",
+	).Default("10000").Int()
+	slowLogDigestTextLimit = kingpin.Flag(
+		"collect.slow_log.digest_text_limit",
+		"Maximum length of the normalized statement text",
+	).Default("120").Int()
+	slowLogExpiry = kingpin.Flag(
+		"collect.slow_log.expiry",
+		"Time after which a user and statement combination without new slow queries, or a server no longer scraped, stops being tracked",
+	).Default("1h").Duration()
+)
+
+// Metric descriptors.
+var (
+	slowLogQueriesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slowLog, "queries_total"),
+		"The number of slow queries by user and normalized statement.",
+		[]string{"user", "statement"}, nil,
+	)
+	slowLogQuerySecondsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slowLog, "query_seconds_total"),
+		"The total execution time of slow queries by user and normalized statement.",
+		[]string{"user", "statement"}, nil,
+	)
+	slowLogRowsExaminedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, slowLog, "rows_examined_total"),
+		"The number of rows examined by slow queries by user and normalized statement.",
+		[]string{"user", "statement"}, nil,
+	)
+)
+
+var (
+	slowLogStringRE   = regexp.MustCompile(`'(?:[^'\\]|\\.)*'|"(?:[^"\\]|\\.)*"`)
+	slowLogNumberRE   = regexp.MustCompile(`\b\d+(?:\.\d+)?\b`)
+	slowLogListRE     = regexp.MustCompile(`\(\s*\?(?:\s*,\s*\?)*\s*\)`)
+	slowLogSpaceRE    = regexp.MustCompile(`\s+`)
+	slowLogUserHostRE = regexp.MustCompile(`^([^\[]*)\[`)
+)
+
+// slowLogCursor is the position of the last slow log row read.
+type slowLogCursor struct {
+	startTime string
+	threadID  uint64
+}
+
+// after reports whether c comes after other in the slow log. start_time is
+// returned in a fixed format, so it sorts as a string.
+func (c slowLogCursor) after(other slowLogCursor) bool {
+	return c.startTime > other.startTime || (c.startTime == other.startTime && c.threadID > other.threadID)
+}
+
+type slowLogKey struct {
+	user, statement string
+}
+
+type slowLogStatement struct {
+	queries, seconds, rowsExamined float64
+	lastSeen                       time.Time
+}
+
+type slowLogRow struct {
+	cursor       slowLogCursor
+	key          slowLogKey
+	queryTime    float64
+	rowsExamined uint64
+}
+
+// slowLogState is the read position and the counters of the slow log of a
+// server. The slow log is read incrementally, so they are kept across scrapes.
+type slowLogState struct {
+	cursor     *slowLogCursor
+	keys       []slowLogKey
+	statements map[slowLogKey]*slowLogStatement
+	lastScrape time.Time
+}
+
+// slowLogServers holds the state of every server. The mutex is only held
+// while the state is read or updated, not while querying.
+var slowLogServers = struct {
+	sync.Mutex
+	byServer map[string]*slowLogState
+}{byServer: map[string]*slowLogState{}}
+
+// ScrapeSlowLog collects from `mysql.slow_log`.
+type ScrapeSlowLog struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeSlowLog) Name() string {
+	return slowLog
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeSlowLog) Help() string {
+	return "Collect slow query counts, time and rows examined by user and statement from mysql.slow_log when log_output includes TABLE"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeSlowLog) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var logOutput, host, port string
+	if err := db.QueryRow(slowLogOutputQuery).Scan(&logOutput, &host, &port); err != nil {
+		return err
+	}
+	if !strings.Contains(strings.ToUpper(logOutput), "TABLE") {
+		return nil
+	}
+	server := host + ":" + port
+
+	slowLogServers.Lock()
+	var cursor *slowLogCursor
+	if state, ok := slowLogServers.byServer[server]; ok && state.cursor != nil {
+		cursor = &slowLogCursor{}
+		*cursor = *state.cursor
+	}
+	slowLogServers.Unlock()
+
+	// Start from the end of the table, so that the counters begin at zero
+	// and the existing backlog is not read.
+	if cursor == nil {
+		cursor = &slowLogCursor{}
+		err := db.QueryRow(slowLogCursorQuery).Scan(&cursor.startTime, &cursor.threadID)
+		if err == sql.ErrNoRows {
+			cursor.startTime = "1970-01-01 00:00:00"
+		} else if err != nil {
+			return err
+		}
+	}
+
+	slowLogRows, err := db.Query(fmt.Sprintf(slowLogQuery, *slowLogRowLimit), cursor.startTime, cursor.startTime, cursor.threadID)
+	if err != nil {
+		return err
+	}
+	defer slowLogRows.Close()
+
+	var (
+		rows              []slowLogRow
+		userHost, sqlText string
+	)
+	for slowLogRows.Next() {
+		var row slowLogRow
+		if err := slowLogRows.Scan(&row.cursor.startTime, &row.cursor.threadID, &userHost, &row.queryTime, &row.rowsExamined, &sqlText); err != nil {
+			return err
+		}
+		row.key = slowLogKey{user: slowLogUser(userHost), statement: normalizeStatement(sqlText, *slowLogDigestTextLimit)}
+		rows = append(rows, row)
+	}
+	if err := slowLogRows.Err(); err != nil {
+		return err
+	}
+
+	metrics := updateSlowLogState(server, cursor, rows, time.Now())
+	for _, metric := range metrics {
+		ch <- metric
+	}
+	return nil
+}
+
+// updateSlowLogState adds the rows read from the slow log of the server to
+// its counters and returns their metrics. Rows already counted by a
+// concurrent scrape of the same server are skipped.
+func updateSlowLogState(server string, cursor *slowLogCursor, rows []slowLogRow, now time.Time) []prometheus.Metric {
+	slowLogServers.Lock()
+	defer slowLogServers.Unlock()
+
+	for name, state := range slowLogServers.byServer {
+		if name != server && now.Sub(state.lastScrape) > *slowLogExpiry {
+			delete(slowLogServers.byServer, name)
+		}
+	}
+	state, ok := slowLogServers.byServer[server]
+	if !ok {
+		state = &slowLogState{statements: map[slowLogKey]*slowLogStatement{}}
+		slowLogServers.byServer[server] = state
+	}
+	if state.cursor == nil {
+		state.cursor = cursor
+	}
+	state.lastScrape = now
+
+	// Drop the combinations without new slow queries, making room for
+	// new ones below the limit.
+	keys := state.keys[:0]
+	for _, key := range state.keys {
+		if now.Sub(state.statements[key].lastSeen) > *slowLogExpiry {
+			delete(state.statements, key)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	state.keys = keys
+
+	for _, row := range rows {
+		if !row.cursor.after(*state.cursor) {
+			continue
+		}
+		*state.cursor = row.cursor
+
+		key := row.key
+		counters, ok := state.statements[key]
+		if !ok {
+			if len(state.keys) >= *slowLogLimit {
+				key = slowLogKey{user: "other", statement: "other"}
+				counters, ok = state.statements[key]
+			}
+			if !ok {
+				counters = &slowLogStatement{}
+				state.statements[key] = counters
+				state.keys = append(state.keys, key)
+			}
+		}
+		counters.queries++
+		counters.seconds += row.queryTime
+		counters.rowsExamined += float64(row.rowsExamined)
+		counters.lastSeen = now
+	}
+
+	metrics := make([]prometheus.Metric, 0, 3*len(state.keys))
+	for _, key := range state.keys {
+		counters := state.statements[key]
+		metrics = append(metrics,
+			prometheus.MustNewConstMetric(
+				slowLogQueriesDesc, prometheus.CounterValue, counters.queries, key.user, key.statement,
+			),
+			prometheus.MustNewConstMetric(
+				slowLogQuerySecondsDesc, prometheus.CounterValue, counters.seconds, key.user, key.statement,
+			),
+			prometheus.MustNewConstMetric(
+				slowLogRowsExaminedDesc, prometheus.CounterValue, counters.rowsExamined, key.user, key.statement,
+			),
+		)
+	}
+	return metrics
+}
+
+// slowLogUser extracts the user from a `user[user] @ host [ip]` user_host.
+func slowLogUser(userHost string) string {
+	userHost = strings.ToValidUTF8(userHost, "?")
+	if match := slowLogUserHostRE.FindStringSubmatch(userHost); match != nil {
+		return strings.TrimSpace(match[1])
+	}
+	return userHost
+}
+
+// normalizeStatement replaces literals with placeholders, similar to
+// performance_schema statement digests, and truncates the result. The
+// result is valid UTF-8, as required for label values.
+func normalizeStatement(statement string, limit int) string {
+	statement = strings.ToValidUTF8(statement, "?")
+	statement = slowLogStringRE.ReplaceAllString(statement, "?")
+	statement = slowLogNumberRE.ReplaceAllString(statement, "?")
+	statement = slowLogListRE.ReplaceAllString(statement, "(...)")
+	statement = strings.TrimSpace(slowLogSpaceRE.ReplaceAllString(statement, " "))
+	if len(statement) > limit {
+		// Do not cut a multi-byte character in half.
+		for limit > 0 && !utf8.RuneStart(statement[limit]) {
+			limit--
+		}
+		statement = statement[:limit]
+	}
+	return statement
+}
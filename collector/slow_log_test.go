@@ -0,0 +1,129 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeSlowLog(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	outputColumns := []string{"@@log_output", "@@hostname", "@@port"}
+	mock.ExpectQuery(sanitizeQuery(slowLogOutputQuery)).WillReturnRows(sqlmock.NewRows(outputColumns).AddRow("FILE,TABLE", "db1", "3306"))
+	cursorColumns := []string{"start_time", "thread_id"}
+	mock.ExpectQuery(sanitizeQuery(slowLogCursorQuery)).WillReturnRows(sqlmock.NewRows(cursorColumns).AddRow("2018-01-01 00:00:00.000000", "12"))
+	columns := []string{"start_time", "thread_id", "user_host", "query_time", "rows_examined", "sql_text"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("2018-01-01 00:00:00.000000", "15", "app[app] @  [10.0.0.1]", "1.5", "1000", "SELECT * FROM t1 WHERE id IN (1, 2, 3)").
+		AddRow("2018-01-01 00:00:02.000000", "12", "app[app] @  [10.0.0.1]", "2.5", "3000", "SELECT * FROM t1 WHERE id IN (4,5)").
+		AddRow("2018-01-01 00:00:02.000000", "13", "root[root] @ localhost []", "10", "5", "UPDATE t2 SET c = 'x' WHERE id = 7")
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(slowLogQuery, 10000))).WithArgs("2018-01-01 00:00:00.000000", "2018-01-01 00:00:00.000000", 12).WillReturnRows(rows)
+
+	// Another server starts from the end of its own slow log.
+	mock.ExpectQuery(sanitizeQuery(slowLogOutputQuery)).WillReturnRows(sqlmock.NewRows(outputColumns).AddRow("TABLE", "db2", "3306"))
+	// Its slow log is empty.
+	mock.ExpectQuery(sanitizeQuery(slowLogCursorQuery)).WillReturnRows(sqlmock.NewRows(cursorColumns))
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(slowLogQuery, 10000))).WithArgs("1970-01-01 00:00:00", "1970-01-01 00:00:00", 0).WillReturnRows(sqlmock.NewRows(columns))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		for i := 0; i < 2; i++ {
+			if err = (ScrapeSlowLog{}).Scrape(db, ch); err != nil {
+				t.Errorf("error calling function on test: %s", err)
+			}
+		}
+		close(ch)
+	}()
+
+	selectLabels := labelMap{"user": "app", "statement": "SELECT * FROM t1 WHERE id IN (...)"}
+	updateLabels := labelMap{"user": "root", "statement": "UPDATE t2 SET c = ? WHERE id = ?"}
+	expected := []MetricResult{
+		{labels: selectLabels, value: 2, metricType: dto.MetricType_COUNTER},
+		{labels: selectLabels, value: 4, metricType: dto.MetricType_COUNTER},
+		{labels: selectLabels, value: 4000, metricType: dto.MetricType_COUNTER},
+		{labels: updateLabels, value: 1, metricType: dto.MetricType_COUNTER},
+		{labels: updateLabels, value: 10, metricType: dto.MetricType_COUNTER},
+		{labels: updateLabels, value: 5, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, ok := <-ch
+		convey.So(ok, convey.ShouldBeFalse)
+		convey.So(*slowLogServers.byServer["db1:3306"].cursor, convey.ShouldResemble, slowLogCursor{"2018-01-01 00:00:02.000000", 13})
+		convey.So(*slowLogServers.byServer["db2:3306"].cursor, convey.ShouldResemble, slowLogCursor{"1970-01-01 00:00:00", 0})
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestUpdateSlowLogState(t *testing.T) {
+	*slowLogExpiry = time.Hour
+	now := time.Now()
+	slowLogServers.byServer = map[string]*slowLogState{}
+
+	row := func(startTime string, threadID uint64, statement string) slowLogRow {
+		return slowLogRow{
+			cursor:    slowLogCursor{startTime, threadID},
+			key:       slowLogKey{user: "app", statement: statement},
+			queryTime: 1,
+		}
+	}
+	convey.Convey("Slow log state", t, func() {
+		updateSlowLogState("db1:3306", &slowLogCursor{"2018-01-01 00:00:00", 0}, []slowLogRow{
+			row("2018-01-01 00:00:01", 1, "SELECT ?"),
+			row("2018-01-01 00:00:01", 2, "SELECT ?"),
+		}, now.Add(-2*time.Hour))
+		updateSlowLogState("db2:3306", &slowLogCursor{"2018-01-01 00:00:00", 0}, nil, now.Add(-2*time.Hour))
+
+		convey.Convey("Rows already counted by a concurrent scrape are skipped", func() {
+			metrics := updateSlowLogState("db1:3306", &slowLogCursor{"2018-01-01 00:00:00", 0}, []slowLogRow{
+				row("2018-01-01 00:00:01", 2, "SELECT ?"),
+				row("2018-01-01 00:00:01", 3, "UPDATE t SET c = ?"),
+			}, now.Add(-2*time.Hour))
+			convey.So(metrics, convey.ShouldHaveLength, 6)
+			convey.So(readMetric(metrics[0]).value, convey.ShouldEqual, 2)
+			convey.So(readMetric(metrics[3]).value, convey.ShouldEqual, 1)
+		})
+		convey.Convey("Stale statements and servers are dropped", func() {
+			metrics := updateSlowLogState("db1:3306", nil, []slowLogRow{
+				row("2018-01-01 00:00:02", 1, "DELETE FROM t"),
+			}, now)
+			convey.So(metrics, convey.ShouldHaveLength, 3)
+			convey.So(readMetric(metrics[0]).labels, convey.ShouldResemble, labelMap{"user": "app", "statement": "DELETE FROM t"})
+			convey.So(slowLogServers.byServer, convey.ShouldNotContainKey, "db2:3306")
+		})
+	})
+}
+
+func TestNormalizeStatement(t *testing.T) {
+	convey.Convey("Normalize statement", t, func() {
+		convey.So(normalizeStatement("SELECT * FROM t WHERE a = 'x' AND b IN (1, 2)", 120), convey.ShouldEqual, "SELECT * FROM t WHERE a = ? AND b IN (...)")
+		convey.Convey("Truncated on a character boundary", func() {
+			// "é" takes bytes 17 and 18, a cut at 18 would split it.
+			statement := normalizeStatement("SELECT * FROM café", 18)
+			convey.So(statement, convey.ShouldEqual, "SELECT * FROM caf")
+			convey.So(utf8.ValidString(statement), convey.ShouldBeTrue)
+		})
+		convey.Convey("Invalid UTF-8 is replaced", func() {
+			statement := normalizeStatement("SELECT * FROM t\xff", 120)
+			convey.So(statement, convey.ShouldEqual, "SELECT * FROM t?")
+		})
+	})
+}
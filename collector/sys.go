@@ -0,0 +1,4 @@
+package collector
+
+// Subsystem.
+const sysSchema = "sys"
@@ -0,0 +1,98 @@
+// Scrape `sys.x$host_summary_by_statement_type`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const sysHostSummaryQuery = `
+	SELECT
+	    host,
+	    SUM(total),
+	    SUM(total_latency),
+	    SUM(rows_sent),
+	    SUM(rows_examined),
+	    SUM(rows_affected)
+	  FROM sys.x$host_summary_by_statement_type
+	  GROUP BY host
+	`
+
+// Metric descriptors.
+var (
+	sysHostSummaryStatementsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, sysSchema, "host_summary_statements_total"),
+		"The total number of statements executed by client host.",
+		[]string{"host"}, nil,
+	)
+	sysHostSummaryStatementLatencyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, sysSchema, "host_summary_statement_seconds_total"),
+		"The total time spent executing statements by client host.",
+		[]string{"host"}, nil,
+	)
+	sysHostSummaryRowsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, sysSchema, "host_summary_rows_total"),
+		"The total number of rows sent, examined or affected by statements by client host.",
+		[]string{"host", "operation"}, nil,
+	)
+)
+
+// ScrapeSysHostSummary collects from `sys.x$host_summary_by_statement_type`.
+type ScrapeSysHostSummary struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeSysHostSummary) Name() string {
+	return sysSchema + ".host_summary"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeSysHostSummary) Help() string {
+	return "Collect statements, latency and rows by client host from sys.host_summary_by_statement_type"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeSysHostSummary) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	// Timers here are returned in picoseconds.
+	sysHostSummaryRows, err := db.Query(sysHostSummaryQuery)
+	if err != nil {
+		return err
+	}
+	defer sysHostSummaryRows.Close()
+
+	var (
+		host                                 string
+		statements, latency                  uint64
+		rowsSent, rowsExamined, rowsAffected uint64
+	)
+
+	for sysHostSummaryRows.Next() {
+		if err := sysHostSummaryRows.Scan(
+			&host, &statements, &latency, &rowsSent, &rowsExamined, &rowsAffected,
+		); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			sysHostSummaryStatementsDesc, prometheus.CounterValue, float64(statements),
+			host,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			sysHostSummaryStatementLatencyDesc, prometheus.CounterValue, float64(latency)/picoSeconds,
+			host,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			sysHostSummaryRowsDesc, prometheus.CounterValue, float64(rowsSent),
+			host, "sent",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			sysHostSummaryRowsDesc, prometheus.CounterValue, float64(rowsExamined),
+			host, "examined",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			sysHostSummaryRowsDesc, prometheus.CounterValue, float64(rowsAffected),
+			host, "affected",
+		)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeSysHostSummary(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"host", "SUM(total)", "SUM(total_latency)", "SUM(rows_sent)", "SUM(rows_examined)", "SUM(rows_affected)"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("10.0.0.1", "5000", "12000000000000", "100", "20000", "300")
+	mock.ExpectQuery(sanitizeQuery(sysHostSummaryQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeSysHostSummary{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"host": "10.0.0.1"}, value: 5000, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"host": "10.0.0.1"}, value: 12, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"host": "10.0.0.1", "operation": "sent"}, value: 100, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"host": "10.0.0.1", "operation": "examined"}, value: 20000, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"host": "10.0.0.1", "operation": "affected"}, value: 300, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
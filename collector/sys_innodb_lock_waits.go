@@ -0,0 +1,147 @@
+// Scrape `sys.innodb_lock_waits`.
+
+package collector
+
+import (
+	"database/sql"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const (
+	sysInnodbLockWaitsCountQuery = `
+	SELECT
+	    COUNT(*),
+	    COUNT(DISTINCT blocking_pid),
+	    COUNT(DISTINCT CASE WHEN blocking_pid NOT IN (
+	        SELECT waiting_pid FROM sys.innodb_lock_waits
+	    ) THEN blocking_pid END),
+	    ifnull(MAX(wait_age_secs), 0)
+	  FROM sys.innodb_lock_waits
+	`
+	sysInnodbLockWaitsGraphQuery = `
+	SELECT
+	    w.blocking_pid,
+	    ifnull(s.DIGEST, '') as DIGEST,
+	    COUNT(*),
+	    MAX(w.wait_age_secs)
+	  FROM sys.innodb_lock_waits w
+	  LEFT JOIN performance_schema.threads t
+	    ON t.PROCESSLIST_ID = w.blocking_pid
+	  LEFT JOIN performance_schema.events_statements_current s
+	    ON s.THREAD_ID = t.THREAD_ID AND s.NESTING_EVENT_ID IS NULL
+	  GROUP BY w.blocking_pid, s.DIGEST
+	`
+)
+
+// Tunable flags.
+var (
+	sysInnodbLockWaitsThreshold = kingpin.Flag(
+		"collect.sys.innodb_lock_waits.threshold",
+		"Minimum number of lock waits before the blocking graph summary is collected",
+	).Default("1").Int()
+)
+
+// Metric descriptors.
+var (
+	sysInnodbLockWaitsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, sysSchema, "innodb_lock_waits"),
+		"The number of InnoDB lock waits from sys.innodb_lock_waits.",
+		nil, nil,
+	)
+	sysInnodbLockWaitsBlockersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, sysSchema, "innodb_lock_waits_blockers"),
+		"The number of distinct threads blocking another thread from sys.innodb_lock_waits.",
+		nil, nil,
+	)
+	sysInnodbLockWaitsChainsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, sysSchema, "innodb_lock_waits_chains"),
+		"The number of lock wait chains, i.e. blocking threads which are not waiting themselves.",
+		nil, nil,
+	)
+	sysInnodbLockWaitsOldestAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, sysSchema, "innodb_lock_waits_oldest_age_seconds"),
+		"The age of the oldest lock wait from sys.innodb_lock_waits.",
+		nil, nil,
+	)
+	sysInnodbLockWaitsWaitersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, sysSchema, "innodb_lock_waits_waiters"),
+		"The number of lock waiters by blocking thread and the digest of its current statement.",
+		[]string{"blocking_pid", "blocking_digest"}, nil,
+	)
+	sysInnodbLockWaitsMaxAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, sysSchema, "innodb_lock_waits_max_age_seconds"),
+		"The age of the oldest lock wait by blocking thread and the digest of its current statement.",
+		[]string{"blocking_pid", "blocking_digest"}, nil,
+	)
+)
+
+// ScrapeSysInnodbLockWaits collects from `sys.innodb_lock_waits`.
+type ScrapeSysInnodbLockWaits struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeSysInnodbLockWaits) Name() string {
+	return sysSchema + ".innodb_lock_waits"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeSysInnodbLockWaits) Help() string {
+	return "Collect lock wait counts, chains, blockers and a summary of the lock wait graph from sys.innodb_lock_waits"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeSysInnodbLockWaits) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var waits, blockers, chains, oldestAge uint64
+	if err := db.QueryRow(sysInnodbLockWaitsCountQuery).Scan(&waits, &blockers, &chains, &oldestAge); err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(
+		sysInnodbLockWaitsDesc, prometheus.GaugeValue, float64(waits),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		sysInnodbLockWaitsBlockersDesc, prometheus.GaugeValue, float64(blockers),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		sysInnodbLockWaitsChainsDesc, prometheus.GaugeValue, float64(chains),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		sysInnodbLockWaitsOldestAgeDesc, prometheus.GaugeValue, float64(oldestAge),
+	)
+	// Only walk the blocking graph during contention.
+	if waits == 0 || waits < uint64(*sysInnodbLockWaitsThreshold) {
+		return nil
+	}
+
+	sysInnodbLockWaitsGraphRows, err := db.Query(sysInnodbLockWaitsGraphQuery)
+	if err != nil {
+		return err
+	}
+	defer sysInnodbLockWaitsGraphRows.Close()
+
+	var (
+		blockingPid    uint64
+		blockingDigest string
+		waiters        uint64
+		maxAge         float64
+	)
+
+	for sysInnodbLockWaitsGraphRows.Next() {
+		if err := sysInnodbLockWaitsGraphRows.Scan(
+			&blockingPid, &blockingDigest, &waiters, &maxAge,
+		); err != nil {
+			return err
+		}
+		pid := strconv.FormatUint(blockingPid, 10)
+		ch <- prometheus.MustNewConstMetric(
+			sysInnodbLockWaitsWaitersDesc, prometheus.GaugeValue, float64(waiters),
+			pid, blockingDigest,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			sysInnodbLockWaitsMaxAgeDesc, prometheus.GaugeValue, maxAge,
+			pid, blockingDigest,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeSysInnodbLockWaits(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(sysInnodbLockWaitsCountQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"COUNT(*)", "blockers", "chains", "oldest_age"}).AddRow(3, 2, 1, 35))
+
+	columns := []string{"blocking_pid", "DIGEST", "COUNT(*)", "MAX(w.wait_age_secs)"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("12", "abc", "2", "35").
+		AddRow("17", "", "1", "4")
+	mock.ExpectQuery(sanitizeQuery(sysInnodbLockWaitsGraphQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeSysInnodbLockWaits{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{}, value: 3, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 35, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"blocking_pid": "12", "blocking_digest": "abc"}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"blocking_pid": "12", "blocking_digest": "abc"}, value: 35, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"blocking_pid": "17", "blocking_digest": ""}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"blocking_pid": "17", "blocking_digest": ""}, value: 4, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
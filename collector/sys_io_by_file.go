@@ -0,0 +1,135 @@
+// Scrape `sys.x$io_global_by_file_by_bytes` and `sys.x$io_global_by_file_by_latency`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const sysIOByFileQuery = `
+	SELECT
+	    b.file,
+	    b.total_read,
+	    b.total_written,
+	    ifnull(l.read_latency, 0) as read_latency,
+	    ifnull(l.write_latency, 0) as write_latency
+	  FROM sys.x$io_global_by_file_by_bytes b
+	  LEFT JOIN sys.x$io_global_by_file_by_latency l
+	    ON l.file = b.file
+	  ORDER BY b.total DESC
+	  LIMIT %d
+	`
+
+// Tunable flags.
+var (
+	sysIOByFileLimit = kingpin.Flag(
+		"collect.sys.io_by_file.limit",
+		"Limit the number of files by total bytes read and written",
+	).Default("50").Int()
+	sysIOByFileRemovePrefix = kingpin.Flag(
+		"collect.sys.io_by_file.remove_prefix",
+		"Remove path prefix in sys.io_global_by_file_by_bytes",
+	).Default("/var/lib/mysql/").String()
+)
+
+// Metric descriptors.
+var (
+	sysIOByFileBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, sysSchema, "io_by_file_bytes_total"),
+		"The number of bytes read or written by file.",
+		[]string{"file", "kind", "mode"}, nil,
+	)
+	sysIOByFileLatencyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, sysSchema, "io_by_file_seconds_total"),
+		"The total time spent on reads or writes by file.",
+		[]string{"file", "kind", "mode"}, nil,
+	)
+)
+
+// Regexp to match binary log file names, e.g. binlog.000042 or mysql-bin.000042.
+var binlogFileRE = regexp.MustCompile(`\.[0-9]{6}$`)
+
+// ScrapeSysIOByFile collects from `sys.x$io_global_by_file_by_bytes`.
+type ScrapeSysIOByFile struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeSysIOByFile) Name() string {
+	return sysSchema + ".io_by_file"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeSysIOByFile) Help() string {
+	return "Collect I/O bytes and latency of the busiest files from sys.io_global_by_file_by_bytes"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeSysIOByFile) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	// Timers here are returned in picoseconds.
+	sysIOByFileRows, err := db.Query(fmt.Sprintf(sysIOByFileQuery, *sysIOByFileLimit))
+	if err != nil {
+		return err
+	}
+	defer sysIOByFileRows.Close()
+
+	var (
+		file                      string
+		read, written             uint64
+		readLatency, writeLatency uint64
+	)
+
+	for sysIOByFileRows.Next() {
+		if err := sysIOByFileRows.Scan(
+			&file, &read, &written, &readLatency, &writeLatency,
+		); err != nil {
+			return err
+		}
+		kind := classifyFile(file)
+		file = strings.TrimPrefix(file, *sysIOByFileRemovePrefix)
+		ch <- prometheus.MustNewConstMetric(
+			sysIOByFileBytesDesc, prometheus.CounterValue, float64(read),
+			file, kind, "read",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			sysIOByFileBytesDesc, prometheus.CounterValue, float64(written),
+			file, kind, "write",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			sysIOByFileLatencyDesc, prometheus.CounterValue, float64(readLatency)/picoSeconds,
+			file, kind, "read",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			sysIOByFileLatencyDesc, prometheus.CounterValue, float64(writeLatency)/picoSeconds,
+			file, kind, "write",
+		)
+	}
+	return nil
+}
+
+// classifyFile returns the kind of server file from its path.
+func classifyFile(file string) string {
+	base := path.Base(file)
+	switch {
+	case strings.HasPrefix(base, "ibdata"):
+		return "system_tablespace"
+	case strings.HasPrefix(base, "ibtmp") || strings.Contains(file, "#innodb_temp"):
+		return "temporary_tablespace"
+	case strings.HasPrefix(base, "ib_logfile") || strings.Contains(file, "#innodb_redo"):
+		return "redo_log"
+	case strings.HasPrefix(base, "undo_") || strings.HasSuffix(base, ".ibu"):
+		return "undo_tablespace"
+	case strings.HasSuffix(base, ".ibd"):
+		return "tablespace"
+	case strings.Contains(base, "relay"):
+		return "relay_log"
+	case binlogFileRE.MatchString(base) || strings.HasSuffix(base, "-bin.index") || base == "binlog.index":
+		return "binlog"
+	}
+	return "other"
+}
@@ -0,0 +1,64 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeSysIOByFile(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"file", "total_read", "total_written", "read_latency", "write_latency"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("/var/lib/mysql/shop/orders.ibd", "1024", "2048", "3000000000000", "500000000000").
+		AddRow("/var/lib/mysql/binlog.000042", "0", "4096", "0", "1000000000")
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(sysIOByFileQuery, 50))).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeSysIOByFile{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"file": "shop/orders.ibd", "kind": "tablespace", "mode": "read"}, value: 1024, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"file": "shop/orders.ibd", "kind": "tablespace", "mode": "write"}, value: 2048, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"file": "shop/orders.ibd", "kind": "tablespace", "mode": "read"}, value: 3, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"file": "shop/orders.ibd", "kind": "tablespace", "mode": "write"}, value: 0.5, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"file": "binlog.000042", "kind": "binlog", "mode": "read"}, value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"file": "binlog.000042", "kind": "binlog", "mode": "write"}, value: 4096, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"file": "binlog.000042", "kind": "binlog", "mode": "read"}, value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"file": "binlog.000042", "kind": "binlog", "mode": "write"}, value: 0.001, metricType: dto.MetricType_COUNTER},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	convey.Convey("File classification", t, func() {
+		convey.So(classifyFile("/var/lib/mysql/ibdata1"), convey.ShouldEqual, "system_tablespace")
+		convey.So(classifyFile("/var/lib/mysql/#innodb_redo/#ib_redo10"), convey.ShouldEqual, "redo_log")
+		convey.So(classifyFile("/var/lib/mysql/undo_001"), convey.ShouldEqual, "undo_tablespace")
+		convey.So(classifyFile("/var/lib/mysql/host-relay-bin.000003"), convey.ShouldEqual, "relay_log")
+		convey.So(classifyFile("/var/lib/mysql/mysql-bin.000003"), convey.ShouldEqual, "binlog")
+		convey.So(classifyFile("/var/lib/mysql/auto.cnf"), convey.ShouldEqual, "other")
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
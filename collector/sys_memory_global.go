@@ -0,0 +1,87 @@
+// Scrape `sys.x$memory_global_by_current_bytes`.
+
+package collector
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const sysMemoryGlobalQuery = `
+	SELECT event_name, current_alloc, high_alloc
+	  FROM sys.x$memory_global_by_current_bytes
+	  LIMIT %d
+	`
+
+// Tunable flags.
+var (
+	sysMemoryGlobalLimit = kingpin.Flag(
+		"collect.sys.memory_global.limit",
+		"Limit the number of memory event names by current bytes allocated",
+	).Default("20").Int()
+)
+
+// Metric descriptors.
+var (
+	sysMemoryGlobalBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, sysSchema, "memory_global_bytes"),
+		"The number of bytes currently allocated by code area and memory event name.",
+		[]string{"code_area", "event_name"}, nil,
+	)
+	sysMemoryGlobalHighBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, sysSchema, "memory_global_high_bytes"),
+		"The high water mark of bytes allocated by code area and memory event name.",
+		[]string{"code_area", "event_name"}, nil,
+	)
+)
+
+// ScrapeSysMemoryGlobal collects from `sys.x$memory_global_by_current_bytes`.
+type ScrapeSysMemoryGlobal struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeSysMemoryGlobal) Name() string {
+	return sysSchema + ".memory_global"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeSysMemoryGlobal) Help() string {
+	return "Collect current allocated bytes by allocation area from sys.memory_global_by_current_bytes"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeSysMemoryGlobal) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	sysMemoryGlobalRows, err := db.Query(fmt.Sprintf(sysMemoryGlobalQuery, *sysMemoryGlobalLimit))
+	if err != nil {
+		return err
+	}
+	defer sysMemoryGlobalRows.Close()
+
+	var (
+		eventName     string
+		current, high int64
+	)
+
+	for sysMemoryGlobalRows.Next() {
+		if err := sysMemoryGlobalRows.Scan(&eventName, &current, &high); err != nil {
+			return err
+		}
+		// Event names look like memory/<code area>/<instrument>.
+		codeArea, name := "", strings.TrimPrefix(eventName, "memory/")
+		if parts := strings.SplitN(name, "/", 2); len(parts) == 2 {
+			codeArea, name = parts[0], parts[1]
+		}
+		ch <- prometheus.MustNewConstMetric(
+			sysMemoryGlobalBytesDesc, prometheus.GaugeValue, float64(current),
+			codeArea, name,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			sysMemoryGlobalHighBytesDesc, prometheus.GaugeValue, float64(high),
+			codeArea, name,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeSysMemoryGlobal(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"event_name", "current_alloc", "high_alloc"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("memory/innodb/buf_buf_pool", "137428992", "137428992").
+		AddRow("memory/sql/TABLE", "8388608", "9437184")
+	mock.ExpectQuery(sanitizeQuery(fmt.Sprintf(sysMemoryGlobalQuery, 20))).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeSysMemoryGlobal{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"code_area": "innodb", "event_name": "buf_buf_pool"}, value: 137428992, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"code_area": "innodb", "event_name": "buf_buf_pool"}, value: 137428992, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"code_area": "sql", "event_name": "TABLE"}, value: 8388608, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"code_area": "sql", "event_name": "TABLE"}, value: 9437184, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
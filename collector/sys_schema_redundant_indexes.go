@@ -0,0 +1,81 @@
+// Scrape `sys.schema_redundant_indexes`.
+
+package collector
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// sysSchemaRedundantIndexesQuery estimates the wasted space from the
+// persistent index statistics, which only exist for InnoDB tables.
+const sysSchemaRedundantIndexesQuery = `
+	SELECT
+	    r.table_schema,
+	    COUNT(*),
+	    ifnull(SUM(s.stat_value), 0) * @@innodb_page_size
+	  FROM sys.schema_redundant_indexes r
+	  LEFT JOIN mysql.innodb_index_stats s
+	    ON s.database_name = r.table_schema
+	    AND s.table_name = r.table_name
+	    AND s.index_name = r.redundant_index_name
+	    AND s.stat_name = 'size'
+	  GROUP BY r.table_schema
+	`
+
+// Metric descriptors.
+var (
+	sysSchemaRedundantIndexesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, sysSchema, "schema_redundant_indexes"),
+		"The number of indexes made redundant by another index by schema.",
+		[]string{"schema"}, nil,
+	)
+	sysSchemaRedundantIndexesBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, sysSchema, "schema_redundant_indexes_bytes"),
+		"The estimated size of the redundant indexes by schema.",
+		[]string{"schema"}, nil,
+	)
+)
+
+// ScrapeSysSchemaRedundantIndexes collects from `sys.schema_redundant_indexes`.
+type ScrapeSysSchemaRedundantIndexes struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeSysSchemaRedundantIndexes) Name() string {
+	return sysSchema + ".schema_redundant_indexes"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeSysSchemaRedundantIndexes) Help() string {
+	return "Collect redundant index counts and estimated sizes from sys.schema_redundant_indexes"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeSysSchemaRedundantIndexes) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	sysSchemaRedundantIndexesRows, err := db.Query(sysSchemaRedundantIndexesQuery)
+	if err != nil {
+		return err
+	}
+	defer sysSchemaRedundantIndexesRows.Close()
+
+	var (
+		tableSchema  string
+		count, bytes uint64
+	)
+
+	for sysSchemaRedundantIndexesRows.Next() {
+		if err := sysSchemaRedundantIndexesRows.Scan(&tableSchema, &count, &bytes); err != nil {
+			return err
+		}
+		ch <- prometheus.MustNewConstMetric(
+			sysSchemaRedundantIndexesDesc, prometheus.GaugeValue, float64(count),
+			tableSchema,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			sysSchemaRedundantIndexesBytesDesc, prometheus.GaugeValue, float64(bytes),
+			tableSchema,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,337 @@
+package collector
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/log"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// Tunable flags.
+var (
+	initStatements = kingpin.Flag(
+		"exporter.init-statement",
+		"SQL statement executed on every new connection, e.g. SET SESSION max_execution_time=4000. Can be repeated.",
+	).Strings()
+	queryLog = kingpin.Flag(
+		"exporter.query-log",
+		"Log every SQL statement run by the exporter with its collector, target, duration and number of rows.",
+	).Default("false").Bool()
+)
+
+// sharedConn is the single MySQL connection of a scrape. It is handed out to
+// the collectors one query at a time, so each collector can get its own
+// instrumented sql.DB while the exporter still uses one connection.
+type sharedConn struct {
+	driver driver.Driver
+	dsn    string
+	// target is the address of the server, for the query log.
+	target string
+
+	// mtx is held from the start of a query until its result is released.
+	mtx sync.Mutex
+
+	openMtx sync.Mutex
+	conn    driver.Conn
+}
+
+func (s *sharedConn) get() (driver.Conn, error) {
+	s.openMtx.Lock()
+	defer s.openMtx.Unlock()
+	if s.conn == nil {
+		conn, err := s.driver.Open(s.dsn)
+		if err != nil {
+			return nil, err
+		}
+		if err := runInitStatements(conn); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		s.conn = conn
+	}
+	return s.conn, nil
+}
+
+// runInitStatements executes the configured init statements on a new connection.
+func runInitStatements(conn driver.Conn) error {
+	for _, statement := range *initStatements {
+		var err error
+		if execer, ok := conn.(driver.ExecerContext); ok {
+			_, err = execer.ExecContext(context.Background(), statement, nil)
+		} else {
+			var stmt driver.Stmt
+			if stmt, err = conn.Prepare(statement); err == nil {
+				_, err = stmt.Exec(nil)
+				stmt.Close()
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to run init statement %q: %s", statement, err)
+		}
+	}
+	return nil
+}
+
+// Close closes the underlying connection.
+func (s *sharedConn) Close() error {
+	s.openMtx.Lock()
+	defer s.openMtx.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// collectorConnector implements driver.Connector for the sql.DB of a single
+// collector, counting the queries, rows and bytes it reads.
+type collectorConnector struct {
+	shared    *sharedConn
+	collector string
+	metrics   Metrics
+
+	mtx         sync.Mutex
+	slowest     string
+	slowestTime time.Duration
+}
+
+// slowestQuery returns the slowest query run by the collector and its duration.
+func (c *collectorConnector) slowestQuery() (string, time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.slowest, c.slowestTime
+}
+
+func (c *collectorConnector) Connect(context.Context) (driver.Conn, error) {
+	if _, err := c.shared.get(); err != nil {
+		return nil, err
+	}
+	return &collectorConn{c}, nil
+}
+
+func (c *collectorConnector) Driver() driver.Driver {
+	return c.shared.driver
+}
+
+// queryDone records the outcome of a query, which must be called with the
+// shared connection locked. driver.ErrSkip only means the query is retried as
+// a prepared statement, so it is not counted.
+func (c *collectorConnector) queryDone(err error) {
+	if err == driver.ErrSkip {
+		return
+	}
+	if err == driver.ErrBadConn {
+		// Reconnect on the next attempt.
+		c.shared.Close()
+	}
+	c.metrics.Queries.WithLabelValues(c.collector).Inc()
+	if err != nil {
+		c.metrics.QueryErrors.WithLabelValues(c.collector).Inc()
+	}
+}
+
+// queryFinished records the slowest query and writes the query to the query
+// log, if enabled.
+func (c *collectorConnector) queryFinished(query string, start time.Time, rows int, err error) {
+	if err == driver.ErrSkip {
+		return
+	}
+	query = strings.Join(strings.Fields(query), " ")
+	elapsed := time.Since(start)
+	c.mtx.Lock()
+	if elapsed > c.slowestTime {
+		c.slowest, c.slowestTime = query, elapsed
+	}
+	c.mtx.Unlock()
+
+	if !*queryLog {
+		return
+	}
+	logger := log.With("collector", c.collector).
+		With("target", c.shared.target).
+		With("query", query).
+		With("duration_seconds", elapsed.Seconds()).
+		With("rows", rows)
+	if err != nil {
+		logger.With("err", err).Infoln("Query failed")
+		return
+	}
+	logger.Infoln("Query executed")
+}
+
+type collectorConn struct {
+	*collectorConnector
+}
+
+func (c *collectorConn) Prepare(query string) (driver.Stmt, error) {
+	conn, err := c.shared.get()
+	if err != nil {
+		return nil, err
+	}
+	c.shared.mtx.Lock()
+	start := time.Now()
+	stmt, err := conn.Prepare(query)
+	if err != nil {
+		c.queryDone(err)
+		c.queryFinished(query, start, 0, err)
+		c.shared.mtx.Unlock()
+		return nil, err
+	}
+	return &collectorStmt{Stmt: stmt, conn: c, query: query}, nil
+}
+
+// Close is a no-op, the shared connection is closed at the end of the scrape.
+func (c *collectorConn) Close() error {
+	return nil
+}
+
+func (c *collectorConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported by collector connections")
+}
+
+func (c *collectorConn) Ping(ctx context.Context) error {
+	conn, err := c.shared.get()
+	if err != nil {
+		return err
+	}
+	pinger, ok := conn.(driver.Pinger)
+	if !ok {
+		return nil
+	}
+	c.shared.mtx.Lock()
+	defer c.shared.mtx.Unlock()
+	return pinger.Ping(ctx)
+}
+
+func (c *collectorConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	conn, err := c.shared.get()
+	if err != nil {
+		return nil, err
+	}
+	queryer, ok := conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	c.shared.mtx.Lock()
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	c.queryDone(err)
+	if err != nil {
+		c.queryFinished(query, start, 0, err)
+		c.shared.mtx.Unlock()
+		return nil, err
+	}
+	return &collectorRows{Rows: rows, conn: c, query: query, start: start, unlock: true}, nil
+}
+
+func (c *collectorConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	conn, err := c.shared.get()
+	if err != nil {
+		return nil, err
+	}
+	execer, ok := conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	c.shared.mtx.Lock()
+	defer c.shared.mtx.Unlock()
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	c.queryDone(err)
+	c.queryFinished(query, start, 0, err)
+	return result, err
+}
+
+// collectorStmt keeps the shared connection locked until it is closed.
+type collectorStmt struct {
+	driver.Stmt
+	conn   *collectorConn
+	query  string
+	closed bool
+}
+
+func (s *collectorStmt) Close() error {
+	err := s.Stmt.Close()
+	if !s.closed {
+		s.closed = true
+		s.conn.shared.mtx.Unlock()
+	}
+	return err
+}
+
+func (s *collectorStmt) Exec(args []driver.Value) (driver.Result, error) {
+	start := time.Now()
+	result, err := s.Stmt.Exec(args)
+	s.conn.queryDone(err)
+	s.conn.queryFinished(s.query, start, 0, err)
+	return result, err
+}
+
+func (s *collectorStmt) Query(args []driver.Value) (driver.Rows, error) {
+	start := time.Now()
+	rows, err := s.Stmt.Query(args)
+	s.conn.queryDone(err)
+	if err != nil {
+		s.conn.queryFinished(s.query, start, 0, err)
+		return nil, err
+	}
+	return &collectorRows{Rows: rows, conn: s.conn, query: s.query, start: start}, nil
+}
+
+// collectorRows counts the rows and bytes read. Rows of a direct query
+// release the shared connection when closed.
+type collectorRows struct {
+	driver.Rows
+	conn   *collectorConn
+	query  string
+	start  time.Time
+	rows   int
+	err    error
+	unlock bool
+	closed bool
+}
+
+func (r *collectorRows) Next(dest []driver.Value) error {
+	err := r.Rows.Next(dest)
+	if err != nil {
+		if err != io.EOF {
+			r.conn.metrics.QueryErrors.WithLabelValues(r.conn.collector).Inc()
+			r.err = err
+		}
+		return err
+	}
+	r.rows++
+	var bytes int
+	for _, v := range dest {
+		switch v := v.(type) {
+		case []byte:
+			bytes += len(v)
+		case string:
+			bytes += len(v)
+		}
+	}
+	r.conn.metrics.RowsScanned.WithLabelValues(r.conn.collector).Inc()
+	r.conn.metrics.BytesScanned.WithLabelValues(r.conn.collector).Add(float64(bytes))
+	return nil
+}
+
+func (r *collectorRows) Close() error {
+	err := r.Rows.Close()
+	if r.closed {
+		return err
+	}
+	r.closed = true
+	r.conn.queryFinished(r.query, r.start, r.rows, r.err)
+	if r.unlock {
+		r.conn.shared.mtx.Unlock()
+	}
+	return err
+}
@@ -0,0 +1,151 @@
+package collector
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/log"
+	"github.com/sirupsen/logrus"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestCollectorConnTelemetry(t *testing.T) {
+	mockDB, mock, err := sqlmock.NewWithDSN("telemetry")
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer mockDB.Close()
+
+	mock.ExpectQuery(sanitizeQuery(perfDataLockWaitsQuery)).WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2))
+	rows := sqlmock.NewRows([]string{"OBJECT_SCHEMA", "OBJECT_NAME", "LOCK_TYPE", "LOCK_MODE", "LOCK_STATUS", "COUNT(*)"}).
+		AddRow("db1", "t1", "TABLE", "IX", "GRANTED", "3").
+		AddRow("db1", "t2", "TABLE", "IX", "GRANTED", "1")
+	mock.ExpectQuery(sanitizeQuery(perfDataLocksQuery)).WillReturnRows(rows)
+
+	metrics := NewMetrics()
+	e := &Exporter{metrics: metrics}
+	shared := &sharedConn{driver: mockDB.Driver(), dsn: "telemetry"}
+	db, conn := e.openDB(shared, "collect.perf_schema.data_locks")
+	defer db.Close()
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapePerfDataLocks{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+	for range ch {
+	}
+
+	counter := func(vec *prometheus.CounterVec) float64 {
+		pb := &dto.Metric{}
+		vec.WithLabelValues("collect.perf_schema.data_locks").Write(pb)
+		return pb.GetCounter().GetValue()
+	}
+	convey.Convey("Telemetry", t, func() {
+		convey.So(counter(metrics.Queries), convey.ShouldEqual, 2)
+		convey.So(counter(metrics.QueryErrors), convey.ShouldEqual, 0)
+		convey.So(counter(metrics.RowsScanned), convey.ShouldEqual, 3)
+		convey.So(counter(metrics.BytesScanned), convey.ShouldBeGreaterThan, 0)
+
+		query, _ := conn.slowestQuery()
+		convey.So(query, convey.ShouldStartWith, "SELECT ")
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+// queryLogHook captures the entries of the query log.
+type queryLogHook struct {
+	mtx     sync.Mutex
+	entries []logrus.Fields
+}
+
+func (h *queryLogHook) Levels() []logrus.Level {
+	return []logrus.Level{logrus.InfoLevel}
+}
+
+func (h *queryLogHook) Fire(entry *logrus.Entry) error {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if _, ok := entry.Data["query"]; ok {
+		h.entries = append(h.entries, entry.Data)
+	}
+	return nil
+}
+
+func TestQueryLog(t *testing.T) {
+	*queryLog = true
+	defer func() { *queryLog = false }()
+	hook := &queryLogHook{}
+	log.AddHook(hook)
+
+	mockDB, mock, err := sqlmock.NewWithDSN("querylog")
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer mockDB.Close()
+
+	mock.ExpectQuery(sanitizeQuery(perfDataLockWaitsQuery)).WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2))
+
+	e := &Exporter{metrics: NewMetrics()}
+	shared := &sharedConn{driver: mockDB.Driver(), dsn: "querylog", target: "db1:3306"}
+	db, _ := e.openDB(shared, "collect.perf_schema.data_locks")
+	defer db.Close()
+
+	var waits uint64
+	if err := db.QueryRow(perfDataLockWaitsQuery).Scan(&waits); err != nil {
+		t.Fatalf("error running query: %s", err)
+	}
+
+	convey.Convey("Query log", t, func() {
+		hook.mtx.Lock()
+		defer hook.mtx.Unlock()
+		convey.So(hook.entries, convey.ShouldHaveLength, 1)
+		convey.So(hook.entries[0]["collector"], convey.ShouldEqual, "collect.perf_schema.data_locks")
+		convey.So(hook.entries[0]["target"], convey.ShouldEqual, "db1:3306")
+		convey.So(hook.entries[0]["query"], convey.ShouldEqual, "SELECT COUNT(*) FROM performance_schema.data_lock_waits")
+		convey.So(hook.entries[0]["rows"], convey.ShouldEqual, 1)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestInitStatements(t *testing.T) {
+	*initStatements = []string{"SET SESSION max_execution_time=4000"}
+	defer func() { *initStatements = nil }()
+
+	mockDB, mock, err := sqlmock.NewWithDSN("initstatements")
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer mockDB.Close()
+
+	mock.ExpectExec("SET SESSION max_execution_time=4000").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(sanitizeQuery(perfDataLockWaitsQuery)).WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2))
+
+	e := &Exporter{metrics: NewMetrics()}
+	shared := &sharedConn{driver: mockDB.Driver(), dsn: "initstatements"}
+	db, _ := e.openDB(shared, "collect.perf_schema.data_locks")
+	defer db.Close()
+
+	var waits uint64
+	if err := db.QueryRow(perfDataLockWaitsQuery).Scan(&waits); err != nil {
+		t.Fatalf("error running query: %s", err)
+	}
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
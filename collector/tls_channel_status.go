@@ -0,0 +1,111 @@
+// Scrape `performance_schema.tls_channel_status`.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	// ssl is the Metric subsystem we use.
+	ssl = "ssl"
+	// tlsChannelStatusQuery is available as of MySQL 8.0.21.
+	tlsChannelStatusQuery = `
+	SELECT CHANNEL, PROPERTY, VALUE
+	  FROM performance_schema.tls_channel_status
+	  WHERE PROPERTY IN ('Ssl_server_not_after', 'Ssl_server_not_before')
+	`
+	// sslServerStatusQuery is the fallback for older servers, which only
+	// have the main channel.
+	sslServerStatusQuery = `SHOW GLOBAL STATUS LIKE 'Ssl_server_not_%'`
+	// sslMainChannel is the name of the classic protocol channel.
+	sslMainChannel = "mysql_main"
+	// sslTimeLayout is the OpenSSL ASN1_TIME_print format used by the server.
+	sslTimeLayout = "Jan _2 15:04:05 2006 MST"
+)
+
+// Metric descriptors.
+var (
+	sslServerCertExpiryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, ssl, "server_cert_expiry_seconds"),
+		"The unix timestamp after which the server certificate of the TLS channel is no longer valid.",
+		[]string{"channel"}, nil,
+	)
+	sslServerCertNotBeforeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, ssl, "server_cert_not_before_seconds"),
+		"The unix timestamp before which the server certificate of the TLS channel is not yet valid.",
+		[]string{"channel"}, nil,
+	)
+)
+
+// ScrapeTLSChannelStatus collects from `performance_schema.tls_channel_status`.
+type ScrapeTLSChannelStatus struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeTLSChannelStatus) Name() string {
+	return "tls_channel_status"
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeTLSChannelStatus) Help() string {
+	return "Collect server certificate validity from performance_schema.tls_channel_status"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeTLSChannelStatus) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	var channelName string
+	tlsChannelStatusRows, err := db.Query(tlsChannelStatusQuery)
+	if err != nil {
+		log.Debugln("Error querying tls_channel_status, falling back to global status:", err)
+		tlsChannelStatusRows, err = db.Query(sslServerStatusQuery)
+		if err != nil {
+			return err
+		}
+		channelName = sslMainChannel
+	}
+	defer tlsChannelStatusRows.Close()
+
+	cols, err := tlsChannelStatusRows.Columns()
+	if err != nil {
+		return err
+	}
+
+	var property, value string
+	for tlsChannelStatusRows.Next() {
+		if len(cols) == 3 {
+			err = tlsChannelStatusRows.Scan(&channelName, &property, &value)
+		} else {
+			err = tlsChannelStatusRows.Scan(&property, &value)
+		}
+		if err != nil {
+			return err
+		}
+		if value == "" {
+			// TLS is not configured for this channel.
+			continue
+		}
+		ts, err := time.Parse(sslTimeLayout, value)
+		if err != nil {
+			log.Warnf("Unable to parse %s %q of TLS channel %s: %s", property, value, channelName, err)
+			continue
+		}
+		switch strings.ToLower(property) {
+		case "ssl_server_not_after":
+			ch <- prometheus.MustNewConstMetric(
+				sslServerCertExpiryDesc, prometheus.GaugeValue, float64(ts.Unix()),
+				channelName,
+			)
+		case "ssl_server_not_before":
+			ch <- prometheus.MustNewConstMetric(
+				sslServerCertNotBeforeDesc, prometheus.GaugeValue, float64(ts.Unix()),
+				channelName,
+			)
+		}
+	}
+	return nil
+}
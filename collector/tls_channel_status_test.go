@@ -0,0 +1,83 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestScrapeTLSChannelStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"CHANNEL", "PROPERTY", "VALUE"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("mysql_main", "Ssl_server_not_after", "Dec 31 23:59:59 2030 GMT").
+		AddRow("mysql_main", "Ssl_server_not_before", "Jan  1 00:00:00 2020 GMT").
+		AddRow("mysql_admin", "Ssl_server_not_after", "")
+	mock.ExpectQuery(sanitizeQuery(tlsChannelStatusQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeTLSChannelStatus{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	metricExpected := []MetricResult{
+		{labels: labelMap{"channel": "mysql_main"}, value: 1924991999, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"channel": "mysql_main"}, value: 1577836800, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range metricExpected {
+			got := readMetric(<-ch)
+			convey.So(got, convey.ShouldResemble, expect)
+		}
+		_, more := <-ch
+		convey.So(more, convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestScrapeTLSChannelStatusFallback(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(sanitizeQuery(tlsChannelStatusQuery)).WillReturnError(fmt.Errorf("Table 'performance_schema.tls_channel_status' doesn't exist"))
+	rows := sqlmock.NewRows([]string{"Variable_name", "Value"}).
+		AddRow("Ssl_server_not_after", "Dec 31 23:59:59 2030 GMT")
+	mock.ExpectQuery(sanitizeQuery(sslServerStatusQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeTLSChannelStatus{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	convey.Convey("Metrics comparison", t, func() {
+		got := readMetric(<-ch)
+		convey.So(got, convey.ShouldResemble, MetricResult{labels: labelMap{"channel": "mysql_main"}, value: 1924991999, metricType: dto.MetricType_GAUGE})
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,165 @@
+// Scrape `SHOW VITESS_TABLETS` and `SHOW VITESS_SHARDS` of a vtgate.
+
+package collector
+
+import (
+	"database/sql"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Subsystem.
+const vitess = "vitess"
+
+const (
+	vitessVersionQuery = `SELECT @@version`
+	vitessTabletsQuery = `SHOW VITESS_TABLETS`
+	vitessShardsQuery  = `SHOW VITESS_SHARDS`
+)
+
+// vitessCompatibleScrapers run against Vitess, which lacks most of the
+// performance_schema and information_schema tables the other collectors read.
+var vitessCompatibleScrapers = map[string]bool{
+	"global_status":    true,
+	"global_variables": true,
+	"vitess":           true,
+}
+
+// vitessByDSN caches whether the server of a DSN is a Vitess vtgate, as
+// that doesn't change between scrapes.
+var (
+	vitessByDSNMtx sync.Mutex
+	vitessByDSN    = map[string]bool{}
+)
+
+// isVitess reports whether the server is a Vitess vtgate, which reports a
+// version such as 8.0.30-Vitess. The server is only queried on the first
+// successful check of dsn.
+func isVitess(dsn string, db *sql.DB) bool {
+	vitessByDSNMtx.Lock()
+	vitess, ok := vitessByDSN[dsn]
+	vitessByDSNMtx.Unlock()
+	if ok {
+		return vitess
+	}
+
+	var version string
+	if err := db.QueryRow(vitessVersionQuery).Scan(&version); err != nil {
+		return false
+	}
+	vitess = strings.Contains(strings.ToLower(version), "vitess")
+	vitessByDSNMtx.Lock()
+	vitessByDSN[dsn] = vitess
+	vitessByDSNMtx.Unlock()
+	return vitess
+}
+
+// Metric descriptors.
+var (
+	vitessTabletsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, vitess, "tablets"),
+		"The number of tablets known to the vtgate by cell, keyspace, shard, type and state.",
+		[]string{"cell", "keyspace", "shard", "tablet_type", "state"}, nil,
+	)
+	vitessShardsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, vitess, "shards"),
+		"The number of shards known to the vtgate by keyspace.",
+		[]string{"keyspace"}, nil,
+	)
+)
+
+// ScrapeVitess collects tablet and shard counts from a Vitess vtgate.
+type ScrapeVitess struct{}
+
+// Name of the Scraper. Should be unique.
+func (ScrapeVitess) Name() string {
+	return vitess
+}
+
+// Help describes the role of the Scraper.
+func (ScrapeVitess) Help() string {
+	return "Collect tablet and shard counts from SHOW VITESS_TABLETS and SHOW VITESS_SHARDS of a Vitess vtgate"
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (ScrapeVitess) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	if err := scrapeVitessTablets(db, ch); err != nil {
+		return err
+	}
+	return scrapeVitessShards(db, ch)
+}
+
+func scrapeVitessTablets(db *sql.DB, ch chan<- prometheus.Metric) error {
+	tabletRows, err := db.Query(vitessTabletsQuery)
+	if err != nil {
+		return err
+	}
+	defer tabletRows.Close()
+
+	// The columns differ between Vitess releases, so they are read by name.
+	columns, err := tabletRows.Columns()
+	if err != nil {
+		return err
+	}
+	index := map[string]int{}
+	for i, column := range columns {
+		index[column] = i
+	}
+	values := make([]sql.RawBytes, len(columns))
+	scanArgs := make([]interface{}, len(columns))
+	for i := range values {
+		scanArgs[i] = &values[i]
+	}
+	value := func(column string) string {
+		if i, ok := index[column]; ok {
+			return string(values[i])
+		}
+		return ""
+	}
+
+	type tabletKey struct{ cell, keyspace, shard, tabletType, state string }
+	tablets := map[tabletKey]float64{}
+	for tabletRows.Next() {
+		if err := tabletRows.Scan(scanArgs...); err != nil {
+			return err
+		}
+		tablets[tabletKey{value("Cell"), value("Keyspace"), value("Shard"), value("TabletType"), value("State")}]++
+	}
+	if err := tabletRows.Err(); err != nil {
+		return err
+	}
+	for key, count := range tablets {
+		ch <- prometheus.MustNewConstMetric(
+			vitessTabletsDesc, prometheus.GaugeValue, count,
+			key.cell, key.keyspace, key.shard, key.tabletType, key.state,
+		)
+	}
+	return nil
+}
+
+func scrapeVitessShards(db *sql.DB, ch chan<- prometheus.Metric) error {
+	shardRows, err := db.Query(vitessShardsQuery)
+	if err != nil {
+		return err
+	}
+	defer shardRows.Close()
+
+	// Shards are listed as keyspace/shard.
+	var shard string
+	shards := map[string]float64{}
+	for shardRows.Next() {
+		if err := shardRows.Scan(&shard); err != nil {
+			return err
+		}
+		shards[strings.SplitN(shard, "/", 2)[0]]++
+	}
+	if err := shardRows.Err(); err != nil {
+		return err
+	}
+	for keyspace, count := range shards {
+		ch <- prometheus.MustNewConstMetric(vitessShardsDesc, prometheus.GaugeValue, count, keyspace)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestIsVitess(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	vitessByDSN = map[string]bool{}
+	mock.ExpectQuery(sanitizeQuery(vitessVersionQuery)).WillReturnError(fmt.Errorf("connection refused"))
+	mock.ExpectQuery(sanitizeQuery(vitessVersionQuery)).WillReturnRows(sqlmock.NewRows([]string{"@@version"}).AddRow("8.0.30-Vitess"))
+	mock.ExpectQuery(sanitizeQuery(vitessVersionQuery)).WillReturnRows(sqlmock.NewRows([]string{"@@version"}).AddRow("8.0.36"))
+
+	convey.Convey("Vitess detection", t, func() {
+		// Failed checks are retried, successful ones are cached.
+		convey.So(isVitess("vtgate", db), convey.ShouldBeFalse)
+		convey.So(isVitess("vtgate", db), convey.ShouldBeTrue)
+		convey.So(isVitess("vtgate", db), convey.ShouldBeTrue)
+		convey.So(isVitess("mysql", db), convey.ShouldBeFalse)
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestScrapeVitess(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error opening a stub database connection: %s", err)
+	}
+	defer db.Close()
+
+	columns := []string{"Cell", "Keyspace", "Shard", "TabletType", "State", "Alias", "Hostname", "PrimaryTermStartTime"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("zone1", "commerce", "0", "PRIMARY", "SERVING", "zone1-0000000100", "vttablet-100", "2024-01-01T00:00:00Z").
+		AddRow("zone1", "commerce", "0", "REPLICA", "SERVING", "zone1-0000000101", "vttablet-101", "").
+		AddRow("zone1", "commerce", "0", "REPLICA", "SERVING", "zone1-0000000102", "vttablet-102", "")
+	mock.ExpectQuery(sanitizeQuery(vitessTabletsQuery)).WillReturnRows(rows)
+	rows = sqlmock.NewRows([]string{"Shards"}).
+		AddRow("commerce/0").
+		AddRow("customer/-80").
+		AddRow("customer/80-")
+	mock.ExpectQuery(sanitizeQuery(vitessShardsQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		if err = (ScrapeVitess{}).Scrape(db, ch); err != nil {
+			t.Errorf("error calling function on test: %s", err)
+		}
+		close(ch)
+	}()
+
+	var got []MetricResult
+	for m := range ch {
+		got = append(got, readMetric(m))
+	}
+	metricExpected := []MetricResult{
+		{labels: labelMap{"cell": "zone1", "keyspace": "commerce", "shard": "0", "tablet_type": "PRIMARY", "state": "SERVING"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"cell": "zone1", "keyspace": "commerce", "shard": "0", "tablet_type": "REPLICA", "state": "SERVING"}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"keyspace": "commerce"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"keyspace": "customer"}, value: 2, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		convey.So(len(got), convey.ShouldEqual, len(metricExpected))
+		for _, expect := range metricExpected {
+			convey.So(got, convey.ShouldContain, expect)
+		}
+	})
+
+	// Ensure all SQL queries were executed
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
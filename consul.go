@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+var (
+	consulServer = kingpin.Flag(
+		"discovery.consul.server",
+		"URL of the Consul agent to discover MySQL services from, e.g. http://localhost:8500. The ACL token is read from CONSUL_HTTP_TOKEN.",
+	).Default("").String()
+	consulService = kingpin.Flag(
+		"discovery.consul.service",
+		"Name of the MySQL service in the Consul catalog.",
+	).Default("mysql").String()
+	consulTags = kingpin.Flag(
+		"discovery.consul.tag",
+		"Tag the discovered service instances must have. Can be repeated.",
+	).Strings()
+	consulDatacenter = kingpin.Flag(
+		"discovery.consul.datacenter",
+		"Datacenter to query. Defaults to the datacenter of the agent.",
+	).Default("").String()
+	consulAuthModule = kingpin.Flag(
+		"discovery.consul.auth-module",
+		"Client section of the .my.cnf file used to probe the discovered services, e.g. client.eu.",
+	).Default("").String()
+	consulRefreshInterval = kingpin.Flag(
+		"discovery.consul.refresh-interval",
+		"Interval at which the Consul catalog is read.",
+	).Default("30s").Duration()
+)
+
+// consulDiscoverer lists the instances of a service in the Consul catalog.
+type consulDiscoverer struct {
+	server     string
+	token      string
+	client     *http.Client
+	service    string
+	tags       []string
+	datacenter string
+}
+
+func newConsulDiscoverer(server, service string, tags []string, datacenter string) *consulDiscoverer {
+	return &consulDiscoverer{
+		server:     strings.TrimSuffix(server, "/"),
+		token:      os.Getenv("CONSUL_HTTP_TOKEN"),
+		client:     &http.Client{Timeout: 10 * time.Second},
+		service:    service,
+		tags:       tags,
+		datacenter: datacenter,
+	}
+}
+
+// catalogService is the part of a Consul catalog service entry used for discovery.
+type catalogService struct {
+	Node           string
+	Address        string
+	Datacenter     string
+	ServiceID      string
+	ServiceAddress string
+	ServicePort    int
+	ServiceTags    []string
+}
+
+func (c *consulDiscoverer) discover() ([]discoveredTarget, error) {
+	params := url.Values{}
+	if c.datacenter != "" {
+		params.Set("dc", c.datacenter)
+	}
+	u := fmt.Sprintf("%s/v1/catalog/service/%s?%s", c.server, url.PathEscape(c.service), params.Encode())
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("X-Consul-Token", c.token)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("reading service %s from the catalog failed: %s", c.service, resp.Status)
+	}
+	var services []catalogService
+	if err := json.NewDecoder(resp.Body).Decode(&services); err != nil {
+		return nil, err
+	}
+
+	targets := []discoveredTarget{}
+	for _, service := range services {
+		if !hasTags(service.ServiceTags, c.tags) {
+			continue
+		}
+		// The service address is empty if it is the node address.
+		host := service.ServiceAddress
+		if host == "" {
+			host = service.Address
+		}
+		targets = append(targets, discoveredTarget{
+			Address: net.JoinHostPort(host, strconv.Itoa(service.ServicePort)),
+			Labels: map[string]string{
+				"consul_datacenter": service.Datacenter,
+				"consul_node":       service.Node,
+				"consul_service_id": service.ServiceID,
+				"consul_tags":       "," + strings.Join(service.ServiceTags, ",") + ",",
+			},
+		})
+	}
+	return targets, nil
+}
+
+// hasTags reports whether all wanted tags are among the tags.
+func hasTags(tags, wanted []string) bool {
+	for _, w := range wanted {
+		found := false
+		for _, tag := range tags {
+			if tag == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+const testCatalogService = `[
+  {"Node": "node1", "Address": "10.0.0.1", "Datacenter": "dc1", "ServiceID": "mysql-1", "ServiceAddress": "", "ServicePort": 3306, "ServiceTags": ["primary", "prod"]},
+  {"Node": "node2", "Address": "10.0.0.2", "Datacenter": "dc1", "ServiceID": "mysql-2", "ServiceAddress": "10.1.0.2", "ServicePort": 3307, "ServiceTags": ["replica", "prod"]},
+  {"Node": "node3", "Address": "10.0.0.3", "Datacenter": "dc1", "ServiceID": "mysql-3", "ServiceAddress": "", "ServicePort": 3306, "ServiceTags": ["replica", "staging"]}
+]`
+
+func TestConsulDiscoverer(t *testing.T) {
+	var gotPath, gotDC, gotToken string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotDC = r.URL.Query().Get("dc")
+		gotToken = r.Header.Get("X-Consul-Token")
+		w.Write([]byte(testCatalogService))
+	}))
+	defer server.Close()
+
+	convey.Convey("Consul discovery", t, func() {
+		c := newConsulDiscoverer(server.URL+"/", "mysql", []string{"prod"}, "dc1")
+		c.token = "secret"
+		targets, err := c.discover()
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(gotPath, convey.ShouldEqual, "/v1/catalog/service/mysql")
+		convey.So(gotDC, convey.ShouldEqual, "dc1")
+		convey.So(gotToken, convey.ShouldEqual, "secret")
+		convey.So(targets, convey.ShouldResemble, []discoveredTarget{
+			{Address: "10.0.0.1:3306", Labels: map[string]string{
+				"consul_datacenter": "dc1",
+				"consul_node":       "node1",
+				"consul_service_id": "mysql-1",
+				"consul_tags":       ",primary,prod,",
+			}},
+			{Address: "10.1.0.2:3307", Labels: map[string]string{
+				"consul_datacenter": "dc1",
+				"consul_node":       "node2",
+				"consul_service_id": "mysql-2",
+				"consul_tags":       ",replica,prod,",
+			}},
+		})
+
+		c.tags = []string{"replica", "prod"}
+		targets, err = c.discover()
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(targets), convey.ShouldEqual, 1)
+		convey.So(targets[0].Address, convey.ShouldEqual, "10.1.0.2:3307")
+	})
+}
+
+type failingDiscoverer struct{}
+
+func (failingDiscoverer) discover() ([]discoveredTarget, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestTargetsHandler(t *testing.T) {
+	defer func(d []*discovery) { discoveries = d }(discoveries)
+
+	convey.Convey("Discovered targets", t, func() {
+		d := &discovery{name: "consul", discoverer: failingDiscoverer{}}
+		d.targets = []discoveredTarget{{Address: "10.0.0.1:3306"}}
+		d.refresh()
+		discoveries = []*discovery{d}
+
+		rec := httptest.NewRecorder()
+		targetsHandler(rec, httptest.NewRequest("GET", "/targets", nil))
+		var statuses []discoveryStatus
+		convey.So(json.Unmarshal(rec.Body.Bytes(), &statuses), convey.ShouldBeNil)
+		convey.So(len(statuses), convey.ShouldEqual, 1)
+		convey.So(statuses[0].Discovery, convey.ShouldEqual, "consul")
+		// The previous targets are kept on errors.
+		convey.So(statuses[0].Targets, convey.ShouldResemble, []discoveredTarget{{Address: "10.0.0.1:3306"}})
+		convey.So(statuses[0].LastError, convey.ShouldEqual, "connection refused")
+	})
+}
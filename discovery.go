@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/log"
+)
+
+// discoveredTarget is a MySQL server found by service discovery.
+type discoveredTarget struct {
+	Address string            `json:"address"`
+	Labels  map[string]string `json:"labels,omitempty"`
+}
+
+// targetDiscoverer lists the MySQL servers of a service discovery backend.
+type targetDiscoverer interface {
+	discover() ([]discoveredTarget, error)
+}
+
+// discovery keeps the targets of a discoverer up to date.
+type discovery struct {
+	name       string
+	discoverer targetDiscoverer
+	interval   time.Duration
+	// authModule is passed on /probe with the discovered targets, if set.
+	authModule string
+
+	mtx         sync.Mutex
+	targets     []discoveredTarget
+	lastRefresh time.Time
+	lastErr     error
+}
+
+// discoveries holds the enabled service discovery backends.
+var discoveries []*discovery
+
+// refresh replaces the targets with the current ones of the discoverer. The
+// previous targets are kept if the backend cannot be reached.
+func (d *discovery) refresh() {
+	targets, err := d.discoverer.discover()
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.lastErr = err
+	if err != nil {
+		log.Errorf("Error refreshing %s targets: %s", d.name, err)
+		return
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].Address < targets[j].Address })
+	d.lastRefresh = time.Now()
+	if len(targets) != len(d.targets) {
+		log.Infof("Discovered %d %s targets", len(targets), d.name)
+	}
+	d.targets = targets
+}
+
+// run refreshes the targets every interval.
+func (d *discovery) run() {
+	for {
+		d.refresh()
+		time.Sleep(d.interval)
+	}
+}
+
+func (d *discovery) current() []discoveredTarget {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.targets
+}
+
+// sdTargetGroup is a target group of the Prometheus HTTP service discovery format.
+type sdTargetGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// discoveryHandler serves the discovered targets in the Prometheus HTTP
+// service discovery format, pointing Prometheus at /probe of this exporter.
+func discoveryHandler(w http.ResponseWriter, r *http.Request) {
+	groups := []sdTargetGroup{}
+	for _, d := range discoveries {
+		for _, target := range d.current() {
+			labels := map[string]string{
+				"__metrics_path__": "/probe",
+				"__param_target":   target.Address,
+			}
+			if d.authModule != "" {
+				labels["__param_auth_module"] = d.authModule
+			}
+			for name, value := range target.Labels {
+				labels[name] = value
+			}
+			groups = append(groups, sdTargetGroup{Targets: []string{r.Host}, Labels: labels})
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(groups); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// discoveryStatus is the state of a service discovery backend shown on /targets.
+type discoveryStatus struct {
+	Discovery   string             `json:"discovery"`
+	Targets     []discoveredTarget `json:"targets"`
+	LastRefresh time.Time          `json:"last_refresh"`
+	LastError   string             `json:"last_error,omitempty"`
+}
+
+// targetsHandler serves the targets found by every service discovery backend as JSON.
+func targetsHandler(w http.ResponseWriter, r *http.Request) {
+	statuses := []discoveryStatus{}
+	for _, d := range discoveries {
+		d.mtx.Lock()
+		status := discoveryStatus{
+			Discovery:   d.name,
+			Targets:     d.targets,
+			LastRefresh: d.lastRefresh,
+		}
+		if d.lastErr != nil {
+			status.LastError = d.lastErr.Error()
+		}
+		d.mtx.Unlock()
+		if status.Targets == nil {
+			status.Targets = []discoveredTarget{}
+		}
+		statuses = append(statuses, status)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(statuses); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/prometheus/common/log"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// failoverNet is the network name of DSN addresses listing several servers,
+// e.g. user:password@failover(db1:3306,db2:3306)/.
+const failoverNet = "failover"
+
+var failoverRoleRefreshInterval = kingpin.Flag(
+	"mysqld.failover-role-refresh-interval",
+	"Interval at which the read-only state of the failover hosts is checked again when prefer-replica is set.",
+).Default("1m").Duration()
+
+// failoverDialer connects to the first reachable server of the address list.
+type failoverDialer struct {
+	// preferReplica tries the read-only servers first.
+	preferReplica bool
+	// dsn is used to check the role of the servers, with its address replaced.
+	dsn string
+
+	mtx   sync.Mutex
+	roles map[string]failoverRole
+}
+
+// failoverRole is the last known read-only state of a server.
+type failoverRole struct {
+	replica bool
+	checked time.Time
+}
+
+// registerFailover registers the dialer of the named failover network with
+// the MySQL driver. The DSN must be set before connecting if preferReplica is set.
+func registerFailover(network string, preferReplica bool) *failoverDialer {
+	d := &failoverDialer{preferReplica: preferReplica, roles: map[string]failoverRole{}}
+	mysql.RegisterDial(network, d.dial)
+	return d
+}
+
+// failoverAddrs returns the comma-separated host list as addresses, using
+// port for the hosts without one.
+func failoverAddrs(hosts string, port uint) string {
+	var addrs []string
+	for _, host := range strings.Split(hosts, ",") {
+		host = strings.TrimSpace(host)
+		if host == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(host); err != nil {
+			host = net.JoinHostPort(strings.Trim(host, "[]"), fmt.Sprint(port))
+		}
+		addrs = append(addrs, host)
+	}
+	return strings.Join(addrs, ",")
+}
+
+func (d *failoverDialer) dial(addrs string) (net.Conn, error) {
+	candidates := strings.Split(addrs, ",")
+	if d.preferReplica {
+		candidates = d.replicasFirst(candidates)
+	}
+	var errs []string
+	for _, addr := range candidates {
+		conn, err := net.DialTimeout("tcp", addr, 10*time.Second)
+		if err == nil {
+			return conn, nil
+		}
+		log.Debugf("Failing over from %s: %s", addr, err)
+		errs = append(errs, err.Error())
+	}
+	return nil, fmt.Errorf("no server of %s is reachable: %s", addrs, strings.Join(errs, "; "))
+}
+
+// replicasFirst orders the addresses so read-only servers come first.
+func (d *failoverDialer) replicasFirst(addrs []string) []string {
+	var replicas, others []string
+	now := time.Now()
+	for _, addr := range addrs {
+		if d.isReplica(addr, now) {
+			replicas = append(replicas, addr)
+		} else {
+			others = append(others, addr)
+		}
+	}
+	return append(replicas, others...)
+}
+
+// isReplica reports whether the server at addr was reachable and read-only
+// when last checked, checking it again once the refresh interval has passed.
+func (d *failoverDialer) isReplica(addr string, now time.Time) bool {
+	d.mtx.Lock()
+	role, ok := d.roles[addr]
+	d.mtx.Unlock()
+	if ok && now.Sub(role.checked) < *failoverRoleRefreshInterval {
+		return role.replica
+	}
+	// Connecting can take up to the dial timeout, don't block the other
+	// connections meanwhile.
+	replica := d.checkReadOnly(addr)
+	d.mtx.Lock()
+	d.roles[addr] = failoverRole{replica: replica, checked: now}
+	d.mtx.Unlock()
+	return replica
+}
+
+// checkReadOnly connects to the server at addr and reports whether it is read-only.
+func (d *failoverDialer) checkReadOnly(addr string) bool {
+	cfg, err := mysql.ParseDSN(d.dsn)
+	if err != nil {
+		return false
+	}
+	cfg.Net = "tcp"
+	cfg.Addr = addr
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	db, err := sql.Open("mysql", cfg.FormatDSN())
+	if err != nil {
+		return false
+	}
+	defer db.Close()
+	var readOnly bool
+	if err := db.QueryRow("SELECT @@global.read_only").Scan(&readOnly); err != nil {
+		log.Debugf("Failed to check the role of %s: %s", addr, err)
+		return false
+	}
+	return readOnly
+}
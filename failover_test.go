@@ -0,0 +1,83 @@
+package main
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestFailoverAddrs(t *testing.T) {
+	convey.Convey("Failover addresses", t, func() {
+		convey.So(failoverAddrs("db1, db2:3307,,[::1]", 3306), convey.ShouldEqual, "db1:3306,db2:3307,[::1]:3306")
+	})
+}
+
+func TestFailoverDialer(t *testing.T) {
+	down, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	downAddr := down.Addr().String()
+	down.Close()
+
+	up, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer up.Close()
+	go func() {
+		for {
+			conn, err := up.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	d := &failoverDialer{}
+	convey.Convey("Failover dialer", t, func() {
+		convey.Convey("First host down", func() {
+			conn, err := d.dial(downAddr + "," + up.Addr().String())
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(conn.RemoteAddr().String(), convey.ShouldEqual, up.Addr().String())
+			conn.Close()
+		})
+		convey.Convey("All hosts down", func() {
+			_, err := d.dial(downAddr)
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestFailoverReplicasFirst(t *testing.T) {
+	down, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	downAddr := down.Addr().String()
+	down.Close()
+
+	interval := time.Minute
+	failoverRoleRefreshInterval = &interval
+	now := time.Now()
+	d := &failoverDialer{
+		preferReplica: true,
+		dsn:           "user:password@tcp(127.0.0.1:3306)/",
+		roles: map[string]failoverRole{
+			"db1:3306": {replica: false, checked: now},
+			"db2:3306": {replica: true, checked: now.Add(-30 * time.Second)},
+			downAddr:   {replica: true, checked: now.Add(-2 * time.Minute)},
+		},
+	}
+	convey.Convey("Replicas first", t, func() {
+		convey.So(d.replicasFirst([]string{"db1:3306", "db2:3306", downAddr}), convey.ShouldResemble, []string{"db2:3306", "db1:3306", downAddr})
+		convey.Convey("Expired roles are checked again", func() {
+			role := d.roles[downAddr]
+			convey.So(role.replica, convey.ShouldBeFalse)
+			convey.So(role.checked.After(now.Add(-time.Minute)), convey.ShouldBeTrue)
+		})
+	})
+}
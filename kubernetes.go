@@ -0,0 +1,185 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+var (
+	kubernetesDiscovery = kingpin.Flag(
+		"discovery.kubernetes",
+		"Discover MySQL pods through the Kubernetes API and serve them on /discovery for Prometheus HTTP service discovery.",
+	).Default("false").Bool()
+	kubernetesNamespace = kingpin.Flag(
+		"discovery.kubernetes.namespace",
+		"Namespace of the MySQL pods. Defaults to the namespace of the exporter.",
+	).Default("").String()
+	kubernetesSelector = kingpin.Flag(
+		"discovery.kubernetes.selector",
+		"Label selector of the MySQL pods, e.g. app=mysql.",
+	).Default("").String()
+	kubernetesPort = kingpin.Flag(
+		"discovery.kubernetes.port",
+		"Number or name of the MySQL container port of the pods.",
+	).Default("3306").String()
+	kubernetesAuthModule = kingpin.Flag(
+		"discovery.kubernetes.auth-module",
+		"Client section of the .my.cnf file used to probe the discovered pods, e.g. client.eu.",
+	).Default("").String()
+	kubernetesRefreshInterval = kingpin.Flag(
+		"discovery.kubernetes.refresh-interval",
+		"Interval at which the pods are listed.",
+	).Default("30s").Duration()
+)
+
+// invalidLabelChars matches the characters of Kubernetes label names that are
+// not valid in Prometheus label names.
+var invalidLabelChars = regexp.MustCompile("[^a-zA-Z0-9_]")
+
+// serviceAccountDir holds the credentials of the exporter inside a pod.
+const serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// kubernetesDiscoverer lists the running pods matching a label selector.
+type kubernetesDiscoverer struct {
+	apiServer string
+	// tokenFile is read on every request, as the kubelet rotates bound
+	// service account tokens.
+	tokenFile string
+	client    *http.Client
+	namespace string
+	selector  string
+	port      string
+}
+
+// newKubernetesDiscoverer configures the discoverer from the in-cluster
+// service account of the exporter.
+func newKubernetesDiscoverer(namespace, selector, port string) (*kubernetesDiscoverer, error) {
+	host, apiPort := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || apiPort == "" {
+		return nil, fmt.Errorf("KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT must be set, the exporter is not running in a cluster")
+	}
+	caCert, err := ioutil.ReadFile(serviceAccountDir + "/ca.crt")
+	if err != nil {
+		return nil, err
+	}
+	rootCertPool := x509.NewCertPool()
+	if ok := rootCertPool.AppendCertsFromPEM(caCert); !ok {
+		return nil, fmt.Errorf("failed to append PEM of %s/ca.crt", serviceAccountDir)
+	}
+	if namespace == "" {
+		ns, err := ioutil.ReadFile(serviceAccountDir + "/namespace")
+		if err != nil {
+			return nil, err
+		}
+		namespace = strings.TrimSpace(string(ns))
+	}
+	return &kubernetesDiscoverer{
+		apiServer: "https://" + net.JoinHostPort(host, apiPort),
+		tokenFile: serviceAccountDir + "/token",
+		client: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: rootCertPool}},
+		},
+		namespace: namespace,
+		selector:  selector,
+		port:      port,
+	}, nil
+}
+
+// podList is the part of the Kubernetes pod list used for discovery.
+type podList struct {
+	Items []struct {
+		Metadata struct {
+			Name      string            `json:"name"`
+			Namespace string            `json:"namespace"`
+			Labels    map[string]string `json:"labels"`
+		} `json:"metadata"`
+		Spec struct {
+			Containers []struct {
+				Ports []struct {
+					Name          string `json:"name"`
+					ContainerPort int    `json:"containerPort"`
+				} `json:"ports"`
+			} `json:"containers"`
+		} `json:"spec"`
+		Status struct {
+			Phase string `json:"phase"`
+			PodIP string `json:"podIP"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+func (k *kubernetesDiscoverer) discover() ([]discoveredTarget, error) {
+	u := fmt.Sprintf("%s/api/v1/namespaces/%s/pods?labelSelector=%s",
+		k.apiServer, url.PathEscape(k.namespace), url.QueryEscape(k.selector))
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	token, err := ioutil.ReadFile(k.tokenFile)
+	if err != nil {
+		return nil, err
+	}
+	if token := strings.TrimSpace(string(token)); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("listing pods failed: %s", resp.Status)
+	}
+	var pods podList
+	if err := json.NewDecoder(resp.Body).Decode(&pods); err != nil {
+		return nil, err
+	}
+
+	targets := []discoveredTarget{}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != "Running" || pod.Status.PodIP == "" {
+			continue
+		}
+		port := k.port
+		if _, err := strconv.Atoi(port); err != nil {
+			// Look up the named container port.
+			port = ""
+			for _, container := range pod.Spec.Containers {
+				for _, p := range container.Ports {
+					if p.Name == k.port {
+						port = strconv.Itoa(p.ContainerPort)
+					}
+				}
+			}
+			if port == "" {
+				continue
+			}
+		}
+		labels := map[string]string{
+			"kubernetes_namespace": pod.Metadata.Namespace,
+			"kubernetes_pod_name":  pod.Metadata.Name,
+		}
+		for name, value := range pod.Metadata.Labels {
+			labels["kubernetes_pod_label_"+invalidLabelChars.ReplaceAllString(name, "_")] = value
+		}
+		targets = append(targets, discoveredTarget{
+			Address: net.JoinHostPort(pod.Status.PodIP, port),
+			Labels:  labels,
+		})
+	}
+	return targets, nil
+}
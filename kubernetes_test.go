@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+const testPodList = `{
+  "items": [
+    {
+      "metadata": {"name": "mysql-0", "namespace": "db", "labels": {"app": "mysql", "statefulset.kubernetes.io/pod-name": "mysql-0"}},
+      "spec": {"containers": [{"ports": [{"name": "mysql", "containerPort": 3306}]}]},
+      "status": {"phase": "Running", "podIP": "10.0.0.1"}
+    },
+    {
+      "metadata": {"name": "mysql-1", "namespace": "db", "labels": {"app": "mysql"}},
+      "spec": {"containers": [{"ports": [{"name": "mysql", "containerPort": 3306}]}]},
+      "status": {"phase": "Pending"}
+    },
+    {
+      "metadata": {"name": "mysql-2", "namespace": "db", "labels": {"app": "mysql"}},
+      "spec": {"containers": [{"ports": [{"name": "admin", "containerPort": 33062}]}]},
+      "status": {"phase": "Running", "podIP": "10.0.0.3"}
+    }
+  ]
+}`
+
+func TestKubernetesDiscoverer(t *testing.T) {
+	var gotPath, gotSelector, gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotSelector = r.URL.Query().Get("labelSelector")
+		gotAuth = r.Header.Get("Authorization")
+		w.Write([]byte(testPodList))
+	}))
+	defer server.Close()
+
+	tokenFile, err := ioutil.TempFile("", "mysqld_exporter_token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tokenFile.Name())
+	tokenFile.WriteString("secret\n")
+	tokenFile.Close()
+
+	k := &kubernetesDiscoverer{
+		apiServer: server.URL,
+		tokenFile: tokenFile.Name(),
+		client:    server.Client(),
+		namespace: "db",
+		selector:  "app=mysql",
+	}
+
+	convey.Convey("Kubernetes discovery", t, func() {
+		convey.Convey("Port number", func() {
+			k.port = "3306"
+			targets, err := k.discover()
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(gotPath, convey.ShouldEqual, "/api/v1/namespaces/db/pods")
+			convey.So(gotSelector, convey.ShouldEqual, "app=mysql")
+			convey.So(gotAuth, convey.ShouldEqual, "Bearer secret")
+			convey.So(targets, convey.ShouldResemble, []discoveredTarget{
+				{Address: "10.0.0.1:3306", Labels: map[string]string{
+					"kubernetes_namespace":                                    "db",
+					"kubernetes_pod_name":                                     "mysql-0",
+					"kubernetes_pod_label_app":                                "mysql",
+					"kubernetes_pod_label_statefulset_kubernetes_io_pod_name": "mysql-0",
+				}},
+				{Address: "10.0.0.3:3306", Labels: map[string]string{
+					"kubernetes_namespace":     "db",
+					"kubernetes_pod_name":      "mysql-2",
+					"kubernetes_pod_label_app": "mysql",
+				}},
+			})
+		})
+		convey.Convey("Rotated token", func() {
+			k.port = "3306"
+			convey.So(ioutil.WriteFile(tokenFile.Name(), []byte("rotated"), 0600), convey.ShouldBeNil)
+			_, err := k.discover()
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(gotAuth, convey.ShouldEqual, "Bearer rotated")
+		})
+		convey.Convey("Named port", func() {
+			k.port = "admin"
+			targets, err := k.discover()
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(targets), convey.ShouldEqual, 1)
+			convey.So(targets[0].Address, convey.ShouldEqual, "10.0.0.3:33062")
+		})
+		convey.Convey("Served for HTTP service discovery", func() {
+			k.port = "3306"
+			defer func(d []*discovery) { discoveries = d }(discoveries)
+			d := &discovery{name: "kubernetes", discoverer: k, authModule: "client.eu"}
+			d.refresh()
+			discoveries = []*discovery{d}
+
+			req := httptest.NewRequest("GET", "http://exporter:9104/discovery", nil)
+			rec := httptest.NewRecorder()
+			discoveryHandler(rec, req)
+			var groups []sdTargetGroup
+			convey.So(json.Unmarshal(rec.Body.Bytes(), &groups), convey.ShouldBeNil)
+			convey.So(len(groups), convey.ShouldEqual, 2)
+			convey.So(groups[0].Targets, convey.ShouldResemble, []string{"exporter:9104"})
+			convey.So(groups[0].Labels["__metrics_path__"], convey.ShouldEqual, "/probe")
+			convey.So(groups[0].Labels["__param_target"], convey.ShouldEqual, "10.0.0.1:3306")
+			convey.So(groups[0].Labels["__param_auth_module"], convey.ShouldEqual, "client.eu")
+			convey.So(groups[0].Labels["kubernetes_pod_name"], convey.ShouldEqual, "mysql-0")
+		})
+	})
+}
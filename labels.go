@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
+)
+
+// labelNameRE matches valid Prometheus label names.
+var labelNameRE = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*$")
+
+// sectionLabels holds the static labels of the [client] and [client.<name>]
+// sections, added to every metric of the servers scraped with them.
+var sectionLabels = map[string]prometheus.Labels{}
+
+// parseLabels parses a comma-separated list of name=value pairs.
+func parseLabels(s string) (prometheus.Labels, error) {
+	labels := prometheus.Labels{}
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid label %q, expected name=value", pair)
+		}
+		name, value := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if !labelNameRE.MatchString(name) || strings.HasPrefix(name, "__") {
+			return nil, fmt.Errorf("invalid label name %q", name)
+		}
+		if name == "target" {
+			return nil, fmt.Errorf("label name %q is reserved", name)
+		}
+		labels[name] = value
+	}
+	return labels, nil
+}
+
+// parseMycnfLabels returns the labels key of the [client] and
+// [client.<name>] sections, keyed by section name.
+func parseMycnfLabels(config interface{}) (map[string]prometheus.Labels, error) {
+	cfg, err := loadMycnf(config)
+	if err != nil {
+		return nil, err
+	}
+	labels := map[string]prometheus.Labels{}
+	for _, section := range cfg.SectionStrings() {
+		if section != "client" && !strings.HasPrefix(section, "client.") {
+			continue
+		}
+		sectionLabels, err := parseLabels(cfg.Section(section).Key("labels").String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse labels under [%s] in %s: %s", section, config, err)
+		}
+		if len(sectionLabels) > 0 {
+			labels[section] = sectionLabels
+		}
+	}
+	return labels, nil
+}
+
+// withLabels adds the labels to every metric gathered by g.
+func withLabels(g prometheus.Gatherer, labels prometheus.Labels) prometheus.Gatherer {
+	if len(labels) == 0 {
+		return g
+	}
+	return prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		mfs, err := g.Gather()
+		addLabels(mfs, labels)
+		return mfs, err
+	})
+}
+
+// addLabels adds the labels to every metric of the families. Labels a metric
+// already has are left alone, so collectors cannot be broken by the config.
+func addLabels(mfs []*dto.MetricFamily, labels prometheus.Labels) {
+	for _, mf := range mfs {
+		for _, m := range mf.Metric {
+			existing := map[string]bool{}
+			for _, l := range m.Label {
+				existing[l.GetName()] = true
+			}
+			for name, value := range labels {
+				if existing[name] {
+					continue
+				}
+				m.Label = append(m.Label, &dto.LabelPair{Name: proto.String(name), Value: proto.String(value)})
+			}
+			sort.Slice(m.Label, func(i, j int) bool { return m.Label[i].GetName() < m.Label[j].GetName() })
+		}
+	}
+}
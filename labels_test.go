@@ -0,0 +1,68 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseMycnfLabels(t *testing.T) {
+	convey.Convey("Section labels", t, func() {
+		convey.Convey("Labels of each section", func() {
+			labels, err := parseMycnfLabels([]byte(`
+				[client]
+				user = root
+				password = abc123
+				labels = environment=prod, cluster=main
+
+				[client.eu]
+				labels = environment=prod,cluster=eu,shard=1
+
+				[client.us]
+				host = db.us.example.com
+			`))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(labels, convey.ShouldResemble, map[string]prometheus.Labels{
+				"client":    {"environment": "prod", "cluster": "main"},
+				"client.eu": {"environment": "prod", "cluster": "eu", "shard": "1"},
+				// Missing keys are taken from [client].
+				"client.us": {"environment": "prod", "cluster": "main"},
+			})
+		})
+		convey.Convey("Invalid label", func() {
+			for _, labels := range []string{"cluster", "1cluster=eu", "__name__=foo", "target=db1"} {
+				_, err := parseMycnfLabels([]byte("[client]\nlabels = " + labels + "\n"))
+				convey.So(err, convey.ShouldNotBeNil)
+			}
+		})
+	})
+}
+
+func TestWithLabels(t *testing.T) {
+	convey.Convey("Labels added to gathered metrics", t, func() {
+		g := prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+			return []*dto.MetricFamily{{
+				Name: proto.String("mysql_info_schema_table_rows"),
+				Metric: []*dto.Metric{{
+					Label: []*dto.LabelPair{
+						{Name: proto.String("schema"), Value: proto.String("app")},
+						{Name: proto.String("table"), Value: proto.String("users")},
+					},
+				}},
+			}}, nil
+		})
+		mfs, err := withLabels(g, prometheus.Labels{"cluster": "eu", "table": "ignored"}).Gather()
+		convey.So(err, convey.ShouldBeNil)
+		labels := map[string]string{}
+		var names []string
+		for _, l := range mfs[0].Metric[0].Label {
+			labels[l.GetName()] = l.GetValue()
+			names = append(names, l.GetName())
+		}
+		convey.So(labels, convey.ShouldResemble, map[string]string{"cluster": "eu", "schema": "app", "table": "users"})
+		convey.So(names, convey.ShouldResemble, []string{"cluster", "schema", "table"})
+	})
+}
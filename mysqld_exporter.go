@@ -1,17 +1,27 @@
 package main
 
 import (
+	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-sql-driver/mysql"
 	"github.com/prometheus/client_golang/prometheus"
+	versioncollector "github.com/prometheus/client_golang/prometheus/collectors/version"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/common/expfmt"
 	"github.com/prometheus/common/log"
 	"github.com/prometheus/common/version"
 	"gopkg.in/alecthomas/kingpin.v2"
@@ -33,6 +43,30 @@ var (
 		"config.my-cnf",
 		"Path to .my.cnf file to read MySQL credentials from.",
 	).Default(path.Join(os.Getenv("HOME"), ".my.cnf")).String()
+	mysqldAddress = kingpin.Flag(
+		"mysqld.address",
+		"Address of the MySQL server, overriding the address of the data source name. srv:// addresses are resolved through DNS SRV records at scrape time.",
+	).Default("").String()
+	mysqldSocket = kingpin.Flag(
+		"mysqld.socket",
+		"Path to the Unix socket of the MySQL server, overriding the address of the data source name.",
+	).Default("").String()
+	dsnParams = kingpin.Flag(
+		"exporter.dsn-params",
+		"Extra go-sql-driver DSN parameters, e.g. readTimeout=5s&writeTimeout=5s.",
+	).Default("").String()
+	strictProbe = kingpin.Flag(
+		"exporter.strict-probe",
+		"Respond with 503 Service Unavailable instead of mysql_up 0 when the DSN cannot be formed or MySQL cannot be reached.",
+	).Default("false").Bool()
+	probeAllowedTargets = kingpin.Flag(
+		"exporter.probe-allowed-targets",
+		"Regular expression of further targets allowed on /probe, besides --mysqld.address, the targets of the .my.cnf file and discovered targets. The configured credentials are sent to every probed target.",
+	).Default("").String()
+	probeTargetExpiry = kingpin.Flag(
+		"exporter.probe-target-expiry",
+		"Time after which the exporter metrics of a target no longer probed are dropped.",
+	).Default("1h").Duration()
 	dsn string
 )
 
@@ -46,15 +80,74 @@ var scrapers = map[collector.Scraper]bool{
 	collector.ScrapeInfoSchemaInnodbTablespaces{}:     false,
 	collector.ScrapeInnodbMetrics{}:                   false,
 	collector.ScrapeAutoIncrementColumns{}:            false,
+	collector.ScrapeIntColumnCapacity{}:               false,
+	collector.ScrapeColumnStatistics{}:                false,
 	collector.ScrapeBinlogSize{}:                      false,
+	collector.ScrapeBackupHistory{}:                   false,
 	collector.ScrapePerfTableIOWaits{}:                false,
 	collector.ScrapePerfIndexIOWaits{}:                false,
 	collector.ScrapePerfTableLockWaits{}:              false,
 	collector.ScrapePerfEventsStatements{}:            false,
+	collector.ScrapePerfEventsStatementsByAccount{}:   false,
 	collector.ScrapePerfEventsWaits{}:                 false,
+	collector.ScrapePerfEventsWaitsByHost{}:           false,
 	collector.ScrapePerfFileEvents{}:                  false,
 	collector.ScrapePerfFileInstances{}:               false,
 	collector.ScrapePerfReplicationGroupMemberStats{}: false,
+	collector.ScrapePerfReplicationGroupMembers{}:     false,
+	collector.ScrapePerfDataLocks{}:                   false,
+	collector.ScrapePerfEventsStatementsSample{}:      false,
+	collector.ScrapeSysInnodbLockWaits{}:              false,
+	collector.ScrapePerfMetadataLocks{}:               false,
+	collector.ScrapePerfMemoryByAccount{}:             false,
+	collector.ScrapePerfMemoryByHost{}:                false,
+	collector.ScrapeReplicationConsistency{}:          false,
+	collector.ScrapePerfPreparedStatements{}:          false,
+	collector.ScrapePerfSocketEvents{}:                false,
+	collector.ScrapePerfErrors{}:                      false,
+	collector.ScrapePerfKeyringKeys{}:                 false,
+	collector.ScrapeTLSChannelStatus{}:                false,
+	collector.ScrapePerfVariablesInfo{}:               false,
+	collector.ScrapePerfLogStatus{}:                   false,
+	collector.ScrapePerfEventsStatementsHistogram{}:   false,
+	collector.ScrapePerfAccounts{}:                    false,
+	collector.ScrapePerfHosts{}:                       false,
+	collector.ScrapePerfThreads{}:                     false,
+	collector.ScrapePerfSessionConnectAttrs{}:         false,
+	collector.ScrapeSysSchemaRedundantIndexes{}:       false,
+	collector.ScrapeSysIOByFile{}:                     false,
+	collector.ScrapeSysMemoryGlobal{}:                 false,
+	collector.ScrapeSysHostSummary{}:                  false,
+	collector.ScrapeInnodbBufferPoolStats{}:           false,
+	collector.ScrapeInnodbBufferPage{}:                false,
+	collector.ScrapeInnodbLockWaits{}:                 false,
+	collector.ScrapePartitions{}:                      false,
+	collector.ScrapeEvents{}:                          false,
+	collector.ScrapeRoutines{}:                        false,
+	collector.ScrapeConstraints{}:                     false,
+	collector.ScrapePlugins{}:                         false,
+	collector.ScrapeMysqlUser{}:                       false,
+	collector.ScrapeInnodbUndoTempFiles{}:             false,
+	collector.ScrapeInnodbTablespaceEncryption{}:      false,
+	collector.ScrapeInnodbSessionTempTablespaces{}:    false,
+	collector.ScrapeInnodbRedoLog{}:                   false,
+	collector.ScrapeInnodbPurge{}:                     true,
+	collector.ScrapeInnodbAdaptiveHashIndex{}:         false,
+	collector.ScrapeInnodbDoublewrite{}:               false,
+	collector.ScrapeInnodbFulltext{}:                  false,
+	collector.ScrapeOpenTables{}:                      false,
+	collector.ScrapeConnectionControl{}:               false,
+	collector.ScrapeAuditLog{}:                        false,
+	collector.ScrapeBinlogStatus{}:                    false,
+	collector.ScrapeReplicationGTIDErrant{}:           false,
+	collector.ScrapeSlowLog{}:                         false,
+	collector.ScrapeReplicasConnected{}:               false,
+	collector.ScrapeReplicationConfig{}:               false,
+	collector.ScrapeReplicationRole{}:                 false,
+	collector.ScrapePerfBlockingTree{}:                false,
+	collector.ScrapeUserConnectionLimits{}:            false,
+	collector.ScrapeInnodbIndexStats{}:                false,
+	collector.ScrapeInnodbTableStats{}:                false,
 	collector.ScrapeUserStat{}:                        false,
 	collector.ScrapeClientStat{}:                      false,
 	collector.ScrapeTableStat{}:                       false,
@@ -65,107 +158,556 @@ var scrapers = map[collector.Scraper]bool{
 	collector.ScrapeEngineInnodbStatus{}:              false,
 	collector.ScrapeHeartbeat{}:                       false,
 	collector.ScrapeSlaveHosts{}:                      false,
+	collector.ScrapeVitess{}:                          false,
+	collector.ScrapeNDBInfoNodes{}:                    false,
+	collector.ScrapeNDBInfoMemoryUsage{}:              false,
+	collector.ScrapeNDBInfoCounters{}:                 false,
+	collector.ScrapeNDBInfoClusterOperations{}:        false,
+	collector.ScrapeHeatWave{}:                        false,
+	collector.ScrapeProxySQLConnectionPool{}:          true,
+	collector.ScrapeProxySQLCommandsCounters{}:        true,
+	collector.ScrapeProxySQLQueryDigest{}:             true,
 }
 
-func parseMycnf(config interface{}) (string, error) {
-	var dsn string
+func loadMycnf(config interface{}) (*ini.File, error) {
 	opts := ini.LoadOptions{
 		// MySQL ini file can have boolean keys.
 		AllowBooleanKeys: true,
 	}
 	cfg, err := ini.LoadSources(opts, config)
 	if err != nil {
-		return dsn, fmt.Errorf("failed reading ini file: %s", err)
+		return nil, fmt.Errorf("failed reading ini file: %s", err)
+	}
+	return cfg, nil
+}
+
+func parseMycnf(config interface{}) (string, error) {
+	cfg, err := loadMycnf(config)
+	if err != nil {
+		return "", err
+	}
+	return formDSN(cfg, "client", config)
+}
+
+// parseMycnfSections returns the DSN of every [client.<name>] section, keyed
+// by section name. Keys missing from a section are taken from [client].
+func parseMycnfSections(config interface{}) (map[string]string, error) {
+	cfg, err := loadMycnf(config)
+	if err != nil {
+		return nil, err
 	}
-	user := cfg.Section("client").Key("user").String()
-	password := cfg.Section("client").Key("password").String()
+	dsns := map[string]string{}
+	for _, section := range cfg.SectionStrings() {
+		if !strings.HasPrefix(section, "client.") {
+			continue
+		}
+		dsn, err := formDSN(cfg, section, config)
+		if err != nil {
+			return nil, err
+		}
+		dsns[section] = dsn
+	}
+	return dsns, nil
+}
+
+// formDSN returns the DSN of the given client section. Driver settings such
+// as TLS configurations are registered under a name unique to the section.
+func formDSN(cfg *ini.File, section string, config interface{}) (string, error) {
+	var dsn string
+	key := func(name string) *ini.Key {
+		if sec := cfg.Section(section); sec.HasKey(name) {
+			return sec.Key(name)
+		}
+		return cfg.Section("client").Key(name)
+	}
+	suffix := ""
+	if section != "client" {
+		suffix = "-" + strings.TrimPrefix(section, "client.")
+	}
+
+	user := key("user").String()
+	password := key("password").String()
 	if (user == "") || (password == "") {
-		return dsn, fmt.Errorf("no user or password specified under [client] in %s", config)
+		return dsn, fmt.Errorf("no user or password specified under [%s] in %s", section, config)
 	}
-	host := cfg.Section("client").Key("host").MustString("localhost")
-	port := cfg.Section("client").Key("port").MustUint(3306)
-	socket := cfg.Section("client").Key("socket").String()
+	host := key("host").MustString("localhost")
+	port := key("port").MustUint(3306)
+	socket := key("socket").String()
+	sshHost := key("ssh-host").String()
+	proxyURL := key("proxy-url").String()
+	var failover *failoverDialer
 	if socket != "" {
 		dsn = fmt.Sprintf("%s:%s@unix(%s)/", user, password, socket)
+	} else if sshHost != "" && proxyURL != "" {
+		return dsn, fmt.Errorf("ssh-host and proxy-url cannot be combined under [%s] in %s", section, config)
+	} else if strings.Contains(host, ",") {
+		if sshHost != "" || proxyURL != "" {
+			return dsn, fmt.Errorf("multiple hosts cannot be combined with ssh-host or proxy-url under [%s] in %s", section, config)
+		}
+		failover = registerFailover(failoverNet+suffix, key("prefer-replica").MustBool(false))
+		dsn = fmt.Sprintf("%s:%s@%s(%s)/", user, password, failoverNet+suffix, failoverAddrs(host, port))
+	} else if proxyURL != "" {
+		if proxyErr := registerProxy(proxyNet+suffix, proxyURL); proxyErr != nil {
+			return dsn, fmt.Errorf("failed to register a proxy for mysql dsn: %s", proxyErr)
+		}
+		dsn = fmt.Sprintf("%s:%s@%s(%s:%d)/", user, password, proxyNet+suffix, host, port)
+	} else if sshHost != "" {
+		if sshErr := registerSSHTunnel(
+			sshNet+suffix,
+			sshHost,
+			key("ssh-user").String(),
+			key("ssh-key").String(),
+			key("ssh-host-key").String(),
+			key("ssh-known-hosts").String(),
+		); sshErr != nil {
+			return dsn, fmt.Errorf("failed to register an SSH tunnel for mysql dsn: %s", sshErr)
+		}
+		dsn = fmt.Sprintf("%s:%s@%s(%s:%d)/", user, password, sshNet+suffix, host, port)
 	} else {
 		dsn = fmt.Sprintf("%s:%s@tcp(%s:%d)/", user, password, host, port)
 	}
-	sslCA := cfg.Section("client").Key("ssl-ca").String()
-	sslCert := cfg.Section("client").Key("ssl-cert").String()
-	sslKey := cfg.Section("client").Key("ssl-key").String()
-	if sslCA != "" {
-		if tlsErr := customizeTLS(sslCA, sslCert, sslKey); tlsErr != nil {
+	tlsOpts := tlsOptions{
+		ca:           key("ssl-ca").String(),
+		cert:         key("ssl-cert").String(),
+		key:          key("ssl-key").String(),
+		minVersion:   key("tls-min-version").String(),
+		cipherSuites: key("tls-cipher-suites").String(),
+		serverName:   key("tls-server-name").String(),
+	}
+	var params []string
+	if tlsOpts.ca != "" || tlsOpts.minVersion != "" || tlsOpts.cipherSuites != "" || tlsOpts.serverName != "" {
+		if failover != nil && tlsOpts.serverName == "" {
+			// The driver takes the server name to verify from the address,
+			// which lists several hosts here.
+			return dsn, fmt.Errorf("tls-server-name is required for SSL with multiple hosts under [%s] in %s", section, config)
+		}
+		if tlsErr := customizeTLS("custom"+suffix, tlsOpts); tlsErr != nil {
 			tlsErr = fmt.Errorf("failed to register a custom TLS configuration for mysql dsn: %s", tlsErr)
 			return dsn, tlsErr
 		}
-		dsn = fmt.Sprintf("%s?tls=custom", dsn)
+		params = append(params, "tls=custom"+suffix)
+	}
+	if pubKeyPath := key("server-public-key-path").String(); pubKeyPath != "" {
+		if pubKeyErr := registerServerPubKey("custom"+suffix, pubKeyPath); pubKeyErr != nil {
+			return dsn, fmt.Errorf("failed to register the server public key for mysql dsn: %s", pubKeyErr)
+		}
+		params = append(params, "serverPubKey=custom"+suffix)
+	}
+	if key("enable-cleartext-plugin").MustBool(false) {
+		params = append(params, "allowCleartextPasswords=true")
+	}
+	if charset := charsetParams(
+		key("default-character-set").String(),
+		key("collation").String(),
+	); charset != "" {
+		params = append(params, charset)
+	}
+	if extra := key("dsn-params").String(); extra != "" {
+		params = append(params, extra)
+	}
+	if len(params) > 0 {
+		dsn = fmt.Sprintf("%s?%s", dsn, strings.Join(params, "&"))
+	}
+	if failover != nil {
+		failover.dsn = dsn
 	}
 
 	log.Debugln(dsn)
 	return dsn, nil
 }
 
-func customizeTLS(sslCA string, sslCert string, sslKey string) error {
-	var tlsCfg tls.Config
-	caBundle := x509.NewCertPool()
-	pemCA, err := ioutil.ReadFile(sslCA)
+// charsetParams returns the DSN parameters selecting the connection charset
+// and collation, if set.
+func charsetParams(charset, collation string) string {
+	var params []string
+	if charset != "" {
+		params = append(params, "charset="+url.QueryEscape(charset))
+	}
+	if collation != "" {
+		params = append(params, "collation="+url.QueryEscape(collation))
+	}
+	return strings.Join(params, "&")
+}
+
+// addDSNParams appends extra driver parameters to the DSN.
+func addDSNParams(dsn, params string) (string, error) {
+	if params == "" {
+		return dsn, nil
+	}
+	if strings.Contains(dsn, "?") {
+		dsn = dsn + "&" + params
+	} else {
+		dsn = dsn + "?" + params
+	}
+	if _, err := mysql.ParseDSN(dsn); err != nil {
+		return "", fmt.Errorf("invalid DSN parameters %q: %s", params, err)
+	}
+	return dsn, nil
+}
+
+// registerServerPubKey registers the RSA public key used to encrypt the
+// password of caching_sha2_password and sha256_password accounts without TLS.
+func registerServerPubKey(name, path string) error {
+	data, err := ioutil.ReadFile(path)
 	if err != nil {
 		return err
 	}
-	if ok := caBundle.AppendCertsFromPEM(pemCA); ok {
-		tlsCfg.RootCAs = caBundle
-	} else {
-		return fmt.Errorf("failed parse pem-encoded CA certificates from %s", sslCA)
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return fmt.Errorf("failed to decode pem-encoded public key from %s", path)
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse public key from %s: %s", path, err)
+	}
+	rsaPubKey, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("public key from %s is not an RSA key", path)
 	}
-	if sslCert != "" && sslKey != "" {
-		certPairs := make([]tls.Certificate, 0, 1)
-		keypair, err := tls.LoadX509KeyPair(sslCert, sslKey)
+	mysql.RegisterServerPubKey(name, rsaPubKey)
+	return nil
+}
+
+// tlsOptions are the TLS settings of a client section.
+type tlsOptions struct {
+	ca, cert, key string
+	// minVersion is the minimum protocol version, e.g. TLSv1.2.
+	minVersion string
+	// cipherSuites is a comma separated list of Go cipher suite names.
+	cipherSuites string
+	serverName   string
+}
+
+var tlsVersions = map[string]uint16{
+	"TLSv1":   tls.VersionTLS10,
+	"TLSv1.0": tls.VersionTLS10,
+	"TLSv1.1": tls.VersionTLS11,
+	"TLSv1.2": tls.VersionTLS12,
+	"TLSv1.3": tls.VersionTLS13,
+}
+
+func customizeTLS(name string, opts tlsOptions) error {
+	var tlsCfg tls.Config
+	if opts.ca != "" {
+		caBundle := x509.NewCertPool()
+		pemCA, err := ioutil.ReadFile(opts.ca)
 		if err != nil {
-			return fmt.Errorf("failed to parse pem-encoded SSL cert %s or SSL key %s: %s",
-				sslCert, sslKey, err)
+			return err
+		}
+		if ok := caBundle.AppendCertsFromPEM(pemCA); ok {
+			tlsCfg.RootCAs = caBundle
+		} else {
+			return fmt.Errorf("failed parse pem-encoded CA certificates from %s", opts.ca)
 		}
-		certPairs = append(certPairs, keypair)
-		tlsCfg.Certificates = certPairs
 	}
-	mysql.RegisterTLSConfig("custom", &tlsCfg)
-	return nil
+	if opts.cert != "" && opts.key != "" {
+		reloader, err := newCertReloader(opts.cert, opts.key)
+		if err != nil {
+			return err
+		}
+		tlsCfg.GetClientCertificate = reloader.GetClientCertificate
+	}
+	if opts.minVersion != "" {
+		version, ok := tlsVersions[opts.minVersion]
+		if !ok {
+			return fmt.Errorf("unknown TLS version %s", opts.minVersion)
+		}
+		tlsCfg.MinVersion = version
+	}
+	if opts.cipherSuites != "" {
+		suites := map[string]uint16{}
+		for _, suite := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+			suites[suite.Name] = suite.ID
+		}
+		for _, name := range strings.Split(opts.cipherSuites, ",") {
+			id, ok := suites[strings.TrimSpace(name)]
+			if !ok {
+				return fmt.Errorf("unknown TLS cipher suite %s", name)
+			}
+			tlsCfg.CipherSuites = append(tlsCfg.CipherSuites, id)
+		}
+	}
+	tlsCfg.ServerName = opts.serverName
+	return mysql.RegisterTLSConfig(name, &tlsCfg)
 }
 
+// HTTP handler metrics.
+var (
+	httpRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total number of HTTP requests made.",
+		},
+		[]string{"handler", "code", "method"},
+	)
+	httpRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "http_request_duration_seconds",
+			Help: "The HTTP request latencies in seconds.",
+		},
+		[]string{"handler", "method"},
+	)
+)
+
 func init() {
-	prometheus.MustRegister(version.NewCollector("mysqld_exporter"))
+	prometheus.MustRegister(versioncollector.NewCollector("mysqld_exporter"))
+	prometheus.MustRegister(httpRequestsTotal, httpRequestDuration)
+}
+
+// instrumentHandler counts the requests of the named handler and observes
+// their duration.
+func instrumentHandler(name string, handler http.Handler) http.Handler {
+	labels := prometheus.Labels{"handler": name}
+	return promhttp.InstrumentHandlerCounter(
+		httpRequestsTotal.MustCurryWith(labels),
+		promhttp.InstrumentHandlerDuration(httpRequestDuration.MustCurryWith(labels), handler),
+	)
+}
+
+// filterScrapers applies the "collect[]" query parameters of the request, if any.
+func filterScrapers(r *http.Request, scrapers []collector.Scraper) []collector.Scraper {
+	params := r.URL.Query()["collect[]"]
+	log.Debugln("collect query:", params)
+
+	// Check if we have some "collect[]" query parameters.
+	if len(params) == 0 {
+		return scrapers
+	}
+	filters := make(map[string]bool)
+	for _, param := range params {
+		filters[param] = true
+	}
+
+	var filteredScrapers []collector.Scraper
+	for _, scraper := range scrapers {
+		if filters[scraper.Name()] {
+			filteredScrapers = append(filteredScrapers, scraper)
+		}
+	}
+	return filteredScrapers
 }
 
 func newHandler(metrics collector.Metrics, scrapers []collector.Scraper) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		filteredScrapers := scrapers
-		params := r.URL.Query()["collect[]"]
-		log.Debugln("collect query:", params)
-
-		// Check if we have some "collect[]" query parameters.
-		if len(params) > 0 {
-			filters := make(map[string]bool)
-			for _, param := range params {
-				filters[param] = true
-			}
+		filteredScrapers := filterScrapers(r, scrapers)
+
+		if len(scrapeTargets) > 0 {
+			// A down target must not hide the metrics of the others, so
+			// strict mode does not apply; each has its own mysql_up.
+			serveMetrics(w, r, prometheus.Gatherers{
+				prometheus.DefaultGatherer,
+				gatherTargets(scrapeTargets, filteredScrapers),
+			}, false)
+			return
+		}
 
-			filteredScrapers = nil
-			for _, scraper := range scrapers {
-				if filters[scraper.Name()] {
-					filteredScrapers = append(filteredScrapers, scraper)
+		scrapeDSN := dsn
+		if strings.HasPrefix(*mysqldAddress, srvScheme) {
+			var err error
+			if scrapeDSN, err = dsnForTarget(dsn, *mysqldAddress); err != nil {
+				log.Errorln("Error resolving MySQL address:", err)
+				status := http.StatusInternalServerError
+				if *strictProbe {
+					status = http.StatusServiceUnavailable
 				}
+				http.Error(w, err.Error(), status)
+				return
 			}
 		}
 
 		registry := prometheus.NewRegistry()
-		registry.MustRegister(collector.New(dsn, metrics, filteredScrapers))
+		registry.MustRegister(collector.New(scrapeDSN, metrics, filteredScrapers))
 
 		gatherers := prometheus.Gatherers{
 			prometheus.DefaultGatherer,
-			registry,
+			withLabels(registry, sectionLabels["client"]),
 		}
 		// Delegate http serving to Prometheus client library, which will call collector.Collect.
-		h := promhttp.HandlerFor(gatherers, promhttp.HandlerOpts{})
+		serveMetrics(w, r, gatherers, *strictProbe)
+	}
+}
+
+// serveMetrics writes the gathered metrics. With strict set, it responds with
+// 503 Service Unavailable instead when MySQL is down.
+func serveMetrics(w http.ResponseWriter, r *http.Request, gatherer prometheus.Gatherer, strict bool) {
+	if !strict {
+		h := promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{EnableOpenMetrics: true})
 		h.ServeHTTP(w, r)
+		return
+	}
+
+	mfs, err := gatherer.Gather()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error gathering metrics: %s", err), http.StatusInternalServerError)
+		return
+	}
+	for _, mf := range mfs {
+		if mf.GetName() != "mysql_up" {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			if m.GetGauge().GetValue() == 0 {
+				http.Error(w, "MySQL server is down", http.StatusServiceUnavailable)
+				return
+			}
+		}
+	}
+
+	contentType := expfmt.NegotiateIncludingOpenMetrics(r.Header)
+	w.Header().Set("Content-Type", string(contentType))
+	enc := expfmt.NewEncoder(w, contentType)
+	for _, mf := range mfs {
+		if err := enc.Encode(mf); err != nil {
+			log.Errorln("Error encoding metric family:", err)
+			return
+		}
+	}
+	if closer, ok := enc.(expfmt.Closer); ok {
+		// OpenMetrics requires a final "# EOF" line.
+		if err := closer.Close(); err != nil {
+			log.Errorln("Error encoding metric family:", err)
+		}
+	}
+}
+
+// dsnForTarget returns the DSN with its address replaced by target, keeping
+// the credentials and parameters. Targets of the form unix:///path/to/mysql.sock
+// connect through a Unix socket, srv://name targets are resolved through DNS
+// SRV records, and other targets connect through the SSH bastion or proxy if
+// one is configured.
+func dsnForTarget(dsn, target string) (string, error) {
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(target, "unix://") {
+		cfg.Net = "unix"
+		cfg.Addr = strings.TrimPrefix(target, "unix://")
+		if cfg.Addr == "" {
+			return "", fmt.Errorf("missing socket path in target %s", target)
+		}
+	} else {
+		if cfg.Net == "unix" {
+			cfg.Net = "tcp"
+		}
+		cfg.Addr = target
+		if strings.HasPrefix(target, srvScheme) {
+			if cfg.Addr, err = resolveSRV(target); err != nil {
+				return "", err
+			}
+		}
+	}
+	return cfg.FormatDSN(), nil
+}
+
+// authModules holds the DSN of every [client.<name>] section of the .my.cnf
+// file, selected on /probe by the auth_module parameter.
+var authModules = map[string]string{}
+
+// probeAllowedTargetsRE matches the targets allowed by --exporter.probe-allowed-targets.
+var probeAllowedTargetsRE *regexp.Regexp
+
+// probeTargetAllowed reports whether /probe may send the configured
+// credentials to target.
+func probeTargetAllowed(target string) bool {
+	if probeAllowedTargetsRE != nil && probeAllowedTargetsRE.MatchString(target) {
+		return true
+	}
+	if target == *mysqldAddress {
+		return true
+	}
+	for _, t := range scrapeTargets {
+		if target == t.address {
+			return true
+		}
+	}
+	for _, d := range discoveries {
+		for _, t := range d.current() {
+			if target == t.Address {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// targetMetrics keeps the exporter metrics of each probed target across
+// requests, until the target was not probed for --exporter.probe-target-expiry.
+var (
+	targetMetricsMtx sync.Mutex
+	targetMetrics    = map[string]*probedTarget{}
+)
+
+type probedTarget struct {
+	metrics    collector.Metrics
+	lastProbed time.Time
+}
+
+func metricsForTarget(target string, now time.Time) collector.Metrics {
+	targetMetricsMtx.Lock()
+	defer targetMetricsMtx.Unlock()
+	for address, t := range targetMetrics {
+		if now.Sub(t.lastProbed) > *probeTargetExpiry {
+			delete(targetMetrics, address)
+		}
+	}
+	t, ok := targetMetrics[target]
+	if !ok {
+		t = &probedTarget{metrics: collector.NewTargetMetrics(target)}
+		targetMetrics[target] = t
+	}
+	t.lastProbed = now
+	return t.metrics
+}
+
+// newProbeHandler scrapes the MySQL server given by the "target" query
+// parameter, using the credentials of the configured DSN.
+func newProbeHandler(scrapers []collector.Scraper) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		target := r.URL.Query().Get("target")
+		if target == "" {
+			http.Error(w, "target parameter is missing", http.StatusBadRequest)
+			return
+		}
+		if !probeTargetAllowed(target) {
+			http.Error(w, fmt.Sprintf("target %s is not allowed", target), http.StatusForbidden)
+			return
+		}
+		baseDSN, labels := dsn, sectionLabels["client"]
+		if module := r.URL.Query().Get("auth_module"); module != "" {
+			var ok bool
+			if baseDSN, ok = authModules[module]; !ok {
+				http.Error(w, fmt.Sprintf("unknown auth_module %s", module), http.StatusBadRequest)
+				return
+			}
+			labels = sectionLabels[module]
+		}
+		targetDSN, err := dsnForTarget(baseDSN, target)
+		if err == nil {
+			targetDSN, err = addDSNParams(targetDSN, charsetParams(r.URL.Query().Get("charset"), r.URL.Query().Get("collation")))
+		}
+		if err != nil {
+			status := http.StatusBadRequest
+			if *strictProbe {
+				status = http.StatusServiceUnavailable
+			}
+			http.Error(w, fmt.Sprintf("failed to form DSN for target %s: %s", target, err), status)
+			return
+		}
+
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(collector.New(targetDSN, metricsForTarget(target, time.Now()), filterScrapers(r, scrapers)))
+
+		serveMetrics(w, r, withLabels(registry, labels), *strictProbe)
+	}
+}
+
+// scrapeStatusHandler serves the raw statement sample of the most recent
+// scrape of each server as JSON.
+func scrapeStatusHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(collector.LastStatementSamples()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
 
@@ -206,25 +748,106 @@ func main() {
 	log.Infoln("Starting mysqld_exporter", version.Info())
 	log.Infoln("Build context", version.BuildContext())
 
+	var err error
 	dsn = os.Getenv("DATA_SOURCE_NAME")
 	if len(dsn) == 0 {
-		var err error
 		if dsn, err = parseMycnf(*configMycnf); err != nil {
 			log.Fatal(err)
 		}
+		if authModules, err = parseMycnfSections(*configMycnf); err != nil {
+			log.Fatal(err)
+		}
+		sectionDSNs := map[string]string{"client": dsn}
+		for section, sectionDSN := range authModules {
+			sectionDSNs[section] = sectionDSN
+		}
+		if scrapeTargets, err = parseMycnfTargets(*configMycnf, sectionDSNs); err != nil {
+			log.Fatal(err)
+		}
+		if sectionLabels, err = parseMycnfLabels(*configMycnf); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if dsn, err = addDSNParams(dsn, *dsnParams); err != nil {
+		log.Fatal(err)
+	}
+	if *mysqldSocket != "" && *mysqldAddress != "" {
+		log.Fatal("--mysqld.socket and --mysqld.address cannot be combined")
+	}
+	if *mysqldSocket != "" {
+		if dsn, err = dsnForTarget(dsn, "unix://"+*mysqldSocket); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *mysqldAddress != "" && !strings.HasPrefix(*mysqldAddress, srvScheme) {
+		if dsn, err = dsnForTarget(dsn, *mysqldAddress); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if *probeAllowedTargets != "" {
+		if probeAllowedTargetsRE, err = regexp.Compile("^(?:" + *probeAllowedTargets + ")$"); err != nil {
+			log.Fatalf("Invalid --exporter.probe-allowed-targets: %s", err)
+		}
+	}
+
+	if err := collector.StartHeartbeatWriter(dsn); err != nil {
+		log.Fatal(err)
+	}
+
+	if *kubernetesDiscovery {
+		if _, ok := authModules[*kubernetesAuthModule]; *kubernetesAuthModule != "" && !ok {
+			log.Fatalf("Unknown auth module %s", *kubernetesAuthModule)
+		}
+		discoverer, err := newKubernetesDiscoverer(*kubernetesNamespace, *kubernetesSelector, *kubernetesPort)
+		if err != nil {
+			log.Fatal(err)
+		}
+		discoveries = append(discoveries, &discovery{
+			name:       "kubernetes",
+			discoverer: discoverer,
+			interval:   *kubernetesRefreshInterval,
+			authModule: *kubernetesAuthModule,
+		})
+	}
+	if *consulServer != "" {
+		if _, ok := authModules[*consulAuthModule]; *consulAuthModule != "" && !ok {
+			log.Fatalf("Unknown auth module %s", *consulAuthModule)
+		}
+		discoveries = append(discoveries, &discovery{
+			name:       "consul",
+			discoverer: newConsulDiscoverer(*consulServer, *consulService, *consulTags, *consulDatacenter),
+			interval:   *consulRefreshInterval,
+			authModule: *consulAuthModule,
+		})
+	}
+	for _, d := range discoveries {
+		go d.run()
 	}
 
 	// Register only scrapers enabled by flag.
 	log.Infof("Enabled scrapers:")
 	enabledScrapers := []collector.Scraper{}
 	for scraper, enabled := range scraperFlags {
+		// Only the proxysql collectors apply to ProxySQL, and only to it.
+		if collector.IsProxySQLScraper(scraper) != collector.ProxySQLMode() {
+			continue
+		}
 		if *enabled {
 			log.Infof(" --collect.%s", scraper.Name())
 			enabledScrapers = append(enabledScrapers, scraper)
 		}
 	}
+	for _, scraper := range loadPlugins(*pluginPaths, scrapers) {
+		log.Infof(" %s (plugin)", scraper.Name())
+		enabledScrapers = append(enabledScrapers, scraper)
+	}
 	handlerFunc := newHandler(collector.NewMetrics(), enabledScrapers)
-	http.HandleFunc(*metricPath, prometheus.InstrumentHandlerFunc("metrics", handlerFunc))
+	http.Handle(*metricPath, instrumentHandler("metrics", handlerFunc))
+	http.Handle("/probe", instrumentHandler("probe", newProbeHandler(enabledScrapers)))
+	http.HandleFunc("/scrape-status", scrapeStatusHandler)
+	http.HandleFunc("/discovery", discoveryHandler)
+	http.HandleFunc("/targets", targetsHandler)
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Write(landingPage)
 	})
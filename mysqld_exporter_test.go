@@ -2,21 +2,32 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"os/exec"
 	"reflect"
+	"regexp"
 	"runtime"
 	"strings"
 	"syscall"
 	"testing"
 	"time"
 
+	"github.com/go-sql-driver/mysql"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/smartystreets/goconvey/convey"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/prometheus/mysqld_exporter/collector"
 )
 
 func TestParseMycnf(t *testing.T) {
@@ -62,6 +73,19 @@ func TestParseMycnf(t *testing.T) {
 			[mysql]
 			skip-auto-rehash
 		`
+		dsnParamsConfig = `
+			[client]
+			user = root
+			password = abc123
+			dsn-params = readTimeout=5s&interpolateParams=true
+		`
+		charsetConfig = `
+			[client]
+			user = root
+			password = abc123
+			default-character-set = latin1
+			collation = latin1_swedish_ci
+		`
 		badConfig = `
 			[client]
 			user = root
@@ -102,6 +126,14 @@ func TestParseMycnf(t *testing.T) {
 			dsn, _ := parseMycnf([]byte(ignoreBooleanKeys))
 			convey.So(dsn, convey.ShouldEqual, "root:abc123@tcp(localhost:3306)/")
 		})
+		convey.Convey("Extra DSN parameters", func() {
+			dsn, _ := parseMycnf([]byte(dsnParamsConfig))
+			convey.So(dsn, convey.ShouldEqual, "root:abc123@tcp(localhost:3306)/?readTimeout=5s&interpolateParams=true")
+		})
+		convey.Convey("Charset and collation", func() {
+			dsn, _ := parseMycnf([]byte(charsetConfig))
+			convey.So(dsn, convey.ShouldEqual, "root:abc123@tcp(localhost:3306)/?charset=latin1&collation=latin1_swedish_ci")
+		})
 		convey.Convey("Missed user", func() {
 			_, err := parseMycnf([]byte(badConfig))
 			convey.So(err, convey.ShouldBeError, fmt.Errorf("no user or password specified under [client] in %s", badConfig))
@@ -121,6 +153,412 @@ func TestParseMycnf(t *testing.T) {
 	})
 }
 
+func TestParseMycnfSSHTunnel(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile, err := ioutil.TempFile("", "mysqld_exporter_ssh_key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(keyFile.Name())
+	pem.Encode(keyFile, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	keyFile.Close()
+
+	publicKey, err := ssh.NewPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey)))
+	knownHostsFile, err := ioutil.TempFile("", "mysqld_exporter_known_hosts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(knownHostsFile.Name())
+	fmt.Fprintf(knownHostsFile, "bastion.example.com %s\n", hostKey)
+	knownHostsFile.Close()
+
+	convey.Convey("SSH tunnel", t, func() {
+		convey.Convey("Tunneled connection", func() {
+			dsn, err := parseMycnf([]byte(fmt.Sprintf(`
+				[client]
+				user = root
+				password = abc123
+				host = db1.internal
+				ssh-host = bastion.example.com
+				ssh-user = exporter
+				ssh-key = %s
+				ssh-host-key = %s
+			`, keyFile.Name(), hostKey)))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(dsn, convey.ShouldEqual, "root:abc123@ssh(db1.internal:3306)/")
+
+			dsn, err = dsnForTarget(dsn, "db2.internal:3306")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(dsn, convey.ShouldEqual, "root:abc123@ssh(db2.internal:3306)/")
+		})
+		convey.Convey("Known hosts file", func() {
+			dsn, err := parseMycnf([]byte(fmt.Sprintf(`
+				[client]
+				user = root
+				password = abc123
+				host = db1.internal
+				ssh-host = bastion.example.com
+				ssh-key = %s
+				ssh-known-hosts = %s
+			`, keyFile.Name(), knownHostsFile.Name())))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(dsn, convey.ShouldEqual, "root:abc123@ssh(db1.internal:3306)/")
+		})
+		convey.Convey("Unverified host key", func() {
+			_, err := parseMycnf([]byte(fmt.Sprintf(`
+				[client]
+				user = root
+				password = abc123
+				ssh-host = bastion.example.com
+				ssh-key = %s
+			`, keyFile.Name())))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("Combined with a proxy", func() {
+			_, err := parseMycnf([]byte(fmt.Sprintf(`
+				[client]
+				user = root
+				password = abc123
+				ssh-host = bastion.example.com
+				ssh-key = %s
+				proxy-url = socks5://proxy.example.com:1080
+			`, keyFile.Name())))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("Missing key", func() {
+			_, err := parseMycnf([]byte(`
+				[client]
+				user = root
+				password = abc123
+				ssh-host = bastion.example.com
+				ssh-key = /nonexistent/id_rsa
+			`))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestParseMycnfProxy(t *testing.T) {
+	convey.Convey("Proxied connection", t, func() {
+		dsn, err := parseMycnf([]byte(`
+			[client]
+			user = root
+			password = abc123
+			host = db1.internal
+			proxy-url = socks5://proxy.example.com:1080
+		`))
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(dsn, convey.ShouldEqual, "root:abc123@proxy(db1.internal:3306)/")
+
+		dsn, err = dsnForTarget(dsn, "db2.internal:3306")
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(dsn, convey.ShouldEqual, "root:abc123@proxy(db2.internal:3306)/")
+	})
+}
+
+func TestParseMycnfFailover(t *testing.T) {
+	convey.Convey("Multiple hosts", t, func() {
+		convey.Convey("Failover connection", func() {
+			dsn, err := parseMycnf([]byte(`
+				[client]
+				user = root
+				password = abc123
+				host = db1.internal,db2.internal:3307
+				prefer-replica = true
+			`))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(dsn, convey.ShouldEqual, "root:abc123@failover(db1.internal:3306,db2.internal:3307)/")
+		})
+		convey.Convey("Combined with a proxy", func() {
+			_, err := parseMycnf([]byte(`
+				[client]
+				user = root
+				password = abc123
+				host = db1.internal,db2.internal
+				proxy-url = socks5://proxy.example.com:1080
+			`))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("SSL without a server name", func() {
+			_, err := parseMycnf([]byte(`
+				[client]
+				user = root
+				password = abc123
+				host = db1.internal,db2.internal
+				tls-min-version = TLSv1.2
+			`))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("SSL with a server name", func() {
+			dsn, err := parseMycnf([]byte(`
+				[client]
+				user = root
+				password = abc123
+				host = db1.internal,db2.internal
+				tls-min-version = TLSv1.2
+				tls-server-name = mysql.internal
+			`))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(dsn, convey.ShouldEqual, "root:abc123@failover(db1.internal:3306,db2.internal:3306)/?tls=custom")
+		})
+	})
+}
+
+func TestParseMycnfServerPubKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile, err := ioutil.TempFile("", "mysqld_exporter_public_key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(keyFile.Name())
+	pem.Encode(keyFile, &pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	keyFile.Close()
+
+	convey.Convey("Authentication without TLS", t, func() {
+		convey.Convey("Server public key", func() {
+			dsn, err := parseMycnf([]byte(fmt.Sprintf(`
+				[client]
+				user = root
+				password = abc123
+				server-public-key-path = %s
+			`, keyFile.Name())))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(dsn, convey.ShouldEqual, "root:abc123@tcp(localhost:3306)/?serverPubKey=custom")
+			_, err = mysql.ParseDSN(dsn)
+			convey.So(err, convey.ShouldBeNil)
+		})
+		convey.Convey("Invalid server public key", func() {
+			_, err := parseMycnf([]byte(`
+				[client]
+				user = root
+				password = abc123
+				server-public-key-path = /nonexistent/public_key.pem
+			`))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("Cleartext plugin", func() {
+			dsn, err := parseMycnf([]byte(`
+				[client]
+				user = root
+				password = abc123
+				enable-cleartext-plugin
+			`))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(dsn, convey.ShouldEqual, "root:abc123@tcp(localhost:3306)/?allowCleartextPasswords=true")
+		})
+	})
+}
+
+func TestParseMycnfSections(t *testing.T) {
+	const config = `
+		[client]
+		user = root
+		password = abc123
+
+		[client.db2]
+		host = db2.example.com
+		tls-min-version = TLSv1.2
+		tls-cipher-suites = TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384
+		tls-server-name = mysql.example.com
+
+		[client.db3]
+		user = monitor
+		password = secret
+		tls-min-version = SSLv3
+	`
+	convey.Convey("Client sections", t, func() {
+		convey.Convey("Section inheriting from [client]", func() {
+			cfg, err := loadMycnf([]byte(config))
+			convey.So(err, convey.ShouldBeNil)
+			dsn, err := formDSN(cfg, "client.db2", config)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(dsn, convey.ShouldEqual, "root:abc123@tcp(db2.example.com:3306)/?tls=custom-db2")
+			_, err = mysql.ParseDSN(dsn)
+			convey.So(err, convey.ShouldBeNil)
+		})
+		convey.Convey("Invalid TLS version", func() {
+			_, err := parseMycnfSections([]byte(config))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("All sections", func() {
+			dsns, err := parseMycnfSections([]byte(`
+				[client]
+				user = root
+				password = abc123
+
+				[client.db2]
+				host = db2.example.com
+
+				[mysql]
+				skip-auto-rehash
+			`))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(dsns, convey.ShouldResemble, map[string]string{
+				"client.db2": "root:abc123@tcp(db2.example.com:3306)/",
+			})
+		})
+	})
+}
+
+func TestCustomizeTLS(t *testing.T) {
+	convey.Convey("TLS options", t, func() {
+		convey.So(customizeTLS("test", tlsOptions{minVersion: "TLSv1.3"}), convey.ShouldBeNil)
+		convey.So(customizeTLS("test", tlsOptions{cipherSuites: "TLS_NULL"}), convey.ShouldNotBeNil)
+		convey.So(customizeTLS("test", tlsOptions{ca: "/nonexistent/ca.pem"}), convey.ShouldNotBeNil)
+	})
+}
+
+func TestAddDSNParams(t *testing.T) {
+	convey.Convey("Extra DSN parameters", t, func() {
+		convey.Convey("No parameters", func() {
+			dsn, err := addDSNParams("root@tcp(localhost:3306)/", "")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(dsn, convey.ShouldEqual, "root@tcp(localhost:3306)/")
+		})
+		convey.Convey("Appended to existing parameters", func() {
+			dsn, err := addDSNParams("root@tcp(localhost:3306)/?tls=skip-verify", "writeTimeout=5s")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(dsn, convey.ShouldEqual, "root@tcp(localhost:3306)/?tls=skip-verify&writeTimeout=5s")
+		})
+		convey.Convey("Invalid parameter value", func() {
+			_, err := addDSNParams("root@tcp(localhost:3306)/", "readTimeout=soon")
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("Charset fallback list", func() {
+			dsn, err := addDSNParams("root@tcp(localhost:3306)/", charsetParams("utf8mb4,utf8", ""))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(dsn, convey.ShouldEqual, "root@tcp(localhost:3306)/?charset=utf8mb4%2Cutf8")
+		})
+	})
+}
+
+func TestDSNForTarget(t *testing.T) {
+	convey.Convey("DSN for probe targets", t, func() {
+		convey.Convey("TCP DSN", func() {
+			dsn, err := dsnForTarget("root:abc123@tcp(localhost:3306)/", "db1.example.com:3307")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(dsn, convey.ShouldEqual, "root:abc123@tcp(db1.example.com:3307)/")
+		})
+		convey.Convey("Socket DSN with TLS", func() {
+			dsn, err := dsnForTarget("user:pass@unix(/var/lib/mysql/mysql.sock)/?tls=skip-verify", "10.0.0.1:3306")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(dsn, convey.ShouldEqual, "user:pass@tcp(10.0.0.1:3306)/?tls=skip-verify")
+		})
+		convey.Convey("Unix socket target", func() {
+			dsn, err := dsnForTarget("root:abc123@tcp(localhost:3306)/", "unix:///var/run/mysqld/mysqld.sock")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(dsn, convey.ShouldEqual, "root:abc123@unix(/var/run/mysqld/mysqld.sock)/")
+		})
+		convey.Convey("Unix socket target without path", func() {
+			_, err := dsnForTarget("root:abc123@tcp(localhost:3306)/", "unix://")
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestProbeTargetAllowed(t *testing.T) {
+	defer func() {
+		probeAllowedTargetsRE = nil
+		scrapeTargets = nil
+	}()
+	scrapeTargets = []scrapeTarget{{address: "db1.example.com:3306"}}
+
+	convey.Convey("Probe targets", t, func() {
+		convey.Convey("Configured target", func() {
+			convey.So(probeTargetAllowed("db1.example.com:3306"), convey.ShouldBeTrue)
+			convey.So(probeTargetAllowed("db2.example.com:3306"), convey.ShouldBeFalse)
+		})
+		convey.Convey("Allowed by the regular expression", func() {
+			probeAllowedTargetsRE = regexp.MustCompile("^(?:db[0-9]+\\.example\\.com:3306)$")
+			convey.So(probeTargetAllowed("db2.example.com:3306"), convey.ShouldBeTrue)
+			convey.So(probeTargetAllowed("db2.example.com:3306.attacker.com:3306"), convey.ShouldBeFalse)
+		})
+	})
+}
+
+func TestMetricsForTargetExpiry(t *testing.T) {
+	*probeTargetExpiry = time.Hour
+	defer func() {
+		*probeTargetExpiry = 0
+		targetMetrics = map[string]*probedTarget{}
+	}()
+
+	convey.Convey("Probed target metrics", t, func() {
+		now := time.Now()
+		first := metricsForTarget("db1.example.com:3306", now)
+		metricsForTarget("db2.example.com:3306", now.Add(time.Minute))
+		convey.So(metricsForTarget("db1.example.com:3306", now.Add(time.Minute)).TotalScrapes, convey.ShouldEqual, first.TotalScrapes)
+
+		metricsForTarget("db1.example.com:3306", now.Add(time.Hour+2*time.Minute))
+		convey.So(targetMetrics, convey.ShouldHaveLength, 1)
+		convey.So(targetMetrics, convey.ShouldContainKey, "db1.example.com:3306")
+	})
+}
+
+func TestServeMetricsStrict(t *testing.T) {
+	up := prometheus.NewGauge(prometheus.GaugeOpts{Name: "mysql_up", Help: "Whether the MySQL server is up."})
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(up)
+
+	convey.Convey("Strict probe", t, func() {
+		convey.Convey("MySQL down", func() {
+			rec := httptest.NewRecorder()
+			serveMetrics(rec, httptest.NewRequest("GET", "/metrics", nil), registry, true)
+			convey.So(rec.Code, convey.ShouldEqual, http.StatusServiceUnavailable)
+		})
+		convey.Convey("MySQL up", func() {
+			up.Set(1)
+			rec := httptest.NewRecorder()
+			serveMetrics(rec, httptest.NewRequest("GET", "/metrics", nil), registry, true)
+			convey.So(rec.Code, convey.ShouldEqual, http.StatusOK)
+			convey.So(rec.Body.String(), convey.ShouldContainSubstring, "mysql_up 1")
+		})
+		convey.Convey("OpenMetrics", func() {
+			up.Set(1)
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/metrics", nil)
+			req.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+			serveMetrics(rec, req, registry, true)
+			convey.So(rec.Header().Get("Content-Type"), convey.ShouldStartWith, "application/openmetrics-text")
+			convey.So(rec.Body.String(), convey.ShouldEndWith, "# EOF\n")
+		})
+	})
+}
+
+func TestServeMetricsStrictTargets(t *testing.T) {
+	*strictProbe = true
+	defer func() {
+		*strictProbe = false
+		scrapeTargets = nil
+	}()
+	scrapeTargets = []scrapeTarget{
+		{address: "127.0.0.1:1", dsn: "root@tcp(localhost:3306)/", labels: prometheus.Labels{"target": "127.0.0.1:1"}, metrics: collector.NewMetrics()},
+		{address: "127.0.0.1:2", dsn: "root@tcp(localhost:3306)/", labels: prometheus.Labels{"target": "127.0.0.1:2"}, metrics: collector.NewMetrics()},
+	}
+
+	convey.Convey("Strict probe with several targets", t, func() {
+		rec := httptest.NewRecorder()
+		newHandler(collector.NewMetrics(), nil)(rec, httptest.NewRequest("GET", "/metrics", nil))
+		convey.So(rec.Code, convey.ShouldEqual, http.StatusOK)
+		convey.So(rec.Body.String(), convey.ShouldContainSubstring, `mysql_up{target="127.0.0.1:1"} 0`)
+		convey.So(rec.Body.String(), convey.ShouldContainSubstring, `mysql_up{target="127.0.0.1:2"} 0`)
+	})
+}
+
 // bin stores information about path of executable and attached port
 type bin struct {
 	path string
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"plugin"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"gopkg.in/alecthomas/kingpin.v2"
+
+	"github.com/prometheus/mysqld_exporter/collector"
+)
+
+var pluginPaths = kingpin.Flag(
+	"plugin",
+	"Path of a Go plugin providing additional collectors. Can be repeated.",
+).Strings()
+
+// pluginSymbol is the function a plugin exports to provide its collectors:
+//
+//	func Scrapers() []collector.Scraper
+//
+// The collectors of a plugin are always enabled and can be selected with
+// the collect[] parameters like the built-in ones.
+const pluginSymbol = "Scrapers"
+
+// Plugin health metrics.
+var (
+	pluginUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "mysql",
+		Subsystem: "exporter",
+		Name:      "plugin_up",
+		Help:      "Whether the plugin was loaded successfully.",
+	}, []string{"plugin"})
+	pluginPanics = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "mysql",
+		Subsystem: "exporter",
+		Name:      "plugin_panics_total",
+		Help:      "Total number of times a plugin collector panicked while scraping.",
+	}, []string{"plugin", "collector"})
+)
+
+func init() {
+	prometheus.MustRegister(pluginUp, pluginPanics)
+}
+
+// pluginScraper recovers from the panics of a plugin collector, so that a
+// faulty plugin fails its own scrape only.
+type pluginScraper struct {
+	collector.Scraper
+	plugin string
+}
+
+// Scrape collects data from database connection and sends it over channel as prometheus metric.
+func (s pluginScraper) Scrape(db *sql.DB, ch chan<- prometheus.Metric) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			pluginPanics.WithLabelValues(s.plugin, s.Name()).Inc()
+			err = fmt.Errorf("plugin %s panicked: %v", s.plugin, r)
+		}
+	}()
+	return s.Scraper.Scrape(db, ch)
+}
+
+// pluginName returns the name of the plugin file without its extension.
+func pluginName(path string) string {
+	name := filepath.Base(path)
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+// lookupPluginScrapers returns the collectors exported by the plugin symbol.
+func lookupPluginScrapers(name string, lookup func(string) (plugin.Symbol, error)) ([]collector.Scraper, error) {
+	sym, err := lookup(pluginSymbol)
+	if err != nil {
+		return nil, err
+	}
+	scrapersFunc, ok := sym.(func() []collector.Scraper)
+	if !ok {
+		return nil, fmt.Errorf("%s is %T, not func() []collector.Scraper", pluginSymbol, sym)
+	}
+	var pluginScrapers []collector.Scraper
+	for _, scraper := range scrapersFunc() {
+		pluginScrapers = append(pluginScrapers, pluginScraper{Scraper: scraper, plugin: name})
+	}
+	return pluginScrapers, nil
+}
+
+// loadPlugins opens the plugins and returns their collectors. A plugin that
+// cannot be loaded is reported by mysql_exporter_plugin_up and skipped.
+func loadPlugins(paths []string, builtin map[collector.Scraper]bool) []collector.Scraper {
+	names := map[string]bool{}
+	for scraper := range builtin {
+		names[scraper.Name()] = true
+	}
+	var loaded []collector.Scraper
+	for _, path := range paths {
+		name := pluginName(path)
+		pluginUp.WithLabelValues(name).Set(0)
+		p, err := plugin.Open(path)
+		if err != nil {
+			log.Errorf("Error loading plugin %s: %s", path, err)
+			continue
+		}
+		pluginScrapers, err := lookupPluginScrapers(name, p.Lookup)
+		if err != nil {
+			log.Errorf("Error loading plugin %s: %s", path, err)
+			continue
+		}
+		if err := checkPluginNames(pluginScrapers, names); err != nil {
+			log.Errorf("Error loading plugin %s: %s", path, err)
+			continue
+		}
+		for _, scraper := range pluginScrapers {
+			names[scraper.Name()] = true
+		}
+		loaded = append(loaded, pluginScrapers...)
+		pluginUp.WithLabelValues(name).Set(1)
+	}
+	return loaded
+}
+
+// checkPluginNames returns an error if a collector of the plugin has the name
+// of a built-in collector or one of another plugin.
+func checkPluginNames(pluginScrapers []collector.Scraper, names map[string]bool) error {
+	for _, scraper := range pluginScrapers {
+		if names[scraper.Name()] {
+			return fmt.Errorf("collector %s already exists", scraper.Name())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"plugin"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+
+	"github.com/prometheus/mysqld_exporter/collector"
+)
+
+type panickingScraper struct{}
+
+func (panickingScraper) Name() string { return "internal_schema" }
+func (panickingScraper) Help() string { return "Collect from the internal schema" }
+func (panickingScraper) Scrape(db *sql.DB, ch chan<- prometheus.Metric) error {
+	panic("nil map")
+}
+
+func TestPluginScrapers(t *testing.T) {
+	convey.Convey("Plugin collectors", t, func() {
+		convey.Convey("Exported by the plugin symbol", func() {
+			scrapersFunc := func() []collector.Scraper { return []collector.Scraper{panickingScraper{}} }
+			got, err := lookupPluginScrapers("internal", func(string) (plugin.Symbol, error) { return scrapersFunc, nil })
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(got, convey.ShouldResemble, []collector.Scraper{pluginScraper{Scraper: panickingScraper{}, plugin: "internal"}})
+		})
+		convey.Convey("Symbol of the wrong type", func() {
+			_, err := lookupPluginScrapers("internal", func(string) (plugin.Symbol, error) { return new(int), nil })
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("Missing symbol", func() {
+			_, err := lookupPluginScrapers("internal", func(string) (plugin.Symbol, error) { return nil, errors.New("not found") })
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("Panics are recovered and counted", func() {
+			err := pluginScraper{Scraper: panickingScraper{}, plugin: "internal"}.Scrape(nil, nil)
+			convey.So(err, convey.ShouldNotBeNil)
+			m := &dto.Metric{}
+			pluginPanics.WithLabelValues("internal", "internal_schema").Write(m)
+			convey.So(m.GetCounter().GetValue(), convey.ShouldEqual, 1)
+		})
+		convey.Convey("Names of the built-in collectors are taken", func() {
+			names := map[string]bool{"global_status": true}
+			convey.So(checkPluginNames([]collector.Scraper{collector.ScrapeGlobalStatus{}}, names), convey.ShouldNotBeNil)
+			convey.So(checkPluginNames([]collector.Scraper{panickingScraper{}}, names), convey.ShouldBeNil)
+		})
+		convey.Convey("Plugins that cannot be opened are down", func() {
+			convey.So(loadPlugins([]string{"/nonexistent/vendor.so"}, scrapers), convey.ShouldBeEmpty)
+			m := &dto.Metric{}
+			pluginUp.WithLabelValues("vendor").Write(m)
+			convey.So(m.GetGauge().GetValue(), convey.ShouldEqual, 0)
+		})
+	})
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"golang.org/x/net/proxy"
+)
+
+// proxyNet is the network name of the DSN address for connections made
+// through the outbound proxy, e.g. user:password@proxy(db1:3306)/.
+const proxyNet = "proxy"
+
+func init() {
+	proxy.RegisterDialerType("http", newHTTPConnectDialer)
+}
+
+// registerProxy registers the dialer of the named proxy network with the MySQL
+// driver. SOCKS5 (socks5://) and HTTP CONNECT (http://) proxies are supported.
+func registerProxy(network, proxyURL string) error {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return err
+	}
+	dialer, err := proxy.FromURL(u, &net.Dialer{Timeout: 10 * time.Second})
+	if err != nil {
+		return err
+	}
+	mysql.RegisterDial(network, func(addr string) (net.Conn, error) {
+		return dialer.Dial("tcp", addr)
+	})
+	return nil
+}
+
+// httpConnectDialer opens connections through an HTTP proxy with the CONNECT method.
+type httpConnectDialer struct {
+	addr    string
+	auth    string
+	forward proxy.Dialer
+}
+
+func newHTTPConnectDialer(u *url.URL, forward proxy.Dialer) (proxy.Dialer, error) {
+	d := &httpConnectDialer{addr: u.Host, forward: forward}
+	if u.Port() == "" {
+		d.addr = net.JoinHostPort(u.Hostname(), "80")
+	}
+	if u.User != nil {
+		password, _ := u.User.Password()
+		d.auth = "Basic " + base64.StdEncoding.EncodeToString([]byte(u.User.Username()+":"+password))
+	}
+	return d, nil
+}
+
+func (d *httpConnectDialer) Dial(network, addr string) (net.Conn, error) {
+	conn, err := d.forward.Dial(network, d.addr)
+	if err != nil {
+		return nil, err
+	}
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+	if d.auth != "" {
+		req.Header.Set("Proxy-Authorization", d.auth)
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	// The MySQL server speaks first, so its handshake may already be
+	// buffered along with the response.
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxy %s refused CONNECT to %s: %s", d.addr, addr, resp.Status)
+	}
+	return &bufferedConn{Conn: conn, r: br}, nil
+}
+
+// bufferedConn reads what was buffered while reading the CONNECT response first.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
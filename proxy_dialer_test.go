@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/proxy"
+)
+
+func TestHTTPConnectDialer(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	requests := make(chan *http.Request, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		req, err := http.ReadRequest(bufio.NewReader(conn))
+		if err != nil {
+			return
+		}
+		requests <- req
+		// The server greeting follows the response right away.
+		conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\ngreeting"))
+	}()
+
+	u, _ := url.Parse("http://exporter:secret@" + l.Addr().String())
+	dialer, err := proxy.FromURL(u, proxy.Direct)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	convey.Convey("HTTP CONNECT proxy", t, func() {
+		conn, err := dialer.Dial("tcp", "db1:3306")
+		convey.So(err, convey.ShouldBeNil)
+		defer conn.Close()
+
+		req := <-requests
+		convey.So(req.Method, convey.ShouldEqual, http.MethodConnect)
+		convey.So(req.Host, convey.ShouldEqual, "db1:3306")
+		convey.So(req.Header.Get("Proxy-Authorization"), convey.ShouldEqual, "Basic ZXhwb3J0ZXI6c2VjcmV0")
+
+		greeting, err := ioutil.ReadAll(conn)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(string(greeting), convey.ShouldEqual, "greeting")
+	})
+}
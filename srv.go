@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// srvScheme prefixes targets resolved through DNS SRV records at scrape time,
+// e.g. srv://_mysql._tcp.cluster.example.com.
+const srvScheme = "srv://"
+
+var srvRefreshInterval = kingpin.Flag(
+	"mysqld.srv-refresh-interval",
+	"Interval at which the DNS SRV records of srv:// addresses are looked up again.",
+).Default("30s").Duration()
+
+// lookupSRV returns the SRV records of name, sorted by priority and
+// randomized by weight. It can be replaced in tests.
+var lookupSRV = func(name string) ([]*net.SRV, error) {
+	_, addrs, err := net.LookupSRV("", "", name)
+	return addrs, err
+}
+
+// srvCache keeps resolved SRV targets for srvRefreshInterval.
+var srvCache = struct {
+	sync.Mutex
+	entries map[string]srvEntry
+}{entries: map[string]srvEntry{}}
+
+type srvEntry struct {
+	addr    string
+	expires time.Time
+}
+
+// resolveSRV returns the host:port of the preferred SRV record of the target,
+// using the cached address until the refresh interval has passed.
+func resolveSRV(target string) (string, error) {
+	name := strings.TrimPrefix(target, srvScheme)
+	if name == "" {
+		return "", fmt.Errorf("missing SRV name in target %s", target)
+	}
+
+	srvCache.Lock()
+	defer srvCache.Unlock()
+	if entry, ok := srvCache.entries[name]; ok && time.Now().Before(entry.expires) {
+		return entry.addr, nil
+	}
+	addrs, err := lookupSRV(name)
+	if err == nil && len(addrs) == 0 {
+		err = fmt.Errorf("no SRV records found")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve SRV record %s: %s", name, err)
+	}
+	// The records come sorted by priority, the first one is preferred.
+	addr := net.JoinHostPort(strings.TrimSuffix(addrs[0].Target, "."), fmt.Sprint(addrs[0].Port))
+	srvCache.entries[name] = srvEntry{addr: addr, expires: time.Now().Add(*srvRefreshInterval)}
+	return addr, nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestResolveSRV(t *testing.T) {
+	defer func(f func(string) ([]*net.SRV, error)) { lookupSRV = f }(lookupSRV)
+	defer func(interval time.Duration) { *srvRefreshInterval = interval }(*srvRefreshInterval)
+
+	lookups := 0
+	lookupSRV = func(name string) ([]*net.SRV, error) {
+		lookups++
+		switch name {
+		case "_mysql._tcp.cluster.example.com":
+			return []*net.SRV{
+				{Target: "db1.example.com.", Port: 3306, Priority: 10, Weight: 10},
+				{Target: "db2.example.com.", Port: 3307, Priority: 20, Weight: 10},
+			}, nil
+		case "_mysql._tcp.empty.example.com":
+			return nil, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	convey.Convey("SRV targets", t, func() {
+		convey.Convey("Cached for the refresh interval", func() {
+			*srvRefreshInterval = time.Minute
+			srvCache.entries = map[string]srvEntry{}
+			lookups = 0
+			for i := 0; i < 2; i++ {
+				dsn, err := dsnForTarget("root:abc123@tcp(localhost:3306)/", "srv://_mysql._tcp.cluster.example.com")
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(dsn, convey.ShouldEqual, "root:abc123@tcp(db1.example.com:3306)/")
+			}
+			convey.So(lookups, convey.ShouldEqual, 1)
+		})
+		convey.Convey("No caching", func() {
+			*srvRefreshInterval = 0
+			srvCache.entries = map[string]srvEntry{}
+			lookups = 0
+			for i := 0; i < 2; i++ {
+				addr, err := resolveSRV("srv://_mysql._tcp.cluster.example.com")
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(addr, convey.ShouldEqual, "db1.example.com:3306")
+			}
+			convey.So(lookups, convey.ShouldEqual, 2)
+		})
+		convey.Convey("No records", func() {
+			_, err := resolveSRV("srv://_mysql._tcp.empty.example.com")
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("Unknown name", func() {
+			_, err := dsnForTarget("root:abc123@tcp(localhost:3306)/", "srv://_mysql._tcp.unknown.example.com")
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/prometheus/common/log"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// sshNet is the network name of the DSN address for connections tunneled
+// through the SSH bastion, e.g. user:password@ssh(db1:3306)/.
+const sshNet = "ssh"
+
+// sshTunnel dials MySQL servers through an SSH bastion host, reusing a single
+// SSH connection for all of them.
+type sshTunnel struct {
+	addr   string
+	config *ssh.ClientConfig
+
+	mtx    sync.Mutex
+	client *ssh.Client
+}
+
+// registerSSHTunnel registers the dialer of the named ssh network with the MySQL driver.
+// The host key of the bastion must be given, either directly or as a
+// known_hosts file.
+func registerSSHTunnel(network, host, user, keyFile, hostKey, knownHostsFile string) error {
+	pemKey, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return err
+	}
+	signer, err := ssh.ParsePrivateKey(pemKey)
+	if err != nil {
+		return fmt.Errorf("failed to parse SSH key %s: %s", keyFile, err)
+	}
+
+	var hostKeyCallback ssh.HostKeyCallback
+	switch {
+	case hostKey != "" && knownHostsFile != "":
+		return fmt.Errorf("ssh-host-key and ssh-known-hosts cannot be combined")
+	case hostKey != "":
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hostKey))
+		if err != nil {
+			return fmt.Errorf("failed to parse SSH host key: %s", err)
+		}
+		hostKeyCallback = ssh.FixedHostKey(key)
+	case knownHostsFile != "":
+		if hostKeyCallback, err = knownhosts.New(knownHostsFile); err != nil {
+			return fmt.Errorf("failed to read SSH known hosts %s: %s", knownHostsFile, err)
+		}
+	default:
+		return fmt.Errorf("no ssh-host-key or ssh-known-hosts set for SSH bastion %s", host)
+	}
+
+	if !strings.Contains(host, ":") {
+		host = net.JoinHostPort(host, "22")
+	}
+	tunnel := &sshTunnel{
+		addr: host,
+		config: &ssh.ClientConfig{
+			User:            user,
+			Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+			HostKeyCallback: hostKeyCallback,
+			Timeout:         10 * time.Second,
+		},
+	}
+	mysql.RegisterDial(network, tunnel.dial)
+	return nil
+}
+
+// dial opens a connection to addr through the bastion, reconnecting to the
+// bastion once if the SSH connection was lost.
+func (t *sshTunnel) dial(addr string) (net.Conn, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		if t.client == nil {
+			client, err := ssh.Dial("tcp", t.addr, t.config)
+			if err != nil {
+				return nil, fmt.Errorf("failed to connect to SSH bastion %s: %s", t.addr, err)
+			}
+			t.client = client
+		}
+		conn, err := t.client.Dial("tcp", addr)
+		if err == nil || attempt > 0 {
+			return conn, err
+		}
+		log.Debugf("Reconnecting to SSH bastion %s: %s", t.addr, err)
+		t.client.Close()
+		t.client = nil
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/log"
+
+	"github.com/prometheus/mysqld_exporter/collector"
+)
+
+// scrapeTarget is a server listed in the .my.cnf file to be scraped on /metrics.
+type scrapeTarget struct {
+	address string
+	// dsn is the DSN of the client section listing the target.
+	dsn     string
+	labels  prometheus.Labels
+	metrics collector.Metrics
+}
+
+// scrapeTargets are scraped on /metrics instead of the server of the DSN, if any.
+var scrapeTargets []scrapeTarget
+
+// parseMycnfTargets returns the servers listed by the targets key of the
+// [client] and [client.<name>] sections, given the DSN of every section.
+func parseMycnfTargets(config interface{}, dsns map[string]string) ([]scrapeTarget, error) {
+	cfg, err := loadMycnf(config)
+	if err != nil {
+		return nil, err
+	}
+	var targets []scrapeTarget
+	for _, section := range cfg.SectionStrings() {
+		// Only the keys of the section itself, a [client.<name>] section
+		// does not inherit the targets of [client].
+		list, ok := cfg.Section(section).KeysHash()["targets"]
+		dsn, known := dsns[section]
+		if !ok || !known {
+			continue
+		}
+		labels, err := parseLabels(cfg.Section(section).Key("labels").String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse labels under [%s] in %s: %s", section, config, err)
+		}
+		for _, address := range strings.Split(list, ",") {
+			address = strings.TrimSpace(address)
+			if address == "" {
+				continue
+			}
+			targetLabels := prometheus.Labels{"target": address}
+			for name, value := range labels {
+				targetLabels[name] = value
+			}
+			targets = append(targets, scrapeTarget{
+				address: address,
+				dsn:     dsn,
+				labels:  targetLabels,
+				metrics: collector.NewMetrics(),
+			})
+		}
+	}
+	return targets, nil
+}
+
+// gatherTargets scrapes the targets concurrently, adding the labels of each
+// target to its metrics.
+func gatherTargets(targets []scrapeTarget, scrapers []collector.Scraper) prometheus.Gatherer {
+	return prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		gatherers := make(prometheus.Gatherers, len(targets))
+		var wg sync.WaitGroup
+		for i, target := range targets {
+			gatherers[i] = prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) { return nil, nil })
+			targetDSN, err := dsnForTarget(target.dsn, target.address)
+			if err != nil {
+				log.Errorf("Error forming DSN for target %s: %s", target.address, err)
+				continue
+			}
+			wg.Add(1)
+			go func(i int, target scrapeTarget) {
+				defer wg.Done()
+				registry := prometheus.NewRegistry()
+				registry.MustRegister(collector.New(targetDSN, target.metrics, scrapers))
+				mfs, err := withLabels(registry, target.labels).Gather()
+				gatherers[i] = prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) { return mfs, err })
+			}(i, target)
+		}
+		wg.Wait()
+		return gatherers.Gather()
+	})
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseMycnfTargets(t *testing.T) {
+	const config = `
+		[client]
+		user = root
+		password = abc123
+		targets = db1.example.com:3306, db2.example.com:3306
+
+		[client.eu]
+		targets = db3.eu.example.com:3306
+		labels = cluster=eu
+
+		[client.us]
+		host = db4.us.example.com
+	`
+	convey.Convey("Targets", t, func() {
+		targets, err := parseMycnfTargets([]byte(config), map[string]string{
+			"client":    "root:abc123@tcp(localhost:3306)/",
+			"client.eu": "root:abc123@tcp(localhost:3306)/?tls=custom-eu",
+			"client.us": "root:abc123@tcp(db4.us.example.com:3306)/",
+		})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(targets), convey.ShouldEqual, 3)
+		convey.So(targets[0].address, convey.ShouldEqual, "db1.example.com:3306")
+		convey.So(targets[1].address, convey.ShouldEqual, "db2.example.com:3306")
+		convey.So(targets[2].address, convey.ShouldEqual, "db3.eu.example.com:3306")
+		convey.So(targets[2].dsn, convey.ShouldEqual, "root:abc123@tcp(localhost:3306)/?tls=custom-eu")
+		convey.So(targets[0].labels, convey.ShouldResemble, prometheus.Labels{"target": "db1.example.com:3306"})
+		convey.So(targets[2].labels, convey.ShouldResemble, prometheus.Labels{"target": "db3.eu.example.com:3306", "cluster": "eu"})
+	})
+}
+
+func TestGatherTargets(t *testing.T) {
+	targets, err := parseMycnfTargets([]byte(`
+		[client]
+		targets = 127.0.0.1:1, 127.0.0.1:2
+	`), map[string]string{"client": "root:abc123@tcp(localhost:3306)/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	convey.Convey("Scraping all targets", t, func() {
+		mfs, err := gatherTargets(targets, nil).Gather()
+		convey.So(err, convey.ShouldBeNil)
+		up := map[string]float64{}
+		for _, mf := range mfs {
+			if mf.GetName() != "mysql_up" {
+				continue
+			}
+			for _, m := range mf.GetMetric() {
+				for _, l := range m.GetLabel() {
+					if l.GetName() == "target" {
+						up[l.GetValue()] = m.GetGauge().GetValue()
+					}
+				}
+			}
+		}
+		convey.So(up, convey.ShouldResemble, map[string]float64{"127.0.0.1:1": 0, "127.0.0.1:2": 0})
+	})
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/log"
+)
+
+// certReloader serves the client certificate of a TLS configuration,
+// reloading it when the certificate or key file changes, so rotated
+// certificates are picked up without a restart.
+type certReloader struct {
+	certFile, keyFile string
+
+	mtx             sync.Mutex
+	cert            *tls.Certificate
+	certMod, keyMod time.Time
+}
+
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// reload loads the key pair if either file was modified since the last load.
+func (r *certReloader) reload() error {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return err
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return err
+	}
+	if r.cert != nil && certInfo.ModTime().Equal(r.certMod) && keyInfo.ModTime().Equal(r.keyMod) {
+		return nil
+	}
+	keypair, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to parse pem-encoded SSL cert %s or SSL key %s: %s",
+			r.certFile, r.keyFile, err)
+	}
+	if r.cert != nil {
+		log.Infof("Reloaded SSL cert %s and SSL key %s", r.certFile, r.keyFile)
+	}
+	r.cert = &keypair
+	r.certMod, r.keyMod = certInfo.ModTime(), keyInfo.ModTime()
+	return nil
+}
+
+// GetClientCertificate implements tls.Config.GetClientCertificate. The last
+// good certificate is kept if the files cannot be loaded, e.g. while they are
+// being rewritten.
+func (r *certReloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if err := r.reload(); err != nil {
+		log.Errorln("Error reloading client certificate:", err)
+	}
+	return r.cert, nil
+}